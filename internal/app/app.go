@@ -54,15 +54,49 @@ func _genExamples() string {
   # Find the process listening on a specific port
   witr --port 5432
 
+  # Narrow a port by protocol or bind address, or list every listener in a range
+  witr --port udp/53
+  witr --port 127.0.0.1:6379
+  witr --port 8000-8100
+
   # Find the process holding a file open
   witr --file /var/lib/dpkg/lock
 
+  # Find what keeps a mount point busy ("umount: target is busy")
+  witr --file /mnt/data
+
+  # Find the editors, build tools and agents watching a file for changes
+  witr --watchers ./src/main.go
+
+  # Find what on this box talks to a database, a subnet or the metadata service
+  witr --remote 10.0.4.12:5432
+  witr --remote 10.0.4.0/24
+  witr --remote 169.254.169.254
+
+  # Inspect a systemd unit: its main process, state, restarts and other processes
+  witr --unit nginx
+
+  # Summarize everything a user runs, before deprovisioning the account
+  witr --user alice
+
+  # Analyze every process in a cgroup, or draw how cgroups carve up the machine
+  witr --cgroup /system.slice/docker.service
+  witr --cgroup / --tree
+
   # Inspect a container by name
   witr --container redis
 
   # Inspect a process by name with exact matching (no fuzzy search)
   witr bun --exact
 
+  # Pick one of many interpreter processes by executable or by command line
+  witr --exe /usr/bin/python3.11
+  witr --match 'celery.*worker -Q billing'
+
+  # Find every process running with a given environment variable
+  witr --env-match AWS_PROFILE=prod
+  witr --env-match 'NODE_ENV=dev*'
+
   # Show the full process ancestry (who started whom)
   witr postgres --tree
 
@@ -72,6 +106,9 @@ func _genExamples() string {
   # Display only environment variables of the process
   witr node --env
 
+  # Show only the variables a process added, changed or removed vs. its parent
+  witr node --env-diff
+
   # Short, single-line output (useful for scripts)
   witr sshd --short
 
@@ -84,6 +121,18 @@ func _genExamples() string {
   # Show extended process information (memory, I/O, file descriptors)
   witr mysql --verbose
 
+  # Find the busy thread of a process (per-thread CPU, state, wait channel)
+  witr java --threads
+
+  # List services, containers and sessions still running upgraded libraries
+  witr --needs-restart
+
+  # Look for processes hidden from the /proc listing (rootkit symptom)
+  sudo witr --audit-hidden
+
+  # Find deleted files still taking disk space ("df says full, du disagrees")
+  sudo witr --deleted-files
+
   # Combine flags: inspect port, show environment variables, output JSON
   witr --port 8080 --env --json
 
@@ -142,21 +191,38 @@ func init() {
 	rootCmd.SetErr(output.NewSafeTerminalWriter(os.Stderr))
 
 	rootCmd.Flags().StringSliceP("pid", "p", nil, "pid(s) to look up (repeatable)")
-	rootCmd.Flags().StringSliceP("port", "o", nil, "port(s) to look up (repeatable)")
-	rootCmd.Flags().StringSliceP("file", "f", nil, "file(s) held open by a process (repeatable)")
+	rootCmd.Flags().StringSliceP("port", "o", nil, "port(s) to look up, optionally as proto/port, addr:port or a range such as 8000-8100 (repeatable)")
+	rootCmd.Flags().StringSliceP("file", "f", nil, "file(s) held open by a process, or directories/mount points to list every process keeping them busy (repeatable)")
 	rootCmd.Flags().StringSliceP("container", "c", nil, "container(s) to look up (repeatable)")
+	rootCmd.Flags().StringSlice("socket", nil, "unix socket path(s) to find the serving process of (repeatable)")
+	rootCmd.Flags().StringSlice("watchers", nil, "path(s) to find the processes holding inotify or fanotify watches on (repeatable)")
+	rootCmd.Flags().StringSlice("unit", nil, "systemd unit(s) to look up; a name without a suffix is a .service (repeatable)")
+	rootCmd.Flags().StringSlice("cgroup", nil, "cgroup v2 path(s) whose processes to analyze; with --tree, draw the cgroup subtree (repeatable)")
+	rootCmd.Flags().StringSlice("user", nil, "user name(s) or UID(s) whose processes to summarize, grouped by what started them (repeatable)")
+	rootCmd.Flags().StringSlice("exe", nil, "executable path(s) to look up, matched against each process's resolved executable, deleted ones included (repeatable)")
+	rootCmd.Flags().StringArray("match", nil, "regular expression(s) matched against each process's full command line (repeatable)")
+	rootCmd.Flags().StringArray("env-match", nil, "KEY, KEY=glob or KEY=/regex/ to find every process whose environment matches (repeatable)")
+	rootCmd.Flags().StringSlice("remote", nil, "remote host[:port] or CIDR to find every process connected to (repeatable)")
 	rootCmd.Flags().BoolP("short", "s", false, "show only ancestry")
 	rootCmd.Flags().BoolP("tree", "t", false, "show only ancestry as a tree")
 	rootCmd.Flags().Bool("json", false, "show result as JSON")
 	rootCmd.Flags().Bool("warnings", false, "show only warnings")
 	rootCmd.Flags().Bool("no-color", false, "disable colorized output")
 	rootCmd.Flags().Bool("env", false, "show environment variables for the process")
+	rootCmd.Flags().Bool("env-diff", false, "show only environment variables added, changed or removed relative to the parent or systemd unit")
 	rootCmd.Flags().Bool("verbose", false, "show extended process information")
+	rootCmd.Flags().Bool("threads", false, "show per-thread CPU usage, state and wait channel")
 	rootCmd.Flags().BoolP("exact", "x", false, "use exact name matching (no substring search)")
 	rootCmd.Flags().BoolP("interactive", "i", false, "interactive mode (TUI)")
+	rootCmd.Flags().Bool("needs-restart", false, "list services, containers and sessions running deleted or replaced binaries and libraries")
+	rootCmd.Flags().Bool("audit-hidden", false, "look for processes hidden from the /proc listing by probing every PID")
+	rootCmd.Flags().Bool("deleted-files", false, "list processes holding deleted files open, with the disk space each one pins")
 
 }
 
+// targetFlagNames are the flags that each name a target to look up.
+var targetFlagNames = []string{"pid", "port", "file", "container", "socket", "watchers", "remote", "unit", "cgroup", "user", "exe", "match", "env-match"}
+
 // appFlags holds all parsed CLI flags for convenience.
 type appFlags struct {
 	short   bool
@@ -167,6 +233,8 @@ type appFlags struct {
 	verbose bool
 	exact   bool
 	env     bool
+	envDiff bool
+	threads bool
 }
 
 func runApp(cmd *cobra.Command, args []string) error {
@@ -176,17 +244,28 @@ func runApp(cmd *cobra.Command, args []string) error {
 	}
 
 	envFlag, _ := cmd.Flags().GetBool("env")
-	pidFlags, _ := cmd.Flags().GetStringSlice("pid")
-	portFlags, _ := cmd.Flags().GetStringSlice("port")
-	fileFlags, _ := cmd.Flags().GetStringSlice("file")
-	containerFlags, _ := cmd.Flags().GetStringSlice("container")
+	hasTargets := len(args) > 0
+	for _, name := range targetFlagNames {
+		// Most target flags are string slices; --match and --env-match are
+		// string arrays so that a comma inside a pattern does not split it.
+		if f := cmd.Flags().Lookup(name); f != nil {
+			if values, ok := f.Value.(interface{ GetSlice() []string }); ok && len(values.GetSlice()) > 0 {
+				hasTargets = true
+			}
+		}
+	}
+	envDiffFlag := boolFlag(cmd, "env-diff")
+	needsRestart := boolFlag(cmd, "needs-restart")
+	auditHidden := boolFlag(cmd, "audit-hidden")
+	deletedFiles := boolFlag(cmd, "deleted-files")
 
-	if !envFlag && len(pidFlags) == 0 && len(portFlags) == 0 && len(fileFlags) == 0 && len(containerFlags) == 0 && len(args) == 0 {
+	if !envFlag && !envDiffFlag && !needsRestart && !auditHidden && !deletedFiles && !hasTargets {
 		return runInteractive()
 	}
 
 	flags := appFlags{
 		env:     envFlag,
+		envDiff: envDiffFlag,
 		exact:   boolFlag(cmd, "exact"),
 		short:   boolFlag(cmd, "short"),
 		tree:    boolFlag(cmd, "tree"),
@@ -194,13 +273,35 @@ func runApp(cmd *cobra.Command, args []string) error {
 		warn:    boolFlag(cmd, "warnings"),
 		noColor: boolFlag(cmd, "no-color"),
 		verbose: boolFlag(cmd, "verbose"),
+		threads: boolFlag(cmd, "threads"),
+	}
+
+	if needsRestart {
+		if hasTargets {
+			return withExitCode(ExitInvalidInput, fmt.Errorf("--needs-restart scans every process and cannot be combined with targets"))
+		}
+		return runNeedsRestart(cmd, flags)
+	}
+
+	if auditHidden {
+		if hasTargets {
+			return withExitCode(ExitInvalidInput, fmt.Errorf("--audit-hidden scans every process and cannot be combined with targets"))
+		}
+		return runAuditHidden(cmd, flags)
+	}
+
+	if deletedFiles {
+		if hasTargets {
+			return withExitCode(ExitInvalidInput, fmt.Errorf("--deleted-files scans every process and cannot be combined with targets"))
+		}
+		return runDeletedFiles(cmd, flags)
 	}
 
 	// Collect all targets preserving command-line order
 	targets := collectTargetsInOrder(os.Args[1:], args, flagTakesValue(cmd))
 
 	if len(targets) == 0 {
-		return withExitCode(ExitInvalidInput, fmt.Errorf("must specify --pid, --port, --file, --container, or a process name"))
+		return withExitCode(ExitInvalidInput, fmt.Errorf("must specify --pid, --port, --file, --container, --socket, --watchers, --remote, --unit, --cgroup, --user, --exe, --match, --env-match, or a process name"))
 	}
 
 	outw := cmd.OutOrStdout()
@@ -225,17 +326,7 @@ func runApp(cmd *cobra.Command, args []string) error {
 
 	// Emit JSON array for multi-target
 	if flags.json && multiMode {
-		indented := make([]string, len(jsonResults))
-		for i, r := range jsonResults {
-			lines := strings.Split(r, "\n")
-			for j := range lines {
-				if j > 0 {
-					lines[j] = "  " + lines[j]
-				}
-			}
-			indented[i] = "  " + strings.Join(lines, "\n")
-		}
-		fmt.Fprintf(outw, "[\n%s\n]\n", strings.Join(indented, ",\n"))
+		fmt.Fprintln(outw, jsonArray(jsonResults))
 	}
 
 	if highestExit > ExitOK {
@@ -245,6 +336,76 @@ func runApp(cmd *cobra.Command, args []string) error {
 	return nil
 }
 
+// renderEachPID analyzes every pid as if named by --pid and renders the
+// results one after another: in text, under a divider per process when
+// there are several; in JSON, as an array, or as entries of the multi-target
+// array. decorate adds the target's own details to each result. A process
+// that exits before it is analyzed is reported and skipped.
+func renderEachPID(outw io.Writer, outp output.Printer, t model.Target, pids []int, decorate func(pid int, res *model.Result), flags appFlags, multiMode bool, jsonResults *[]string) int {
+	colorEnabled := useColor(flags, outw)
+	results := jsonResults
+	var own []string
+	if !multiMode {
+		results = &own
+	}
+
+	exit := ExitOK
+	for i, pid := range pids {
+		pidTarget := model.Target{Type: model.TargetPID, Value: strconv.Itoa(pid)}
+		if !flags.json && len(pids) > 1 {
+			printDivider(outp, pidTarget, colorEnabled, i > 0)
+		}
+		res, err := pipeline.AnalyzePID(pipeline.AnalyzeConfig{
+			PID:     pid,
+			Verbose: flags.verbose,
+			Tree:    flags.tree,
+			Threads: flags.threads,
+			JSON:    flags.json,
+			// Resampling each blocked process of a long list adds up;
+			// verbose output, which shows the samples, still takes them.
+			Quick:  len(pids) > 1 && !flags.verbose,
+			Target: t,
+		})
+		if err != nil {
+			if flags.json {
+				*results = append(*results, jsonErrorEntry(pidTarget, err.Error()))
+			} else {
+				outp.Printf("error: %v\n", err)
+			}
+			exit = max(exit, classifyError(err))
+			continue
+		}
+		if decorate != nil {
+			decorate(pid, &res)
+		}
+		renderResult(outw, res, flags, flags.json, results)
+		if len(res.Warnings) > 0 {
+			exit = max(exit, ExitWarnings)
+		}
+	}
+
+	if flags.json && !multiMode {
+		fmt.Fprintln(outw, jsonArray(own))
+	}
+	return exit
+}
+
+// jsonArray wraps already-indented JSON documents in an array, indenting
+// them one more level.
+func jsonArray(entries []string) string {
+	indented := make([]string, len(entries))
+	for i, r := range entries {
+		lines := strings.Split(r, "\n")
+		for j := range lines {
+			if j > 0 {
+				lines[j] = "  " + lines[j]
+			}
+		}
+		indented[i] = "  " + strings.Join(lines, "\n")
+	}
+	return fmt.Sprintf("[\n%s\n]", strings.Join(indented, ",\n"))
+}
+
 func boolFlag(cmd *cobra.Command, name string) bool {
 	v, _ := cmd.Flags().GetBool(name)
 	return v
@@ -286,6 +447,32 @@ func collectTargetsInOrder(rawArgs []string, positionalArgs []string, takesValue
 		"-o": model.TargetPort, "--port": model.TargetPort,
 		"-f": model.TargetFile, "--file": model.TargetFile,
 		"-c": model.TargetContainer, "--container": model.TargetContainer,
+		"--socket":    model.TargetSocket,
+		"--watchers":  model.TargetWatchers,
+		"--remote":    model.TargetRemote,
+		"--unit":      model.TargetUnit,
+		"--cgroup":    model.TargetCgroup,
+		"--user":      model.TargetUser,
+		"--exe":       model.TargetExe,
+		"--match":     model.TargetMatch,
+		"--env-match": model.TargetEnvMatch,
+	}
+
+	// A flag value may list several targets separated by commas, except a
+	// --match pattern or --env-match query, which is taken whole.
+	addTargets := func(tt model.TargetType, val string) {
+		if tt == model.TargetMatch || tt == model.TargetEnvMatch {
+			if val != "" {
+				targets = append(targets, model.Target{Type: tt, Value: val})
+			}
+			return
+		}
+		for _, v := range strings.Split(val, ",") {
+			v = strings.TrimSpace(v)
+			if v != "" {
+				targets = append(targets, model.Target{Type: tt, Value: v})
+			}
+		}
 	}
 
 	// Track which positional args we've placed so we can insert them in order
@@ -306,12 +493,7 @@ func collectTargetsInOrder(rawArgs []string, positionalArgs []string, takesValue
 				flagName := arg[:eqIdx]
 				flagVal := arg[eqIdx+1:]
 				if tt, ok := flagType[flagName]; ok {
-					for _, v := range strings.Split(flagVal, ",") {
-						v = strings.TrimSpace(v)
-						if v != "" {
-							targets = append(targets, model.Target{Type: tt, Value: v})
-						}
-					}
+					addTargets(tt, flagVal)
 				}
 				i++
 				continue
@@ -322,12 +504,7 @@ func collectTargetsInOrder(rawArgs []string, positionalArgs []string, takesValue
 		if tt, ok := flagType[arg]; ok {
 			if i+1 < len(rawArgs) {
 				i++
-				for _, v := range strings.Split(rawArgs[i], ",") {
-					v = strings.TrimSpace(v)
-					if v != "" {
-						targets = append(targets, model.Target{Type: tt, Value: v})
-					}
-				}
+				addTargets(tt, rawArgs[i])
 			}
 			i++
 			continue
@@ -372,6 +549,24 @@ func targetLabel(t model.Target) string {
 		return fmt.Sprintf("file: %s", t.Value)
 	case model.TargetContainer:
 		return fmt.Sprintf("container: %s", t.Value)
+	case model.TargetSocket:
+		return fmt.Sprintf("socket: %s", t.Value)
+	case model.TargetWatchers:
+		return fmt.Sprintf("watchers: %s", t.Value)
+	case model.TargetRemote:
+		return fmt.Sprintf("remote: %s", t.Value)
+	case model.TargetUnit:
+		return fmt.Sprintf("unit: %s", t.Value)
+	case model.TargetCgroup:
+		return fmt.Sprintf("cgroup: %s", t.Value)
+	case model.TargetUser:
+		return fmt.Sprintf("user: %s", t.Value)
+	case model.TargetExe:
+		return fmt.Sprintf("exe: %s", t.Value)
+	case model.TargetMatch:
+		return fmt.Sprintf("match: %s", t.Value)
+	case model.TargetEnvMatch:
+		return fmt.Sprintf("env-match: %s", t.Value)
 	default:
 		return fmt.Sprintf("name: %s", t.Value)
 	}
@@ -404,14 +599,54 @@ func jsonErrorEntry(t model.Target, errMsg string) string {
 func processTarget(cmd *cobra.Command, outw io.Writer, outp output.Printer, t model.Target, flags appFlags, multiMode bool, jsonResults *[]string) int {
 	colorEnabled := useColor(flags, outw)
 
-	if flags.env {
+	if t.Type == model.TargetWatchers {
+		return processWatchersTarget(outw, outp, t, flags, multiMode, jsonResults)
+	}
+
+	if t.Type == model.TargetUser {
+		return processUserTarget(outw, outp, t, flags, multiMode, jsonResults)
+	}
+
+	if t.Type == model.TargetUnit {
+		return processUnitTarget(outw, outp, t, flags, multiMode, jsonResults)
+	}
+
+	if flags.env || flags.envDiff {
 		return processEnvTarget(outw, outp, t, flags, multiMode, jsonResults)
 	}
 
+	if isDirTarget(t) {
+		return processDirTarget(outw, outp, t, flags, multiMode, jsonResults)
+	}
+
+	if t.Type == model.TargetCgroup {
+		return processCgroupTarget(outw, outp, t, flags, multiMode, jsonResults)
+	}
+
+	if t.Type == model.TargetEnvMatch {
+		return processEnvMatchTarget(outw, outp, t, flags, multiMode, jsonResults)
+	}
+
+	if t.Type == model.TargetRemote {
+		return processRemoteTarget(outw, outp, t, flags, multiMode, jsonResults)
+	}
+
 	if t.Type == model.TargetContainer {
 		return processContainerTarget(cmd, outw, outp, t, flags, multiMode, jsonResults)
 	}
 
+	// A range of ports is answered with every listener on it. An invalid
+	// value is reported by Resolve.
+	var portQuery model.PortQuery
+	if t.Type == model.TargetPort {
+		if q, err := target.ParsePort(t.Value); err == nil {
+			portQuery = q
+			if q.IsRange() {
+				return processPortListeners(outw, outp, t, q, flags, multiMode, jsonResults)
+			}
+		}
+	}
+
 	pids, err := target.Resolve(t, flags.exact)
 	if err == nil && len(pids) == 0 {
 		err = fmt.Errorf("no matching process found")
@@ -420,9 +655,22 @@ func processTarget(cmd *cobra.Command, outw io.Writer, outp output.Printer, t mo
 		return handleResolveError(cmd, outw, outp, t, err, flags, multiMode, jsonResults)
 	}
 
+	// Several processes on one port (separate binds, or workers sharing a
+	// socket) are shown as a listener table too.
+	if len(pids) > 1 && t.Type == model.TargetPort {
+		if listeners, err := pipeline.AnalyzePortListeners(portQuery); err == nil && len(listeners.Listeners) > 0 {
+			return renderPortListeners(outw, outp, listeners, flags, multiMode, jsonResults)
+		}
+	}
+
 	if len(pids) > 1 {
-		if multiMode && flags.json {
-			*jsonResults = append(*jsonResults, jsonErrorEntry(t, fmt.Sprintf("multiple processes matched (%d results)", len(pids))))
+		if flags.json {
+			entry := jsonMultiMatchEntry(t, pids)
+			if multiMode {
+				*jsonResults = append(*jsonResults, entry)
+			} else {
+				fmt.Fprintln(outw, entry)
+			}
 		} else {
 			hint := "witr --pid <pid>"
 			if flags.env {
@@ -435,19 +683,12 @@ func processTarget(cmd *cobra.Command, outw io.Writer, outp output.Printer, t mo
 
 	pid := pids[0]
 
-	var systemdService string
-	if t.Type == model.TargetPort && pid == 1 && source.IsSystemdRunning() {
-		if portNum, err := strconv.Atoi(t.Value); err == nil {
-			if svc, err := procpkg.ResolveSystemdService(portNum); err == nil && svc != "" {
-				systemdService = svc
-			}
-		}
-	}
-
 	res, err := pipeline.AnalyzePID(pipeline.AnalyzeConfig{
 		PID:     pid,
 		Verbose: flags.verbose,
 		Tree:    flags.tree,
+		Threads: flags.threads,
+		JSON:    flags.json,
 		Target:  t,
 	})
 
@@ -466,15 +707,21 @@ func processTarget(cmd *cobra.Command, outw io.Writer, outp output.Printer, t mo
 		return classifyError(err)
 	}
 
-	if systemdService != "" {
-		res.ResolvedTarget = strings.TrimSuffix(systemdService, ".service")
-	}
-
+	// Socket activation and socket state describe the socket the query
+	// matched, which carries the actual port, protocol and address.
 	if t.Type == model.TargetPort {
-		portNum := 0
-		fmt.Sscanf(t.Value, "%d", &portNum)
-		if portNum > 0 {
-			res.SocketInfo = procpkg.GetSocketStateForPort(portNum)
+		listener, ok := procpkg.PortListener(res.Process.Sockets, portQuery)
+		if !ok {
+			listener = model.Socket{Protocol: portQuery.Protocol, Address: portQuery.Address, Port: portQuery.Low}
+		}
+		if pid == 1 && source.IsSystemdRunning() {
+			if svc, err := procpkg.ResolveSystemdService(listener); err == nil && svc != "" {
+				res.ResolvedTarget = strings.TrimSuffix(svc, ".service")
+			}
+		}
+		// UDP has no connection state to report.
+		if !procpkg.IsUDP(listener.Protocol) {
+			res.SocketInfo = procpkg.GetSocketStateForPort(listener.Port, listener.Address)
 			source.EnrichSocketInfo(res.SocketInfo)
 		}
 	}
@@ -487,7 +734,7 @@ func processTarget(cmd *cobra.Command, outw io.Writer, outp output.Printer, t mo
 	return ExitOK
 }
 
-// processEnvTarget handles the --env flag for a single target.
+// processEnvTarget handles the --env and --env-diff flags for a single target.
 func processEnvTarget(outw io.Writer, outp output.Printer, t model.Target, flags appFlags, multiMode bool, jsonResults *[]string) int {
 	colorEnabled := useColor(flags, outw)
 
@@ -513,11 +760,18 @@ func processEnvTarget(outw io.Writer, outp output.Printer, t model.Target, flags
 		return ExitNotFound
 	}
 	if len(pids) > 1 {
-		printMultiMatch(outp, pids, colorEnabled, "witr --pid <pid> --env")
+		hint := "witr --pid <pid> --env"
+		if flags.envDiff {
+			hint = "witr --pid <pid> --env-diff"
+		}
+		printMultiMatch(outp, pids, colorEnabled, hint)
 		return ExitInvalidInput
 	}
 
 	pid := pids[0]
+	if flags.envDiff {
+		return processEnvDiff(outw, outp, t, pid, flags, multiMode, jsonResults)
+	}
 	procInfo, err := procpkg.ReadProcess(pid)
 	if err != nil {
 		outp.Printf("error: %v\n", err)
@@ -546,6 +800,35 @@ func processEnvTarget(outw io.Writer, outp output.Printer, t model.Target, flags
 	return ExitOK
 }
 
+// processEnvDiff renders pid's environment diff against its parent or unit.
+func processEnvDiff(outw io.Writer, outp output.Printer, t model.Target, pid int, flags appFlags, multiMode bool, jsonResults *[]string) int {
+	res, err := pipeline.DiffEnvironment(pid)
+	if err != nil {
+		if multiMode && flags.json {
+			*jsonResults = append(*jsonResults, jsonErrorEntry(t, err.Error()))
+		} else {
+			outp.Printf("error: %v\n", err)
+		}
+		return classifyError(err)
+	}
+
+	if flags.json {
+		jsonStr, err := output.ToEnvDiffJSON(res)
+		if err != nil {
+			outp.Printf("failed to generate json output: %v\n", err)
+			return ExitInternalError
+		}
+		if multiMode {
+			*jsonResults = append(*jsonResults, jsonStr)
+		} else {
+			fmt.Fprintln(outw, jsonStr)
+		}
+	} else {
+		output.RenderEnvDiff(outw, res, useColor(flags, outw))
+	}
+	return ExitOK
+}
+
 // handleResolveError handles target resolution errors, including Docker fallback.
 func handleResolveError(cmd *cobra.Command, outw io.Writer, outp output.Printer, t model.Target, err error, flags appFlags, multiMode bool, jsonResults *[]string) int {
 	errStr := err.Error()
@@ -569,8 +852,8 @@ func handleResolveError(cmd *cobra.Command, outw io.Writer, outp output.Printer,
 
 	if errors.Is(err, target.ErrSocketOwnerUnknown) || strings.Contains(errStr, "socket found but owning process not detected") {
 		if t.Type == model.TargetPort {
-			if portNum, convErr := strconv.Atoi(t.Value); convErr == nil {
-				if match := procpkg.ResolveContainerByPort(portNum); match != nil {
+			if q, parseErr := target.ParsePort(t.Value); parseErr == nil && !q.IsRange() {
+				if match := procpkg.ResolveContainerByPort(q.Low); match != nil {
 					label := "port " + t.Value
 					if flags.json {
 						jsonStr, jsonErr := output.ContainerFallbackToJSON(label, match)
@@ -600,7 +883,11 @@ func handleResolveError(cmd *cobra.Command, outw io.Writer, outp output.Printer,
 			}
 			return ExitPermission
 		}
-		errorMsg := fmt.Sprintf("%s\n\nA socket was found for the port, but the owning process could not be detected.\nThis may be due to insufficient permissions. Try running with sudo:\n  sudo %s", errStr, strings.Join(os.Args, " "))
+		what := "port"
+		if t.Type == model.TargetSocket {
+			what = "path"
+		}
+		errorMsg := fmt.Sprintf("%s\n\nA socket was found for the %s, but the owning process could not be detected.\nThis may be due to insufficient permissions. Try running with sudo:\n  sudo %s", errStr, what, strings.Join(os.Args, " "))
 		cmd.PrintErrln(errorMsg)
 		return ExitPermission
 	}
@@ -629,7 +916,9 @@ func renderResult(outw io.Writer, res model.Result, flags appFlags, multiMode bo
 		var jsonStr string
 		var err error
 
-		if flags.short {
+		if flags.threads {
+			jsonStr, err = output.ToThreadsJSON(res)
+		} else if flags.short {
 			jsonStr, err = output.ToShortJSON(res)
 		} else if flags.tree {
 			jsonStr, err = output.ToTreeJSON(res)
@@ -648,6 +937,8 @@ func renderResult(outw io.Writer, res model.Result, flags appFlags, multiMode bo
 		} else {
 			fmt.Fprintln(outw, jsonStr)
 		}
+	} else if flags.threads {
+		output.RenderThreads(outw, res, colorEnabled)
 	} else if flags.warn {
 		output.RenderWarnings(outw, res, colorEnabled)
 	} else if flags.tree {
@@ -672,15 +963,7 @@ func runInteractive() error {
 func printMultiMatch(outp output.Printer, pids []int, colorEnabled bool, hint string) {
 	outp.Printf("Multiple matching processes found:\n\n")
 	for i, pid := range pids {
-		proc, err := procpkg.ReadProcess(pid)
-		var command, cmdline string
-		if err != nil {
-			command = "unknown"
-			cmdline = procpkg.GetCmdline(pid)
-		} else {
-			command = proc.Command
-			cmdline = proc.Cmdline
-		}
+		command, cmdline := describeMatch(pid)
 		if colorEnabled {
 			outp.Printf("[%d] %s%s%s (%spid %d%s)\n    %s\n",
 				i+1, output.ColorGreen, command, output.ColorReset,
@@ -694,6 +977,42 @@ func printMultiMatch(outp output.Printer, pids []int, colorEnabled bool, hint st
 	outp.Printf("  %s\n", hint)
 }
 
+// describeMatch returns the command name and command line listed for one
+// of several processes a target matched.
+func describeMatch(pid int) (command, cmdline string) {
+	proc, err := procpkg.ReadProcess(pid)
+	if err != nil {
+		return "unknown", procpkg.GetCmdline(pid)
+	}
+	return proc.Command, proc.Cmdline
+}
+
+// jsonMultiMatchEntry is the JSON form of printMultiMatch: an error entry
+// for the target that also lists the processes it matched.
+func jsonMultiMatchEntry(t model.Target, pids []int) string {
+	type match struct {
+		PID     int
+		Command string
+		Cmdline string
+	}
+	type multiMatchEntry struct {
+		Target  model.Target
+		Error   string
+		Matches []match
+	}
+	entry := multiMatchEntry{
+		Target:  t,
+		Error:   fmt.Sprintf("multiple processes matched (%d results)", len(pids)),
+		Matches: make([]match, len(pids)),
+	}
+	for i, pid := range pids {
+		command, cmdline := describeMatch(pid)
+		entry.Matches[i] = match{PID: pid, Command: command, Cmdline: cmdline}
+	}
+	data, _ := json.MarshalIndent(entry, "", "  ")
+	return string(data)
+}
+
 func printContainerMultiMatch(outp output.Printer, matches []*model.ContainerMatch, colorEnabled bool) {
 	outp.Printf("Multiple matching containers found:\n\n")
 	for i, m := range matches {
@@ -773,6 +1092,8 @@ func processContainerTarget(cmd *cobra.Command, outw io.Writer, outp output.Prin
 			PID:     pid,
 			Verbose: flags.verbose,
 			Tree:    flags.tree,
+			Threads: flags.threads,
+			JSON:    flags.json,
 			Target:  t,
 		})
 		if err != nil {
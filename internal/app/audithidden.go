@@ -0,0 +1,43 @@
+//go:build linux || darwin || freebsd || windows
+
+package app
+
+import (
+	"fmt"
+	"runtime"
+
+	"github.com/pranshuparmar/witr/internal/output"
+	"github.com/pranshuparmar/witr/internal/pipeline"
+	"github.com/spf13/cobra"
+)
+
+// runAuditHidden handles --audit-hidden: a system-wide cross-check for
+// processes missing from the /proc listing. It exits with ExitWarnings when
+// it finds any.
+func runAuditHidden(cmd *cobra.Command, flags appFlags) error {
+	if runtime.GOOS != "linux" {
+		return withExitCode(ExitInvalidInput, fmt.Errorf("--audit-hidden is not supported on this platform"))
+	}
+
+	audit, err := pipeline.AuditHidden()
+	if err != nil {
+		return withExitCode(classifyError(err), err)
+	}
+
+	outw := cmd.OutOrStdout()
+	if flags.json {
+		jsonStr, err := output.HiddenAuditToJSON(audit)
+		if err != nil {
+			return withExitCode(ExitInternalError, fmt.Errorf("failed to generate json output: %w", err))
+		}
+		fmt.Fprintln(outw, jsonStr)
+	} else {
+		output.RenderHiddenAudit(outw, audit, useColor(flags, outw))
+	}
+
+	if len(audit.Hidden) > 0 {
+		cmd.SilenceErrors = true
+		return withExitCode(ExitWarnings, fmt.Errorf("completed with exit code %d", ExitWarnings))
+	}
+	return nil
+}
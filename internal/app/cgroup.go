@@ -0,0 +1,56 @@
+//go:build linux || darwin || freebsd || windows
+
+package app
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/pranshuparmar/witr/internal/output"
+	procpkg "github.com/pranshuparmar/witr/internal/proc"
+	"github.com/pranshuparmar/witr/pkg/model"
+)
+
+// processCgroupTarget handles --cgroup: every process in the cgroup and the
+// cgroups beneath it, each analyzed as if named by --pid. With --tree the
+// subtree is drawn instead, processes grouped by the slice, service, scope
+// or container cgroup they sit in rather than by parent. It exits with
+// ExitNotFound when the subtree holds no processes.
+func processCgroupTarget(outw io.Writer, outp output.Printer, t model.Target, flags appFlags, multiMode bool, jsonResults *[]string) int {
+	reportErr := func(err error) int {
+		if multiMode && flags.json {
+			*jsonResults = append(*jsonResults, jsonErrorEntry(t, err.Error()))
+		} else {
+			outp.Printf("error: %v\n", err)
+		}
+		return classifyError(err)
+	}
+
+	tree, err := procpkg.ReadCgroupTree(t.Value)
+	if err != nil {
+		return reportErr(err)
+	}
+	if tree.Total == 0 {
+		return reportErr(fmt.Errorf("no process in cgroup %s", tree.Path))
+	}
+
+	if !flags.tree {
+		return renderEachPID(outw, outp, t, tree.PIDs(), nil, flags, multiMode, jsonResults)
+	}
+
+	if flags.json {
+		jsonStr, err := output.CgroupTreeToJSON(tree)
+		if err != nil {
+			outp.Printf("failed to generate json output: %v\n", err)
+			return ExitInternalError
+		}
+		if multiMode {
+			*jsonResults = append(*jsonResults, jsonStr)
+		} else {
+			fmt.Fprintln(outw, jsonStr)
+		}
+	} else {
+		output.RenderCgroupTree(outw, tree, useColor(flags, outw))
+	}
+	return ExitOK
+}
@@ -81,6 +81,90 @@ func TestCollectTargetsInOrder(t *testing.T) {
 				tgt(model.TargetContainer, "web"),
 			},
 		},
+		{
+			name:       "socket flag",
+			rawArgs:    []string{"--socket=/run/a.sock,/run/b.sock", "--socket", "@abstract"},
+			positional: nil,
+			want: []model.Target{
+				tgt(model.TargetSocket, "/run/a.sock"),
+				tgt(model.TargetSocket, "/run/b.sock"),
+				tgt(model.TargetSocket, "@abstract"),
+			},
+		},
+		{
+			name:       "watchers flag mixed with name",
+			rawArgs:    []string{"--watchers", "/etc/app.conf", "app"},
+			positional: []string{"app"},
+			want: []model.Target{
+				tgt(model.TargetWatchers, "/etc/app.conf"),
+				tgt(model.TargetName, "app"),
+			},
+		},
+		{
+			name:       "remote flag",
+			rawArgs:    []string{"--remote", "10.0.4.12:5432", "--remote=169.254.169.254"},
+			positional: nil,
+			want: []model.Target{
+				tgt(model.TargetRemote, "10.0.4.12:5432"),
+				tgt(model.TargetRemote, "169.254.169.254"),
+			},
+		},
+		{
+			name:       "unit flag mixed with pid",
+			rawArgs:    []string{"--unit", "nginx", "-p", "42", "--unit=session-4.scope"},
+			positional: nil,
+			want: []model.Target{
+				tgt(model.TargetUnit, "nginx"),
+				tgt(model.TargetPID, "42"),
+				tgt(model.TargetUnit, "session-4.scope"),
+			},
+		},
+		{
+			name:       "cgroup flag",
+			rawArgs:    []string{"--cgroup", "/system.slice/nginx.service", "--cgroup=/user.slice"},
+			positional: nil,
+			want: []model.Target{
+				tgt(model.TargetCgroup, "/system.slice/nginx.service"),
+				tgt(model.TargetCgroup, "/user.slice"),
+			},
+		},
+		{
+			name:       "user flag",
+			rawArgs:    []string{"--user", "alice", "--user=1001"},
+			positional: nil,
+			want: []model.Target{
+				tgt(model.TargetUser, "alice"),
+				tgt(model.TargetUser, "1001"),
+			},
+		},
+		{
+			name:       "exe flag",
+			rawArgs:    []string{"--exe", "/usr/bin/python3.11,/usr/bin/node"},
+			positional: nil,
+			want: []model.Target{
+				tgt(model.TargetExe, "/usr/bin/python3.11"),
+				tgt(model.TargetExe, "/usr/bin/node"),
+			},
+		},
+		{
+			// A pattern is taken whole: commas and spaces are part of it.
+			name:       "match flag keeps commas",
+			rawArgs:    []string{"--match", "celery.*worker -Q billing,urgent", "--match=^node {1,2}app"},
+			positional: nil,
+			want: []model.Target{
+				tgt(model.TargetMatch, "celery.*worker -Q billing,urgent"),
+				tgt(model.TargetMatch, "^node {1,2}app"),
+			},
+		},
+		{
+			name:       "env-match flag keeps commas",
+			rawArgs:    []string{"--env-match", "AWS_PROFILE=prod", "--env-match=LANG=/^(de|fr),/"},
+			positional: nil,
+			want: []model.Target{
+				tgt(model.TargetEnvMatch, "AWS_PROFILE=prod"),
+				tgt(model.TargetEnvMatch, "LANG=/^(de|fr),/"),
+			},
+		},
 		{
 			name:       "remaining positionals appended",
 			rawArgs:    []string{},
@@ -176,6 +260,15 @@ func TestTargetLabel(t *testing.T) {
 		{tgt(model.TargetPort, "80"), "port: 80"},
 		{tgt(model.TargetFile, "/x"), "file: /x"},
 		{tgt(model.TargetContainer, "c"), "container: c"},
+		{tgt(model.TargetSocket, "/run/docker.sock"), "socket: /run/docker.sock"},
+		{tgt(model.TargetWatchers, "/etc/app.conf"), "watchers: /etc/app.conf"},
+		{tgt(model.TargetRemote, "10.0.0.0/8"), "remote: 10.0.0.0/8"},
+		{tgt(model.TargetUnit, "nginx"), "unit: nginx"},
+		{tgt(model.TargetCgroup, "/user.slice"), "cgroup: /user.slice"},
+		{tgt(model.TargetUser, "alice"), "user: alice"},
+		{tgt(model.TargetExe, "/usr/bin/python3.11"), "exe: /usr/bin/python3.11"},
+		{tgt(model.TargetMatch, "celery.*worker"), "match: celery.*worker"},
+		{tgt(model.TargetEnvMatch, "NODE_ENV=development"), "env-match: NODE_ENV=development"},
 		{tgt(model.TargetName, "n"), "name: n"},
 	}
 	for _, c := range cases {
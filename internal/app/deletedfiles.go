@@ -0,0 +1,43 @@
+//go:build linux || darwin || freebsd || windows
+
+package app
+
+import (
+	"fmt"
+	"runtime"
+
+	"github.com/pranshuparmar/witr/internal/output"
+	"github.com/pranshuparmar/witr/internal/pipeline"
+	"github.com/spf13/cobra"
+)
+
+// runDeletedFiles handles --deleted-files: a system-wide scan for deleted
+// files still held open, the space df counts but du can't find. It exits
+// with ExitWarnings when any are found.
+func runDeletedFiles(cmd *cobra.Command, flags appFlags) error {
+	if runtime.GOOS != "linux" {
+		return withExitCode(ExitInvalidInput, fmt.Errorf("--deleted-files is not supported on this platform"))
+	}
+
+	audit, err := pipeline.AuditDeletedFiles()
+	if err != nil {
+		return withExitCode(classifyError(err), err)
+	}
+
+	outw := cmd.OutOrStdout()
+	if flags.json {
+		jsonStr, err := output.DeletedFilesToJSON(audit)
+		if err != nil {
+			return withExitCode(ExitInternalError, fmt.Errorf("failed to generate json output: %w", err))
+		}
+		fmt.Fprintln(outw, jsonStr)
+	} else {
+		output.RenderDeletedFiles(outw, audit, useColor(flags, outw))
+	}
+
+	if len(audit.Processes) > 0 {
+		cmd.SilenceErrors = true
+		return withExitCode(ExitWarnings, fmt.Errorf("completed with exit code %d", ExitWarnings))
+	}
+	return nil
+}
@@ -0,0 +1,38 @@
+//go:build linux || darwin || freebsd || windows
+
+package app
+
+import (
+	"io"
+	"sort"
+
+	"github.com/pranshuparmar/witr/internal/output"
+	"github.com/pranshuparmar/witr/internal/target"
+	"github.com/pranshuparmar/witr/pkg/model"
+)
+
+// processEnvMatchTarget handles --env-match: every process whose
+// environment sets the variable to a matching value, each analyzed as if
+// named by --pid and shown with the matching entry. JSON output is an
+// array with one result per process. It exits with ExitNotFound when no
+// process matches.
+func processEnvMatchTarget(outw io.Writer, outp output.Printer, t model.Target, flags appFlags, multiMode bool, jsonResults *[]string) int {
+	matches, err := target.FindEnvMatches(t.Value)
+	if err != nil {
+		if multiMode && flags.json {
+			*jsonResults = append(*jsonResults, jsonErrorEntry(t, err.Error()))
+		} else {
+			outp.Printf("error: %v\n", err)
+		}
+		return classifyError(err)
+	}
+
+	pids := make([]int, 0, len(matches))
+	for pid := range matches {
+		pids = append(pids, pid)
+	}
+	sort.Ints(pids)
+	return renderEachPID(outw, outp, t, pids, func(pid int, res *model.Result) {
+		res.MatchedEnv = matches[pid]
+	}, flags, multiMode, jsonResults)
+}
@@ -2,6 +2,7 @@ package app
 
 import (
 	"bytes"
+	"encoding/json"
 	"os"
 	"strings"
 	"testing"
@@ -43,3 +44,27 @@ func TestPrintContainerMultiMatch(t *testing.T) {
 		}
 	}
 }
+
+func TestJSONMultiMatchEntry(t *testing.T) {
+	target := model.Target{Type: model.TargetMatch, Value: "celery.*worker"}
+	out := jsonMultiMatchEntry(target, []int{os.Getpid(), os.Getpid()})
+
+	var entry struct {
+		Target  model.Target
+		Error   string
+		Matches []struct {
+			PID     int
+			Command string
+			Cmdline string
+		}
+	}
+	if err := json.Unmarshal([]byte(out), &entry); err != nil {
+		t.Fatalf("invalid JSON: %v\n%s", err, out)
+	}
+	if entry.Target != target || entry.Error != "multiple processes matched (2 results)" {
+		t.Errorf("unexpected entry: %+v", entry)
+	}
+	if len(entry.Matches) != 2 || entry.Matches[0].PID != os.Getpid() || entry.Matches[0].Command == "" {
+		t.Errorf("unexpected matches: %+v", entry.Matches)
+	}
+}
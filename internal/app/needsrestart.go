@@ -0,0 +1,43 @@
+//go:build linux || darwin || freebsd || windows
+
+package app
+
+import (
+	"fmt"
+	"runtime"
+
+	"github.com/pranshuparmar/witr/internal/output"
+	"github.com/pranshuparmar/witr/internal/pipeline"
+	"github.com/spf13/cobra"
+)
+
+// runNeedsRestart handles --needs-restart: a system-wide scan for processes
+// running deleted or replaced executables and libraries. Like a target with
+// warnings, it exits with ExitWarnings when anything needs a restart.
+func runNeedsRestart(cmd *cobra.Command, flags appFlags) error {
+	if runtime.GOOS != "linux" {
+		return withExitCode(ExitInvalidInput, fmt.Errorf("--needs-restart is not supported on this platform"))
+	}
+
+	audit, err := pipeline.AuditRestarts()
+	if err != nil {
+		return withExitCode(classifyError(err), err)
+	}
+
+	outw := cmd.OutOrStdout()
+	if flags.json {
+		jsonStr, err := output.RestartAuditToJSON(audit)
+		if err != nil {
+			return withExitCode(ExitInternalError, fmt.Errorf("failed to generate json output: %w", err))
+		}
+		fmt.Fprintln(outw, jsonStr)
+	} else {
+		output.RenderRestartAudit(outw, audit, useColor(flags, outw))
+	}
+
+	if len(audit.Groups) > 0 {
+		cmd.SilenceErrors = true
+		return withExitCode(ExitWarnings, fmt.Errorf("completed with exit code %d", ExitWarnings))
+	}
+	return nil
+}
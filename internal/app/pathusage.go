@@ -0,0 +1,61 @@
+//go:build linux || darwin || freebsd || windows
+
+package app
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"runtime"
+
+	"github.com/pranshuparmar/witr/internal/output"
+	"github.com/pranshuparmar/witr/internal/pipeline"
+	"github.com/pranshuparmar/witr/pkg/model"
+)
+
+// isDirTarget reports whether a --file target names a directory, which on
+// Linux lists every process keeping it busy instead of resolving a single
+// holder.
+func isDirTarget(t model.Target) bool {
+	if t.Type != model.TargetFile || runtime.GOOS != "linux" {
+		return false
+	}
+	fi, err := os.Stat(t.Value)
+	return err == nil && fi.IsDir()
+}
+
+// processDirTarget handles a directory or mount point given to --file: every
+// process with its working directory, an open file, a mapping or its root
+// beneath it, grouped by reason. It exits with ExitNotFound when nothing
+// uses the path, as for a file no process holds.
+func processDirTarget(outw io.Writer, outp output.Printer, t model.Target, flags appFlags, multiMode bool, jsonResults *[]string) int {
+	usage, err := pipeline.AnalyzePathUsage(t.Value)
+	if err != nil {
+		if multiMode && flags.json {
+			*jsonResults = append(*jsonResults, jsonErrorEntry(t, err.Error()))
+		} else {
+			outp.Printf("error: %v\n", err)
+		}
+		return classifyError(err)
+	}
+
+	if flags.json {
+		jsonStr, err := output.PathUsageToJSON(usage)
+		if err != nil {
+			outp.Printf("failed to generate json output: %v\n", err)
+			return ExitInternalError
+		}
+		if multiMode {
+			*jsonResults = append(*jsonResults, jsonStr)
+		} else {
+			fmt.Fprintln(outw, jsonStr)
+		}
+	} else {
+		output.RenderPathUsage(outw, usage, useColor(flags, outw))
+	}
+
+	if usage.Count() == 0 {
+		return ExitNotFound
+	}
+	return ExitOK
+}
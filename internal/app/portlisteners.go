@@ -0,0 +1,53 @@
+//go:build linux || darwin || freebsd || windows
+
+package app
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/pranshuparmar/witr/internal/output"
+	"github.com/pranshuparmar/witr/internal/pipeline"
+	"github.com/pranshuparmar/witr/pkg/model"
+)
+
+// processPortListeners handles a --port query that selects a range of
+// ports or several processes: a table of every matching listener with its
+// ancestry, in place of a single process's report. It exits with
+// ExitNotFound when nothing listens.
+func processPortListeners(outw io.Writer, outp output.Printer, t model.Target, q model.PortQuery, flags appFlags, multiMode bool, jsonResults *[]string) int {
+	listeners, err := pipeline.AnalyzePortListeners(q)
+	if err != nil {
+		if multiMode && flags.json {
+			*jsonResults = append(*jsonResults, jsonErrorEntry(t, err.Error()))
+		} else {
+			outp.Printf("error: %v\n", err)
+		}
+		return classifyError(err)
+	}
+
+	return renderPortListeners(outw, outp, listeners, flags, multiMode, jsonResults)
+}
+
+// renderPortListeners prints or collects a listener table.
+func renderPortListeners(outw io.Writer, outp output.Printer, listeners model.PortListeners, flags appFlags, multiMode bool, jsonResults *[]string) int {
+	if flags.json {
+		jsonStr, err := output.PortListenersToJSON(listeners)
+		if err != nil {
+			outp.Printf("failed to generate json output: %v\n", err)
+			return ExitInternalError
+		}
+		if multiMode {
+			*jsonResults = append(*jsonResults, jsonStr)
+		} else {
+			fmt.Fprintln(outw, jsonStr)
+		}
+	} else {
+		output.RenderPortListeners(outw, listeners, useColor(flags, outw))
+	}
+
+	if len(listeners.Listeners) == 0 {
+		return ExitNotFound
+	}
+	return ExitOK
+}
@@ -0,0 +1,63 @@
+//go:build linux || darwin || freebsd || windows
+
+package app
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/pranshuparmar/witr/internal/output"
+	procpkg "github.com/pranshuparmar/witr/internal/proc"
+	"github.com/pranshuparmar/witr/internal/target"
+	"github.com/pranshuparmar/witr/pkg/model"
+)
+
+// processRemoteTarget handles --remote: every process with a socket
+// connected to the endpoint, each analyzed as if named by --pid and shown
+// with its matching connections. JSON output is an array with one result
+// per process. It exits with ExitNotFound when nothing is connected.
+func processRemoteTarget(outw io.Writer, outp output.Printer, t model.Target, flags appFlags, multiMode bool, jsonResults *[]string) int {
+	reportErr := func(err error) int {
+		if multiMode && flags.json {
+			*jsonResults = append(*jsonResults, jsonErrorEntry(t, err.Error()))
+		} else {
+			outp.Printf("error: %v\n", err)
+		}
+		return classifyError(err)
+	}
+
+	q, err := target.ParseRemote(t.Value)
+	if err != nil {
+		return reportErr(err)
+	}
+	conns, err := procpkg.ScanRemoteConnections(q)
+	if err != nil {
+		return reportErr(err)
+	}
+	if len(conns.Processes) == 0 {
+		return reportErr(noRemoteConnections(conns))
+	}
+
+	connections := make(map[int][]model.Socket, len(conns.Processes))
+	pids := make([]int, 0, len(conns.Processes))
+	for _, c := range conns.Processes {
+		connections[c.PID] = c.Sockets
+		pids = append(pids, c.PID)
+	}
+	return renderEachPID(outw, outp, t, pids, func(pid int, res *model.Result) {
+		res.Connections = connections[pid]
+	}, flags, multiMode, jsonResults)
+}
+
+// noRemoteConnections explains an empty --remote result, pointing at
+// connections that were found but have no process to show.
+func noRemoteConnections(conns model.RemoteConnections) error {
+	msg := fmt.Sprintf("no process is connected to %s", conns.Query)
+	switch {
+	case conns.Unowned > 0:
+		msg += fmt.Sprintf(" that witr can inspect (%d held by other users' processes; try again as root)", conns.Unowned)
+	case conns.RecentlyClosed:
+		msg += " (recently closed connections are in TIME_WAIT; run again while one is open)"
+	}
+	return fmt.Errorf("%s", msg)
+}
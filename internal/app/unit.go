@@ -0,0 +1,78 @@
+//go:build linux || darwin || freebsd || windows
+
+package app
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+
+	"github.com/pranshuparmar/witr/internal/output"
+	"github.com/pranshuparmar/witr/internal/pipeline"
+	"github.com/pranshuparmar/witr/pkg/model"
+)
+
+// processUnitTarget handles --unit: the unit's main process is analyzed as
+// if named by --pid, and the report adds the unit's state, restart count
+// and other processes. --env and --env-diff apply to the main process. A
+// unit with no running processes is shown on its own and exits with
+// ExitNotFound.
+func processUnitTarget(outw io.Writer, outp output.Printer, t model.Target, flags appFlags, multiMode bool, jsonResults *[]string) int {
+	reportErr := func(err error) int {
+		if multiMode && flags.json {
+			*jsonResults = append(*jsonResults, jsonErrorEntry(t, err.Error()))
+		} else {
+			outp.Printf("error: %v\n", err)
+		}
+		return classifyError(err)
+	}
+
+	unit, err := pipeline.ResolveUnit(t.Value)
+	if err != nil {
+		return reportErr(err)
+	}
+
+	if unit.MainPID == 0 {
+		if flags.json {
+			jsonStr, err := output.UnitStatusToJSON(unit)
+			if err != nil {
+				outp.Printf("failed to generate json output: %v\n", err)
+				return ExitInternalError
+			}
+			if multiMode {
+				*jsonResults = append(*jsonResults, jsonStr)
+			} else {
+				fmt.Fprintln(outw, jsonStr)
+			}
+		} else {
+			output.RenderUnitStatus(outw, unit, useColor(flags, outw))
+		}
+		return ExitNotFound
+	}
+
+	if flags.env || flags.envDiff {
+		main := model.Target{Type: model.TargetPID, Value: strconv.Itoa(unit.MainPID)}
+		return processEnvTarget(outw, outp, main, flags, multiMode, jsonResults)
+	}
+
+	res, err := pipeline.AnalyzePID(pipeline.AnalyzeConfig{
+		PID:     unit.MainPID,
+		Verbose: flags.verbose,
+		Tree:    flags.tree,
+		Threads: flags.threads,
+		JSON:    flags.json,
+		Target:  t,
+	})
+	if err != nil {
+		return reportErr(err)
+	}
+	res.ResolvedTarget = unit.Name
+	res.RestartCount = unit.Restarts
+	res.Unit = &unit
+
+	renderResult(outw, res, flags, multiMode, jsonResults)
+	if len(res.Warnings) > 0 {
+		return ExitWarnings
+	}
+	return ExitOK
+}
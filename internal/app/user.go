@@ -0,0 +1,47 @@
+//go:build linux || darwin || freebsd || windows
+
+package app
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/pranshuparmar/witr/internal/output"
+	"github.com/pranshuparmar/witr/internal/pipeline"
+	"github.com/pranshuparmar/witr/pkg/model"
+)
+
+// processUserTarget handles --user: everything the user runs, grouped by the
+// unit, container or session that started it. It exits with ExitNotFound
+// when the user runs nothing.
+func processUserTarget(outw io.Writer, outp output.Printer, t model.Target, flags appFlags, multiMode bool, jsonResults *[]string) int {
+	summary, err := pipeline.SummarizeUser(t.Value)
+	if err != nil {
+		if multiMode && flags.json {
+			*jsonResults = append(*jsonResults, jsonErrorEntry(t, err.Error()))
+		} else {
+			outp.Printf("error: %v\n", err)
+		}
+		return classifyError(err)
+	}
+
+	if flags.json {
+		jsonStr, err := output.UserSummaryToJSON(summary)
+		if err != nil {
+			outp.Printf("failed to generate json output: %v\n", err)
+			return ExitInternalError
+		}
+		if multiMode {
+			*jsonResults = append(*jsonResults, jsonStr)
+		} else {
+			fmt.Fprintln(outw, jsonStr)
+		}
+	} else {
+		output.RenderUserSummary(outw, summary, useColor(flags, outw))
+	}
+
+	if summary.Processes == 0 {
+		return ExitNotFound
+	}
+	return ExitOK
+}
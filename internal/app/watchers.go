@@ -0,0 +1,47 @@
+//go:build linux || darwin || freebsd || windows
+
+package app
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/pranshuparmar/witr/internal/output"
+	"github.com/pranshuparmar/witr/internal/pipeline"
+	"github.com/pranshuparmar/witr/pkg/model"
+)
+
+// processWatchersTarget handles --watchers: every process holding an
+// inotify or fanotify watch that reports events for the path. It exits with
+// ExitNotFound when nobody watches it.
+func processWatchersTarget(outw io.Writer, outp output.Printer, t model.Target, flags appFlags, multiMode bool, jsonResults *[]string) int {
+	watchers, err := pipeline.AnalyzeWatchers(t.Value)
+	if err != nil {
+		if multiMode && flags.json {
+			*jsonResults = append(*jsonResults, jsonErrorEntry(t, err.Error()))
+		} else {
+			outp.Printf("error: %v\n", err)
+		}
+		return classifyError(err)
+	}
+
+	if flags.json {
+		jsonStr, err := output.WatchersToJSON(watchers)
+		if err != nil {
+			outp.Printf("failed to generate json output: %v\n", err)
+			return ExitInternalError
+		}
+		if multiMode {
+			*jsonResults = append(*jsonResults, jsonStr)
+		} else {
+			fmt.Fprintln(outw, jsonStr)
+		}
+	} else {
+		output.RenderWatchers(outw, watchers, useColor(flags, outw))
+	}
+
+	if len(watchers.Watchers) == 0 {
+		return ExitNotFound
+	}
+	return ExitOK
+}
@@ -0,0 +1,94 @@
+package output
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/pranshuparmar/witr/pkg/model"
+)
+
+// maxStackFrames caps the kernel stack in the Blocking section; the frames
+// that say what the process waits for are the innermost ones.
+const maxStackFrames = 8
+
+var sleepStates = map[string]string{
+	"S": "interruptible sleep",
+	"D": "uninterruptible sleep",
+}
+
+// renderBlocking prints the verbose Blocking section: the wait channel, the
+// system call and what it waits on, the kernel stack when readable, and the
+// offsets of open files.
+func renderBlocking(out Printer, b *model.BlockingInfo, colorEnabled bool) {
+	if b == nil {
+		return
+	}
+
+	header := fmt.Sprintf("%s (%s)", b.State, sleepStates[b.State])
+	if b.WaitChannel != "" {
+		header += " in " + b.WaitChannel
+	}
+	if colorEnabled {
+		out.Printf("\n%sBlocking%s: %s\n", ColorGreen, ColorReset, header)
+	} else {
+		out.Printf("\nBlocking: %s\n", header)
+	}
+
+	if b.Syscall != "" {
+		out.Printf("  Syscall    : %s(%s)\n", b.Syscall, strings.Join(b.SyscallArgs, ", "))
+	}
+	if b.WaitingOn != "" {
+		out.Printf("  Waiting on : %s\n", b.WaitingOn)
+	}
+	if b.FD != nil {
+		out.Printf("  Descriptor : %s\n", formatFilePosition(*b.FD))
+	}
+	if b.FSType != "" {
+		if b.Mount != "" {
+			out.Printf("  Filesystem : %s mounted on %s\n", b.FSType, b.Mount)
+		} else {
+			out.Printf("  Filesystem : %s\n", b.FSType)
+		}
+	}
+	if b.Samples > 0 {
+		var parts []string
+		if b.State == "D" {
+			parts = append(parts, fmt.Sprintf("%d of %d in D state", b.UninterruptibleSamples, b.Samples))
+		}
+		if b.FSType != "" {
+			parts = append(parts, fmt.Sprintf("%d of %d in the same call", b.SameCallSamples, b.Samples))
+		}
+		line := strings.Join(parts, ", ")
+		if colorEnabled && (b.Stuck() || b.StuckOnFS()) {
+			line = string(ColorRed) + line + string(ColorReset)
+		}
+		out.Printf("  Samples    : %s\n", ansiString(line))
+	}
+	if len(b.Stack) > 0 {
+		frames := b.Stack
+		if len(frames) > maxStackFrames {
+			frames = append(frames[:maxStackFrames:maxStackFrames], fmt.Sprintf("... %d more", len(b.Stack)-maxStackFrames))
+		}
+		out.Printf("  Stack      : %s\n", strings.Join(frames, " <- "))
+	}
+
+	if len(b.Files) > 0 {
+		out.Printf("  Open files :\n")
+		for i, f := range b.Files {
+			if i >= MaxDisplayItems {
+				out.Printf("    ... and %d more\n", len(b.Files)-MaxDisplayItems)
+				break
+			}
+			out.Printf("    %s\n", formatFilePosition(f))
+		}
+	}
+}
+
+// formatFilePosition renders a descriptor as "<fd> -> <path> (pos N, MODE)".
+func formatFilePosition(f model.FilePosition) string {
+	line := fmt.Sprintf("%d -> %s (pos %d", f.FD, f.Path, f.Pos)
+	if f.Access != "" {
+		line += ", " + f.Access
+	}
+	return line + ")"
+}
@@ -0,0 +1,66 @@
+package output
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/pranshuparmar/witr/pkg/model"
+)
+
+func TestRenderBlockingVerbose(t *testing.T) {
+	res := richVerboseResult()
+	blocking := &model.BlockingInfo{
+		State:                  "D",
+		WaitChannel:            "rpc_wait_bit_killable",
+		Syscall:                "read",
+		SyscallArgs:            []string{"0x3", "0x7ffd2c000000", "0x1000", "0x0", "0x0", "0x0"},
+		Stack:                  []string{"rpc_wait_bit_killable", "__rpc_execute", "nfs_file_read", "vfs_read", "ksys_read", "do_syscall_64", "entry_SYSCALL_64", "f8", "f9", "f10"},
+		WaitingOn:              "file /mnt/share/data.bin",
+		FD:                     &model.FilePosition{FD: 3, Path: "/mnt/share/data.bin", Pos: 4096, Access: "R"},
+		FSType:                 "nfs4",
+		Mount:                  "/mnt/share",
+		Samples:                5,
+		UninterruptibleSamples: 5,
+		SameCallSamples:        5,
+		Files: []model.FilePosition{
+			{FD: 3, Path: "/mnt/share/data.bin", Pos: 4096, Access: "R"},
+			{FD: 4, Path: "/var/log/app.log", Pos: 812, Access: "W"},
+		},
+	}
+	res.Ancestry[len(res.Ancestry)-1].Blocking = blocking
+
+	var buf bytes.Buffer
+	RenderStandard(&buf, res, false, true)
+	out := buf.String()
+	for _, want := range []string{
+		"Blocking: D (uninterruptible sleep) in rpc_wait_bit_killable\n",
+		"  Syscall    : read(0x3, 0x7ffd2c000000, 0x1000, 0x0, 0x0, 0x0)\n",
+		"  Waiting on : file /mnt/share/data.bin\n",
+		"  Descriptor : 3 -> /mnt/share/data.bin (pos 4096, R)\n",
+		"  Filesystem : nfs4 mounted on /mnt/share\n",
+		"  Samples    : 5 of 5 in D state, 5 of 5 in the same call\n",
+		"  Stack      : rpc_wait_bit_killable <- __rpc_execute <- nfs_file_read",
+		"<- f8 <- ... 2 more\n",
+		"    4 -> /var/log/app.log (pos 812, W)\n",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("verbose output missing %q\n---\n%s", want, out)
+		}
+	}
+	if len(blocking.Stack) != 10 {
+		t.Errorf("rendering must not modify the stack, now %v", blocking.Stack)
+	}
+
+	buf.Reset()
+	RenderStandard(&buf, res, true, true)
+	if !strings.Contains(buf.String(), string(ColorRed)+"5 of 5 in D state, 5 of 5 in the same call"+string(ColorReset)) {
+		t.Errorf("a stuck process should be highlighted\n---\n%q", buf.String())
+	}
+
+	buf.Reset()
+	RenderStandard(&buf, res, false, false)
+	if strings.Contains(buf.String(), "Blocking:") {
+		t.Error("Blocking section should only appear in verbose mode")
+	}
+}
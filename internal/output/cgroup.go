@@ -0,0 +1,99 @@
+package output
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/pranshuparmar/witr/pkg/model"
+)
+
+// renderCgroup prints the verbose Cgroup section: effective limits next to
+// current usage, OOM history, CPU throttling and PSI averages.
+func renderCgroup(out Printer, cg *model.CgroupStats, colorEnabled bool) {
+	if cg == nil {
+		return
+	}
+
+	if colorEnabled {
+		out.Printf("\n%sCgroup%s:\n", ColorGreen, ColorReset)
+	} else {
+		out.Printf("\nCgroup:\n")
+	}
+	out.Printf("  Path     : %s\n", cg.Path)
+
+	if cg.MemoryMax > 0 && cg.MemoryMaxPath != "" {
+		pct := float64(cg.MemoryMaxCurrent) / float64(cg.MemoryMax) * 100
+		out.Printf("  Memory   : %s (%s: %s of %s, %.0f%%)\n", formatBytes(cg.MemoryCurrent),
+			cg.MemoryMaxPath, formatBytes(cg.MemoryMaxCurrent), formatBytes(cg.MemoryMax), pct)
+	} else if cg.MemoryMax > 0 {
+		pct := float64(cg.MemoryCurrent) / float64(cg.MemoryMax) * 100
+		out.Printf("  Memory   : %s of %s (%.0f%%)\n", formatBytes(cg.MemoryCurrent), formatBytes(cg.MemoryMax), pct)
+	} else if cg.MemoryCurrent > 0 {
+		out.Printf("  Memory   : %s (no limit)\n", formatBytes(cg.MemoryCurrent))
+	}
+	if cg.OOMEvents > 0 || cg.OOMKills > 0 {
+		if colorEnabled {
+			out.Printf("  OOM      : %s%d kill(s), limit hit %d time(s)%s\n", ColorRed, cg.OOMKills, cg.OOMEvents, ColorReset)
+		} else {
+			out.Printf("  OOM      : %d kill(s), limit hit %d time(s)\n", cg.OOMKills, cg.OOMEvents)
+		}
+	}
+
+	if line := formatCPULimit(cg); line != "" {
+		out.Printf("  CPU      : %s\n", line)
+	}
+
+	if cg.PidsMax > 0 {
+		out.Printf("  PIDs     : %d of %d\n", cg.PidsCurrent, cg.PidsMax)
+	} else if cg.PidsCurrent > 0 {
+		out.Printf("  PIDs     : %d (no limit)\n", cg.PidsCurrent)
+	}
+
+	for _, p := range []struct {
+		label string
+		psi   *model.Pressure
+	}{
+		{"CPU PSI ", cg.CPUPressure},
+		{"Mem PSI ", cg.MemoryPressure},
+		{"IO PSI  ", cg.IOPressure},
+	} {
+		if p.psi == nil {
+			continue
+		}
+		out.Printf("  %s : %s\n", p.label, formatPressure(p.psi))
+	}
+}
+
+// formatCPULimit renders the cpu.max quota as cores plus the throttling
+// history, e.g. "1.50 cores, throttled in 12% of periods (3.2s total)".
+func formatCPULimit(cg *model.CgroupStats) string {
+	line := ""
+	if cg.CPUQuota > 0 && cg.CPUPeriod > 0 {
+		line = fmt.Sprintf("%.2f cores", float64(cg.CPUQuota)/float64(cg.CPUPeriod))
+	}
+	if cg.NrThrottled > 0 && cg.NrPeriods > 0 {
+		pct := float64(cg.NrThrottled) / float64(cg.NrPeriods) * 100
+		throttled := (time.Duration(cg.ThrottledUsec) * time.Microsecond).Round(100 * time.Millisecond)
+		if line != "" {
+			line += ", "
+		}
+		line += fmt.Sprintf("throttled in %.0f%% of periods (%s total)", pct, throttled)
+	}
+	if line == "" && cg.NrPeriods == 0 {
+		return ""
+	}
+	if line == "" {
+		return "no limit"
+	}
+	return line
+}
+
+// formatPressure renders a PSI file as "some 1.20/0.80/0.50%" with the full
+// line appended when the kernel reports one (avg10/avg60/avg300).
+func formatPressure(p *model.Pressure) string {
+	s := fmt.Sprintf("some %.2f/%.2f/%.2f%%", p.Some10, p.Some60, p.Some300)
+	if p.Full10 > 0 || p.Full60 > 0 || p.Full300 > 0 {
+		s += fmt.Sprintf(", full %.2f/%.2f/%.2f%%", p.Full10, p.Full60, p.Full300)
+	}
+	return s
+}
@@ -0,0 +1,58 @@
+package output
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/pranshuparmar/witr/pkg/model"
+)
+
+func TestRenderCgroupVerbose(t *testing.T) {
+	res := richVerboseResult()
+	res.Ancestry[len(res.Ancestry)-1].Cgroup = &model.CgroupStats{
+		Path:           "/system.slice/nginx.service",
+		MemoryCurrent:  256 * 1024 * 1024,
+		MemoryMax:      512 * 1024 * 1024,
+		OOMEvents:      4,
+		OOMKills:       1,
+		CPUQuota:       150000,
+		CPUPeriod:      100000,
+		NrPeriods:      1000,
+		NrThrottled:    120,
+		ThrottledUsec:  3200000,
+		PidsCurrent:    12,
+		PidsMax:        100,
+		MemoryPressure: &model.Pressure{Some10: 1.2, Some60: 0.8, Some300: 0.5},
+	}
+
+	var buf bytes.Buffer
+	RenderStandard(&buf, res, false, true)
+	out := buf.String()
+	for _, want := range []string{
+		"Cgroup:",
+		"Path     : /system.slice/nginx.service",
+		"Memory   : 256.0 MB of 512.0 MB (50%)",
+		"OOM      : 1 kill(s), limit hit 4 time(s)",
+		"CPU      : 1.50 cores, throttled in 12% of periods (3.2s total)",
+		"PIDs     : 12 of 100",
+		"Mem PSI  : some 1.20/0.80/0.50%",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("verbose output missing %q\n---\n%s", want, out)
+		}
+	}
+	if strings.Contains(out, "CPU PSI") {
+		t.Errorf("CPU PSI should be omitted when unavailable\n---\n%s", out)
+	}
+
+	// An inherited limit is shown against the ancestor's usage.
+	cg := res.Ancestry[len(res.Ancestry)-1].Cgroup
+	cg.MemoryMaxPath = "/system.slice"
+	cg.MemoryMaxCurrent = 384 * 1024 * 1024
+	buf.Reset()
+	RenderStandard(&buf, res, false, true)
+	if want := "Memory   : 256.0 MB (/system.slice: 384.0 MB of 512.0 MB, 75%)"; !strings.Contains(buf.String(), want) {
+		t.Errorf("verbose output missing %q\n---\n%s", want, buf.String())
+	}
+}
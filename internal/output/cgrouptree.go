@@ -0,0 +1,90 @@
+package output
+
+import (
+	"encoding/json"
+	"io"
+	"path"
+
+	"github.com/pranshuparmar/witr/pkg/model"
+)
+
+// RenderCgroupTree prints a cgroup and the cgroups beneath it that hold
+// processes as a tree, each cgroup with its kind and process count and the
+// processes directly in it, so slices, services, scopes and containers
+// show how the machine is carved up.
+func RenderCgroupTree(w io.Writer, root model.CgroupNode, colorEnabled bool) {
+	out := NewPrinter(w)
+	printCgroupLabel(out, root, root.Path, colorEnabled)
+	renderCgroupChildren(out, root, "", colorEnabled)
+}
+
+// renderCgroupChildren prints a node's processes, then its child cgroups,
+// below it. prefix carries the vertical rules of the enclosing levels.
+func renderCgroupChildren(out Printer, node model.CgroupNode, prefix string, colorEnabled bool) {
+	magenta, dim, green, reset := ansiString(""), ansiString(""), ansiString(""), ansiString("")
+	if colorEnabled {
+		magenta, dim, green, reset = ColorMagenta, ColorDim, ColorGreen, ColorReset
+	}
+
+	procs := node.Processes
+	hidden := 0
+	if len(procs) > MaxDisplayItems {
+		procs, hidden = procs[:MaxDisplayItems], len(procs)-MaxDisplayItems
+	}
+	entries := len(procs) + len(node.Children)
+	if hidden > 0 {
+		entries++
+	}
+
+	i := 0
+	connector := func() (string, string) {
+		i++
+		if i == entries {
+			return "└─ ", "   "
+		}
+		return "├─ ", "│  "
+	}
+
+	for _, p := range procs {
+		c, _ := connector()
+		name := ChainName(model.Process{Command: p.Command, Cmdline: p.Cmdline})
+		owner := ""
+		if p.User != "" && p.User != "unknown" {
+			owner = ", " + p.User
+		}
+		out.Printf("%s%s%s%s%s%s%s (%spid %d%s%s)\n", prefix, magenta, c, reset, green, name, reset, dim, p.PID, owner, reset)
+	}
+	if hidden > 0 {
+		c, _ := connector()
+		out.Printf("%s%s%s%s... and %d more\n", prefix, magenta, c, reset, hidden)
+	}
+	for _, child := range node.Children {
+		c, next := connector()
+		out.Printf("%s%s%s%s", prefix, magenta, c, reset)
+		printCgroupLabel(out, child, path.Base(child.Path), colorEnabled)
+		renderCgroupChildren(out, child, prefix+next, colorEnabled)
+	}
+}
+
+// printCgroupLabel prints a cgroup's tree line: "cron.service (service, 1
+// process)".
+func printCgroupLabel(out Printer, node model.CgroupNode, name string, colorEnabled bool) {
+	detail := plural(node.Total, "process", "processes")
+	if node.Kind != "" {
+		detail = node.Kind + ", " + detail
+	}
+	if colorEnabled {
+		out.Printf("%s%s%s %s(%s)%s\n", ColorCyan, name, ColorReset, ColorDim, detail, ColorReset)
+	} else {
+		out.Printf("%s (%s)\n", name, detail)
+	}
+}
+
+// CgroupTreeToJSON renders a cgroup tree as JSON.
+func CgroupTreeToJSON(root model.CgroupNode) (string, error) {
+	data, err := json.MarshalIndent(root, "", "  ")
+	if err != nil {
+		return "", err
+	}
+	return string(data), nil
+}
@@ -0,0 +1,114 @@
+package output
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/pranshuparmar/witr/pkg/model"
+)
+
+func cgroupTreeFixture() model.CgroupNode {
+	return model.CgroupNode{
+		Path:  "/",
+		Total: 4,
+		Processes: []model.CgroupProcess{
+			{PID: 1, Command: "systemd", User: "root"},
+		},
+		Children: []model.CgroupNode{
+			{
+				Path: "/system.slice", Kind: "slice", Total: 2,
+				Children: []model.CgroupNode{
+					{Path: "/system.slice/cron.service", Kind: "service", Total: 1,
+						Processes: []model.CgroupProcess{{PID: 612, Command: "cron", User: "root"}}},
+					{Path: "/system.slice/docker-4f1c.scope", Kind: "container", Total: 1,
+						Processes: []model.CgroupProcess{{PID: 2201, Command: "nginx", User: "unknown"}}},
+				},
+			},
+			{
+				Path: "/user.slice", Kind: "slice", Total: 1,
+				Processes: []model.CgroupProcess{{PID: 3001, Command: "bash", User: "alice"}},
+			},
+		},
+	}
+}
+
+func TestRenderCgroupTree(t *testing.T) {
+	t.Parallel()
+
+	var buf bytes.Buffer
+	RenderCgroupTree(&buf, cgroupTreeFixture(), false)
+
+	want := strings.Join([]string{
+		"/ (4 processes)",
+		"├─ systemd (pid 1, root)",
+		"├─ system.slice (slice, 2 processes)",
+		"│  ├─ cron.service (service, 1 process)",
+		"│  │  └─ cron (pid 612, root)",
+		"│  └─ docker-4f1c.scope (container, 1 process)",
+		"│     └─ nginx (pid 2201)",
+		"└─ user.slice (slice, 1 process)",
+		"   └─ bash (pid 3001, alice)",
+		"",
+	}, "\n")
+	if got := buf.String(); got != want {
+		t.Fatalf("unexpected tree:\n%s\nwant:\n%s", got, want)
+	}
+}
+
+func TestRenderCgroupTreeCapsProcesses(t *testing.T) {
+	t.Parallel()
+
+	node := model.CgroupNode{Path: "/system.slice/php-fpm.service", Kind: "service"}
+	for pid := 100; pid < 100+MaxDisplayItems+3; pid++ {
+		node.Processes = append(node.Processes, model.CgroupProcess{PID: pid, Command: "php-fpm"})
+	}
+	node.Total = len(node.Processes)
+
+	var buf bytes.Buffer
+	RenderCgroupTree(&buf, node, false)
+	out := buf.String()
+	if strings.Count(out, "php-fpm (pid") != MaxDisplayItems {
+		t.Errorf("expected %d process lines, got:\n%s", MaxDisplayItems, out)
+	}
+	if !strings.HasSuffix(out, "└─ ... and 3 more\n") {
+		t.Errorf("expected overflow line last, got:\n%s", out)
+	}
+}
+
+func TestRenderCgroupTreeColored(t *testing.T) {
+	t.Parallel()
+
+	var buf bytes.Buffer
+	RenderCgroupTree(&buf, cgroupTreeFixture(), true)
+	out := buf.String()
+	for _, want := range []string{
+		string(ColorCyan) + "system.slice" + string(ColorReset),
+		string(ColorMagenta) + "└─ " + string(ColorReset),
+		string(ColorGreen) + "cron" + string(ColorReset),
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("expected %q in colored output:\n%q", want, out)
+		}
+	}
+}
+
+func TestCgroupTreeToJSON(t *testing.T) {
+	t.Parallel()
+
+	out, err := CgroupTreeToJSON(cgroupTreeFixture())
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got model.CgroupNode
+	if err := json.Unmarshal([]byte(out), &got); err != nil {
+		t.Fatalf("invalid JSON: %v\n%s", err, out)
+	}
+	if got.Total != 4 || len(got.Children) != 2 || got.Children[0].Children[1].Kind != "container" {
+		t.Errorf("unexpected round trip: %+v", got)
+	}
+	if pids := got.PIDs(); len(pids) != 4 || pids[0] != 1 || pids[3] != 3001 {
+		t.Errorf("PIDs() = %v, want [1 612 2201 3001]", pids)
+	}
+}
@@ -0,0 +1,121 @@
+package output
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/pranshuparmar/witr/pkg/model"
+)
+
+// maxDeletedFiles caps the files listed per process.
+const maxDeletedFiles = 5
+
+// RenderDeletedFiles prints the --deleted-files report: the total space
+// pinned by deleted files, its split across filesystems, then each holding
+// process with what started it and its largest files.
+func RenderDeletedFiles(w io.Writer, audit model.DeletedFilesAudit, colorEnabled bool) {
+	out := NewPrinter(w)
+
+	if len(audit.Processes) == 0 {
+		if colorEnabled {
+			out.Printf("%sNo deleted files are held open%s (%d processes scanned)\n", ColorGreen, ColorReset, audit.Scanned)
+		} else {
+			out.Printf("No deleted files are held open (%d processes scanned)\n", audit.Scanned)
+		}
+		renderUnreadable(out, audit.Unreadable, colorEnabled)
+		return
+	}
+
+	summary := fmt.Sprintf("%s held open by %s (%d scanned)",
+		formatBytes(audit.TotalBytes), plural(len(audit.Processes), "process", "processes"), audit.Scanned)
+	blue, reset := ansiString(""), ansiString("")
+	if colorEnabled {
+		blue, reset = ColorBlue, ColorReset
+		out.Printf("%sDeleted files%s: %s\n", ColorRed, ColorReset, summary)
+	} else {
+		out.Printf("Deleted files: %s\n", summary)
+	}
+
+	out.Printf("\n%sBy filesystem%s:\n", blue, reset)
+	for _, fs := range audit.Filesystems {
+		mount := fs.Mount
+		if mount == "" {
+			mount = "(unknown mount)"
+		}
+		if fs.FSType != "" {
+			mount += " (" + fs.FSType + ")"
+		}
+		out.Printf("  %9s  %s, %s\n", formatBytes(fs.Bytes), mount, plural(fs.Files, "file", "files"))
+	}
+
+	out.Printf("\n%sBy process%s:\n", blue, reset)
+	for i, h := range audit.Processes {
+		if i > 0 {
+			out.Println()
+		}
+		renderProcessOrigin(out, "  ", h.Command, h.PID, h.User, h.StartedBy, colorEnabled)
+		for j, f := range h.Files {
+			if j == maxDeletedFiles {
+				var rest uint64
+				for _, more := range h.Files[j:] {
+					rest += more.Allocated
+				}
+				out.Printf("    +%d more (%s)\n", len(h.Files)-j, formatBytes(rest))
+				break
+			}
+			line := fmt.Sprintf("    fd %-4d %9s  %s", f.FD, formatBytes(f.Allocated), f.Path)
+			if note := deletedFileNote(f); note != "" {
+				if colorEnabled {
+					out.Printf("%s  %s(%s)%s\n", line, ColorDim, note, ColorReset)
+				} else {
+					out.Printf("%s  (%s)\n", line, note)
+				}
+			} else {
+				out.Printf("%s\n", line)
+			}
+		}
+		if len(h.Files) > 1 {
+			out.Printf("    %s in %s\n", formatBytes(h.Bytes), plural(len(h.Files), "file", "files"))
+		}
+	}
+	if audit.Unreadable > 0 {
+		out.Println()
+		renderUnreadable(out, audit.Unreadable, colorEnabled)
+	}
+}
+
+// deletedFileNote explains when deleting a file's holder won't free what
+// its size suggests: a sparse file, or one other processes hold too.
+func deletedFileNote(f model.DeletedFile) string {
+	var notes []string
+	if f.Size > 0 && uint64(f.Size) > f.Allocated && formatBytes(uint64(f.Size)) != formatBytes(f.Allocated) {
+		notes = append(notes, "sparse, "+formatBytes(uint64(f.Size))+" apparent")
+	}
+	if len(f.SharedWith) > 0 {
+		pids := make([]string, len(f.SharedWith))
+		for i, pid := range f.SharedWith {
+			pids[i] = fmt.Sprint(pid)
+		}
+		notes = append(notes, "also held by pid "+strings.Join(pids, ", "))
+	}
+	return strings.Join(notes, "; ")
+}
+
+// plural formats a count with the singular or plural noun.
+func plural(n int, one, many string) string {
+	if n == 1 {
+		return "1 " + one
+	}
+	return fmt.Sprintf("%d %s", n, many)
+}
+
+// DeletedFilesToJSON renders the --deleted-files report as JSON.
+func DeletedFilesToJSON(audit model.DeletedFilesAudit) (string, error) {
+	data, err := json.MarshalIndent(audit, "", "  ")
+	if err != nil {
+		return "", err
+	}
+	return string(data), nil
+}
@@ -0,0 +1,80 @@
+package output
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/pranshuparmar/witr/pkg/model"
+)
+
+func sampleDeletedFiles() model.DeletedFilesAudit {
+	var files []model.DeletedFile
+	for i := 0; i < 7; i++ {
+		files = append(files, model.DeletedFile{FD: 10 + i, Path: "/var/log/app/old.log", Size: 1 << 20, Allocated: 1 << 20, Mount: "/var", FSType: "ext4"})
+	}
+	return model.DeletedFilesAudit{
+		Processes: []model.DeletedFileHolder{
+			{PID: 812, Command: "rsyslogd", User: "root", Source: model.SourceSystemd, StartedBy: "rsyslog.service", Bytes: 3 << 30, Files: []model.DeletedFile{
+				{FD: 7, Path: "/var/log/syslog.1", Size: 3 << 30, Allocated: 3 << 30, Mount: "/var", FSType: "ext4", SharedWith: []int{820}},
+			}},
+			{PID: 1400, Command: "java", User: "app", Bytes: 7 << 20, Files: files},
+			{PID: 1500, Command: "qemu", Bytes: 4096, Files: []model.DeletedFile{
+				{FD: 3, Path: "/tmp/disk.img", Size: 10 << 30, Allocated: 4096, Mount: "/tmp", FSType: "tmpfs"},
+			}},
+		},
+		Filesystems: []model.DeletedFilesystem{
+			{Mount: "/var", FSType: "ext4", Bytes: 3<<30 + 7<<20, Files: 8},
+			{Mount: "/tmp", FSType: "tmpfs", Bytes: 4096, Files: 1},
+		},
+		TotalBytes: 3<<30 + 7<<20 + 4096,
+		Scanned:    210,
+		Unreadable: 3,
+	}
+}
+
+func TestRenderDeletedFiles(t *testing.T) {
+	var buf bytes.Buffer
+	RenderDeletedFiles(&buf, sampleDeletedFiles(), false)
+	out := buf.String()
+	for _, want := range []string{
+		"Deleted files: 3.0 GB held open by 3 processes (210 scanned)\n",
+		"\nBy filesystem:\n     3.0 GB  /var (ext4), 8 files\n     4.0 KB  /tmp (tmpfs), 1 file\n",
+		"\nBy process:\n  rsyslogd (pid 812, root)  started by rsyslog.service\n",
+		"    fd 7       3.0 GB  /var/log/syslog.1  (also held by pid 820)\n",
+		"    +2 more (2.0 MB)\n    7.0 MB in 7 files\n",
+		"    fd 3       4.0 KB  /tmp/disk.img  (sparse, 10.0 GB apparent)\n",
+		"3 processes could not be inspected",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("output missing %q\n---\n%s", want, out)
+		}
+	}
+	if strings.Count(out, "/var/log/app/old.log") != maxDeletedFiles {
+		t.Errorf("file list not capped at %d:\n%s", maxDeletedFiles, out)
+	}
+}
+
+func TestRenderDeletedFilesNone(t *testing.T) {
+	var buf bytes.Buffer
+	RenderDeletedFiles(&buf, model.DeletedFilesAudit{Scanned: 42}, false)
+	want := "No deleted files are held open (42 processes scanned)\n"
+	if got := buf.String(); got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+}
+
+func TestDeletedFilesToJSON(t *testing.T) {
+	s, err := DeletedFilesToJSON(sampleDeletedFiles())
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got model.DeletedFilesAudit
+	if err := json.Unmarshal([]byte(s), &got); err != nil {
+		t.Fatalf("invalid JSON: %v\n%s", err, s)
+	}
+	if len(got.Processes) != 3 || got.Processes[0].StartedBy != "rsyslog.service" || got.Filesystems[1].Mount != "/tmp" {
+		t.Errorf("unexpected JSON:\n%s", s)
+	}
+}
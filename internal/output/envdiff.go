@@ -0,0 +1,64 @@
+package output
+
+import (
+	"io"
+
+	"github.com/pranshuparmar/witr/pkg/model"
+)
+
+// RenderEnvDiff prints the variables a process added, changed or removed
+// relative to its parent or its systemd unit's declared environment.
+func RenderEnvDiff(w io.Writer, r model.Result, colorEnabled bool) {
+	p := NewPrinter(w)
+
+	reset, blue, dim := ansiString(""), ansiString(""), ansiString("")
+	if colorEnabled {
+		reset, blue, dim = ColorReset, ColorBlue, ColorDim
+	}
+
+	if colorEnabled {
+		p.Printf("%sProcess%s     : %s%s%s (%spid %d%s)\n", blue, reset, ColorGreen, r.Process.Command, reset, dim, r.Process.PID, reset)
+	} else {
+		p.Printf("Process     : %s (pid %d)\n", r.Process.Command, r.Process.PID)
+	}
+	p.Printf("%sCommand%s     : %s\n", blue, reset, r.Process.Cmdline)
+
+	d := r.EnvDiff
+	if d == nil {
+		return
+	}
+	if d.Baseline == "unit" {
+		p.Printf("%sCompared to%s : unit %s (declared environment)\n", blue, reset, d.BaselineName)
+	} else {
+		p.Printf("%sCompared to%s : parent %s (pid %d)\n", blue, reset, d.BaselineName, d.BaselinePID)
+	}
+
+	counts := make(map[string]int)
+	for _, c := range d.Changes {
+		counts[c.Kind]++
+	}
+	if len(d.Changes) == 0 {
+		p.Printf("%sChanges%s     : none (%d unchanged)\n", blue, reset, d.Unchanged)
+		return
+	}
+	p.Printf("%sChanges%s     : %d added, %d changed, %d removed (%d unchanged)\n",
+		blue, reset, counts["added"], counts["changed"], counts["removed"], d.Unchanged)
+
+	for _, c := range d.Changes {
+		switch c.Kind {
+		case "added":
+			p.Printf("  %s+ %s=%s%s\n", envDiffColor(ColorGreen, colorEnabled), c.Name, c.Value, reset)
+		case "changed":
+			p.Printf("  %s~ %s=%s%s %s(was %s)%s\n", envDiffColor(ColorDimYellow, colorEnabled), c.Name, c.Value, reset, dim, c.BaselineValue, reset)
+		case "removed":
+			p.Printf("  %s- %s=%s%s\n", envDiffColor(ColorRed, colorEnabled), c.Name, c.BaselineValue, reset)
+		}
+	}
+}
+
+func envDiffColor(c ansiString, colorEnabled bool) ansiString {
+	if !colorEnabled {
+		return ""
+	}
+	return c
+}
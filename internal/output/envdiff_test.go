@@ -0,0 +1,103 @@
+package output
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/pranshuparmar/witr/pkg/model"
+)
+
+func envDiffResult() model.Result {
+	return model.Result{
+		Process: model.Process{PID: 4242, Command: "node", Cmdline: "node server.js"},
+		EnvDiff: &model.EnvDiff{
+			Baseline:     "parent",
+			BaselinePID:  4200,
+			BaselineName: "bash",
+			Changes: []model.EnvChange{
+				{Name: "LD_PRELOAD", Kind: "added", Value: "/tmp/hook.so"},
+				{Name: "NODE_ENV", Kind: "changed", Value: "development", BaselineValue: "production"},
+				{Name: "LANG", Kind: "removed", BaselineValue: "C.UTF-8"},
+			},
+			Unchanged: 41,
+		},
+	}
+}
+
+func TestRenderEnvDiff(t *testing.T) {
+	var buf bytes.Buffer
+	RenderEnvDiff(&buf, envDiffResult(), false)
+	out := buf.String()
+	for _, want := range []string{
+		"Process     : node (pid 4242)",
+		"Compared to : parent bash (pid 4200)",
+		"Changes     : 1 added, 1 changed, 1 removed (41 unchanged)",
+		"  + LD_PRELOAD=/tmp/hook.so\n",
+		"  ~ NODE_ENV=development (was production)\n",
+		"  - LANG=C.UTF-8\n",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("output missing %q:\n%s", want, out)
+		}
+	}
+	if strings.Contains(out, "\033[") {
+		t.Errorf("plain output contains ANSI escapes:\n%q", out)
+	}
+}
+
+func TestRenderEnvDiffColored(t *testing.T) {
+	var buf bytes.Buffer
+	RenderEnvDiff(&buf, envDiffResult(), true)
+	out := buf.String()
+	for _, want := range []string{
+		string(ColorGreen) + "+ LD_PRELOAD=/tmp/hook.so" + string(ColorReset),
+		string(ColorDimYellow) + "~ NODE_ENV=development" + string(ColorReset),
+		string(ColorRed) + "- LANG=C.UTF-8" + string(ColorReset),
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("colored output missing %q:\n%q", want, out)
+		}
+	}
+}
+
+func TestRenderEnvDiffSanitizesValues(t *testing.T) {
+	r := envDiffResult()
+	r.EnvDiff.Changes = []model.EnvChange{{Name: "EVIL", Kind: "added", Value: "x\033[2Jy"}}
+	var buf bytes.Buffer
+	RenderEnvDiff(&buf, r, true)
+	if strings.Contains(buf.String(), "\033[2J") {
+		t.Errorf("variable value escaped the sanitizer:\n%q", buf.String())
+	}
+}
+
+func TestRenderEnvDiffUnitNoChanges(t *testing.T) {
+	r := envDiffResult()
+	r.EnvDiff = &model.EnvDiff{Baseline: "unit", BaselineName: "app.service", Changes: []model.EnvChange{}, Unchanged: 7}
+	var buf bytes.Buffer
+	RenderEnvDiff(&buf, r, false)
+	out := buf.String()
+	if !strings.Contains(out, "Compared to : unit app.service (declared environment)") ||
+		!strings.Contains(out, "Changes     : none (7 unchanged)") {
+		t.Errorf("unexpected output:\n%s", out)
+	}
+}
+
+func TestToEnvDiffJSON(t *testing.T) {
+	s, err := ToEnvDiffJSON(envDiffResult())
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got struct {
+		PID     int
+		EnvDiff model.EnvDiff
+	}
+	if err := json.Unmarshal([]byte(s), &got); err != nil {
+		t.Fatalf("invalid JSON: %v\n%s", err, s)
+	}
+	if got.PID != 4242 || got.EnvDiff.Baseline != "parent" || len(got.EnvDiff.Changes) != 3 ||
+		got.EnvDiff.Changes[1].BaselineValue != "production" {
+		t.Errorf("unexpected JSON:\n%s", s)
+	}
+}
@@ -0,0 +1,70 @@
+package output
+
+import (
+	"encoding/json"
+	"io"
+	"strings"
+
+	"github.com/pranshuparmar/witr/pkg/model"
+)
+
+// RenderHiddenAudit prints the --audit-hidden report: a summary line, then
+// each process missing from the /proc listing with how it was found, its
+// command line and its ancestry.
+func RenderHiddenAudit(w io.Writer, audit model.HiddenAudit, colorEnabled bool) {
+	out := NewPrinter(w)
+
+	if len(audit.Hidden) == 0 {
+		if colorEnabled {
+			out.Printf("%sNo hidden processes found%s (PIDs 1-%d probed, %d listed in /proc)\n", ColorGreen, ColorReset, audit.PIDMax-1, audit.Listed)
+		} else {
+			out.Printf("No hidden processes found (PIDs 1-%d probed, %d listed in /proc)\n", audit.PIDMax-1, audit.Listed)
+		}
+		return
+	}
+
+	noun := "processes"
+	if len(audit.Hidden) == 1 {
+		noun = "process"
+	}
+	if colorEnabled {
+		out.Printf("%s%d hidden %s%s found (PIDs 1-%d probed, %d listed in /proc)\n", ColorRed, len(audit.Hidden), noun, ColorReset, audit.PIDMax-1, audit.Listed)
+	} else {
+		out.Printf("%d hidden %s found (PIDs 1-%d probed, %d listed in /proc)\n", len(audit.Hidden), noun, audit.PIDMax-1, audit.Listed)
+	}
+
+	for _, h := range audit.Hidden {
+		out.Println()
+		name := "(unreadable)"
+		var proc model.Process
+		if len(h.Ancestry) > 0 {
+			proc = h.Ancestry[len(h.Ancestry)-1]
+			name = ChainName(proc)
+		}
+		if colorEnabled {
+			out.Printf("%sPID %d%s  %s  %s(found by %s; missing from the /proc listing)%s\n",
+				ColorRed, h.PID, ColorReset, name, ColorDim, strings.Join(h.FoundBy, ", "), ColorReset)
+		} else {
+			out.Printf("PID %d  %s  (found by %s; missing from the /proc listing)\n", h.PID, name, strings.Join(h.FoundBy, ", "))
+		}
+		if proc.Cmdline != "" {
+			out.Printf("  Command  : %s\n", proc.Cmdline)
+		}
+		if proc.User != "" {
+			out.Printf("  User     : %s\n", proc.User)
+		}
+		if len(h.Ancestry) > 1 {
+			out.Print("  Ancestry : ")
+			RenderShort(w, model.Result{Ancestry: h.Ancestry}, colorEnabled)
+		}
+	}
+}
+
+// HiddenAuditToJSON renders the --audit-hidden report as JSON.
+func HiddenAuditToJSON(audit model.HiddenAudit) (string, error) {
+	data, err := json.MarshalIndent(audit, "", "  ")
+	if err != nil {
+		return "", err
+	}
+	return string(data), nil
+}
@@ -0,0 +1,79 @@
+package output
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/pranshuparmar/witr/pkg/model"
+)
+
+func sampleHiddenAudit() model.HiddenAudit {
+	return model.HiddenAudit{
+		Listed: 212,
+		PIDMax: 4194304,
+		Hidden: []model.HiddenProcess{
+			{
+				PID:     31337,
+				FoundBy: []string{"kill", "stat"},
+				Ancestry: []model.Process{
+					{PID: 1, Command: "systemd"},
+					{PID: 880, Command: "cron"},
+					{PID: 31337, Command: "kswapd1", Cmdline: "/var/tmp/.k/kswapd1 -d", User: "root"},
+				},
+			},
+			{PID: 40001, FoundBy: []string{"kill"}},
+		},
+	}
+}
+
+func TestRenderHiddenAudit(t *testing.T) {
+	var buf bytes.Buffer
+	RenderHiddenAudit(&buf, sampleHiddenAudit(), false)
+	out := buf.String()
+	for _, want := range []string{
+		"2 hidden processes found (PIDs 1-4194303 probed, 212 listed in /proc)\n",
+		"\nPID 31337  kswapd1  (found by kill, stat; missing from the /proc listing)\n",
+		"  Command  : /var/tmp/.k/kswapd1 -d\n",
+		"  User     : root\n",
+		"  Ancestry : systemd (pid 1) → cron (pid 880) → kswapd1 (pid 31337)\n",
+		"\nPID 40001  (unreadable)  (found by kill; missing from the /proc listing)\n",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("output missing %q\n---\n%s", want, out)
+		}
+	}
+
+	buf.Reset()
+	RenderHiddenAudit(&buf, model.HiddenAudit{Listed: 80, PIDMax: 32768, Hidden: []model.HiddenProcess{}}, false)
+	if got := buf.String(); got != "No hidden processes found (PIDs 1-32767 probed, 80 listed in /proc)\n" {
+		t.Errorf("clean audit = %q", got)
+	}
+}
+
+func TestRenderHiddenAuditColored(t *testing.T) {
+	var buf bytes.Buffer
+	RenderHiddenAudit(&buf, sampleHiddenAudit(), true)
+	out := buf.String()
+	if !strings.Contains(out, string(ColorRed)+"2 hidden processes"+string(ColorReset)) {
+		t.Errorf("summary not colored red:\n%q", out)
+	}
+	if strings.Contains(out, `\x1b`) {
+		t.Errorf("colors were escaped by the sanitizer:\n%q", out)
+	}
+}
+
+func TestHiddenAuditToJSON(t *testing.T) {
+	s, err := HiddenAuditToJSON(sampleHiddenAudit())
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got model.HiddenAudit
+	if err := json.Unmarshal([]byte(s), &got); err != nil {
+		t.Fatalf("invalid JSON: %v\n%s", err, s)
+	}
+	if len(got.Hidden) != 2 || got.Hidden[0].PID != 31337 || len(got.Hidden[0].Ancestry) != 3 || got.PIDMax != 4194304 {
+		t.Errorf("unexpected JSON:\n%s", s)
+	}
+}
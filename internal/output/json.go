@@ -126,3 +126,47 @@ func ToEnvJSON(r model.Result) (string, error) {
 	}
 	return string(data), nil
 }
+
+// ToEnvDiffJSON renders the --env-diff result: the process and the
+// variables it added, changed or removed relative to the baseline.
+func ToEnvDiffJSON(r model.Result) (string, error) {
+	type envDiffResult struct {
+		PID     int
+		Process string
+		Command string
+		EnvDiff *model.EnvDiff
+	}
+
+	data, err := json.MarshalIndent(envDiffResult{
+		PID:     r.Process.PID,
+		Process: r.Process.Command,
+		Command: r.Process.Cmdline,
+		EnvDiff: r.EnvDiff,
+	}, "", "  ")
+	if err != nil {
+		return "", err
+	}
+	return string(data), nil
+}
+
+func ToThreadsJSON(r model.Result) (string, error) {
+	type threadsResult struct {
+		PID     int
+		Process string
+		Threads []model.Thread
+	}
+
+	threads := r.Process.Threads
+	if threads == nil {
+		threads = []model.Thread{}
+	}
+	data, err := json.MarshalIndent(threadsResult{
+		PID:     r.Process.PID,
+		Process: r.Process.Command,
+		Threads: threads,
+	}, "", "  ")
+	if err != nil {
+		return "", err
+	}
+	return string(data), nil
+}
@@ -0,0 +1,70 @@
+package output
+
+import (
+	"fmt"
+
+	"github.com/pranshuparmar/witr/pkg/model"
+)
+
+// renderLibraries prints the verbose Libraries section: deleted or replaced
+// shared objects first, then the rest in load order, with build IDs.
+func renderLibraries(out Printer, libs []model.Library, colorEnabled bool) {
+	if len(libs) == 0 {
+		return
+	}
+
+	var stale, current []model.Library
+	for _, lib := range libs {
+		if lib.Stale() {
+			stale = append(stale, lib)
+		} else {
+			current = append(current, lib)
+		}
+	}
+
+	header := fmt.Sprintf("%d mapped", len(libs))
+	if len(stale) > 0 {
+		header += fmt.Sprintf(", %d deleted or replaced", len(stale))
+	}
+	if colorEnabled {
+		out.Printf("\n%sLibraries%s: %s\n", ColorGreen, ColorReset, header)
+	} else {
+		out.Printf("\nLibraries: %s\n", header)
+	}
+
+	// Stale libraries are the point of the section, so they are never cut.
+	for _, lib := range stale {
+		status := ansiString("(replaced)")
+		if lib.Deleted {
+			status = "(deleted)"
+		}
+		if colorEnabled {
+			status = ColorRed + status + ColorReset
+		}
+		out.Printf("  %s %s%s\n", SanitizeTerminal(lib.Path), status, formatBuildID(lib.BuildID, colorEnabled))
+	}
+	shown := MaxDisplayItems - len(stale)
+	if shown < 0 {
+		shown = 0
+	}
+	for i, lib := range current {
+		if i >= shown {
+			out.Printf("  ... and %d more\n", len(current)-shown)
+			break
+		}
+		out.Printf("  %s%s\n", SanitizeTerminal(lib.Path), formatBuildID(lib.BuildID, colorEnabled))
+	}
+}
+
+// formatBuildID renders the build-ID suffix of a library row. The ID is
+// sanitized here so the colors around it survive the Printer.
+func formatBuildID(id string, colorEnabled bool) ansiString {
+	if id == "" {
+		return ""
+	}
+	id = SanitizeTerminal(id)
+	if colorEnabled {
+		return ansiString(fmt.Sprintf("  %sbuild-id %s%s", ColorDimYellow, id, ColorReset))
+	}
+	return ansiString("  build-id " + id)
+}
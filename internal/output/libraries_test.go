@@ -0,0 +1,59 @@
+package output
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/pranshuparmar/witr/pkg/model"
+)
+
+func TestRenderLibrariesVerbose(t *testing.T) {
+	res := richVerboseResult()
+	libs := []model.Library{
+		{Path: "/usr/lib/x86_64-linux-gnu/libc.so.6", BuildID: "aa11"},
+		{Path: "/usr/lib/x86_64-linux-gnu/libssl.so.3", Deleted: true},
+	}
+	for i := 0; i < MaxDisplayItems; i++ {
+		libs = append(libs, model.Library{Path: fmt.Sprintf("/usr/lib/libx%d.so", i)})
+	}
+	libs = append(libs, model.Library{Path: "/usr/lib/libcrypto.so.3", Replaced: true, BuildID: "bb22"})
+	res.Ancestry[len(res.Ancestry)-1].Libraries = libs
+
+	var buf bytes.Buffer
+	RenderStandard(&buf, res, false, true)
+	out := buf.String()
+	for _, want := range []string{
+		"Libraries: 13 mapped, 2 deleted or replaced",
+		"  /usr/lib/x86_64-linux-gnu/libssl.so.3 (deleted)\n",
+		"  /usr/lib/libcrypto.so.3 (replaced)  build-id bb22\n",
+		"  /usr/lib/x86_64-linux-gnu/libc.so.6  build-id aa11\n",
+		"  ... and 3 more\n",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("verbose output missing %q\n---\n%s", want, out)
+		}
+	}
+	// Stale libraries are listed ahead of the current ones.
+	if strings.Index(out, "libcrypto.so.3") > strings.Index(out, "libc.so.6") {
+		t.Errorf("replaced library should be listed first\n---\n%s", out)
+	}
+
+	buf.Reset()
+	RenderStandard(&buf, res, true, true)
+	colored := buf.String()
+	if !strings.Contains(colored, string(ColorRed)+"(deleted)"+string(ColorReset)) ||
+		!strings.Contains(colored, string(ColorDimYellow)+"build-id bb22"+string(ColorReset)) {
+		t.Errorf("colored output should keep its escape codes intact\n---\n%q", colored)
+	}
+	if strings.Contains(colored, `\x1b`) {
+		t.Errorf("colored output has escaped escape codes\n---\n%q", colored)
+	}
+
+	buf.Reset()
+	RenderStandard(&buf, res, false, false)
+	if strings.Contains(buf.String(), "Libraries:") {
+		t.Error("Libraries section should only appear in verbose mode")
+	}
+}
@@ -0,0 +1,93 @@
+package output
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/pranshuparmar/witr/pkg/model"
+)
+
+// nsenterFlags maps a namespace type to the nsenter(1) option that joins it.
+var nsenterFlags = map[string]string{
+	"mnt":    "-m",
+	"uts":    "-u",
+	"ipc":    "-i",
+	"net":    "-n",
+	"pid":    "-p",
+	"user":   "-U",
+	"cgroup": "-C",
+}
+
+// NsenterCommand returns an nsenter invocation that joins every namespace in
+// which the process is isolated from the host, or "" if it shares them all.
+func NsenterCommand(pid int, namespaces []model.Namespace) string {
+	var flags []string
+	for _, ns := range namespaces {
+		if f, ok := nsenterFlags[ns.Type]; ok && ns.Isolated {
+			flags = append(flags, f)
+		}
+	}
+	if len(flags) == 0 {
+		return ""
+	}
+	return "nsenter -t " + strconv.Itoa(pid) + " " + strings.Join(flags, " ")
+}
+
+// chainPID renders the PID label of an ancestry node: "pid 1234", or
+// "pid 1234, ns pid 1" when the process sits in a child PID namespace.
+func chainPID(p model.Process) string {
+	if p.NSPid > 0 && p.NSPid != p.PID {
+		return fmt.Sprintf("pid %d, ns pid %d", p.PID, p.NSPid)
+	}
+	return fmt.Sprintf("pid %d", p.PID)
+}
+
+// renderNamespaces prints the verbose Namespaces section. A process that
+// shares every namespace with the host collapses to a single line. When PID
+// 1's namespaces were unreadable the comparison was against witr's own, and
+// the section says so rather than claiming the host's.
+func renderNamespaces(out Printer, proc model.Process, colorEnabled bool) {
+	if len(proc.Namespaces) == 0 {
+		return
+	}
+
+	enter := NsenterCommand(proc.PID, proc.Namespaces)
+	var summary string
+	switch {
+	case enter == "" && proc.NamespacesComparedTo == "witr":
+		summary = "shared with witr (pid 1 unreadable)"
+	case enter == "":
+		summary = "host (shared with pid 1)"
+	case proc.NamespacesComparedTo == "witr":
+		summary = "compared with witr's (pid 1 unreadable)"
+	}
+	if colorEnabled {
+		out.Printf("\n%sNamespaces%s:", ColorGreen, ColorReset)
+	} else {
+		out.Printf("\nNamespaces:")
+	}
+	if summary != "" {
+		out.Printf(" %s", summary)
+	}
+	out.Printf("\n")
+	if enter == "" {
+		return
+	}
+
+	for _, ns := range proc.Namespaces {
+		switch {
+		case ns.Isolated && colorEnabled:
+			out.Printf("  %-6s : %d %s(isolated)%s\n", ns.Type, ns.Inode, ColorDimYellow, ColorReset)
+		case ns.Isolated:
+			out.Printf("  %-6s : %d (isolated)\n", ns.Type, ns.Inode)
+		default:
+			out.Printf("  %-6s : %d\n", ns.Type, ns.Inode)
+		}
+	}
+	if colorEnabled {
+		out.Printf("  %sEnter%s  : %s\n", ColorMagenta, ColorReset, enter)
+	} else {
+		out.Printf("  Enter  : %s\n", enter)
+	}
+}
@@ -0,0 +1,86 @@
+package output
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/pranshuparmar/witr/pkg/model"
+)
+
+func TestNsenterCommand(t *testing.T) {
+	ns := []model.Namespace{
+		{Type: "pid", Inode: 1, Isolated: true},
+		{Type: "net", Inode: 2, Isolated: true},
+		{Type: "mnt", Inode: 3, Isolated: true},
+		{Type: "user", Inode: 4},
+	}
+	if got, want := NsenterCommand(1234, ns), "nsenter -t 1234 -p -n -m"; got != want {
+		t.Errorf("NsenterCommand = %q, want %q", got, want)
+	}
+	if got := NsenterCommand(1234, []model.Namespace{{Type: "pid", Inode: 1}}); got != "" {
+		t.Errorf("expected no command for a host process, got %q", got)
+	}
+}
+
+func TestChainPIDShowsNamespacePID(t *testing.T) {
+	r := model.Result{
+		Ancestry: []model.Process{
+			{PID: 1, Command: "systemd"},
+			{PID: 900, Command: "containerd-shim"},
+			{PID: 1234, NSPid: 1, Command: "nginx"},
+		},
+	}
+	var buf bytes.Buffer
+	RenderShort(&buf, r, false)
+	want := "systemd (pid 1) → containerd-shim (pid 900) → nginx (pid 1234, ns pid 1)\n"
+	if got := buf.String(); got != want {
+		t.Errorf("RenderShort = %q, want %q", got, want)
+	}
+}
+
+func TestRenderNamespacesVerbose(t *testing.T) {
+	res := richVerboseResult()
+	proc := res.Ancestry[len(res.Ancestry)-1]
+	proc.Namespaces = []model.Namespace{
+		{Type: "pid", Inode: 4026532201, Isolated: true},
+		{Type: "net", Inode: 4026531840},
+	}
+	proc.NamespacesComparedTo = "pid 1"
+	res.Ancestry[len(res.Ancestry)-1] = proc
+
+	var buf bytes.Buffer
+	RenderStandard(&buf, res, false, true)
+	out := buf.String()
+	for _, want := range []string{"Namespaces:", "pid    : 4026532201 (isolated)", "net    : 4026531840\n", "Enter  : nsenter -t 1234 -p"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("verbose output missing %q\n---\n%s", want, out)
+		}
+	}
+
+	proc.Namespaces = []model.Namespace{{Type: "pid", Inode: 4026531836}}
+	res.Ancestry[len(res.Ancestry)-1] = proc
+	buf.Reset()
+	RenderStandard(&buf, res, false, true)
+	if !strings.Contains(buf.String(), "Namespaces: host (shared with pid 1)") {
+		t.Errorf("expected collapsed host namespace line\n---\n%s", buf.String())
+	}
+
+	// Without access to PID 1 the comparison is against witr's own
+	// namespaces, and the output must not claim the host's.
+	proc.NamespacesComparedTo = "witr"
+	res.Ancestry[len(res.Ancestry)-1] = proc
+	buf.Reset()
+	RenderStandard(&buf, res, false, true)
+	if out := buf.String(); !strings.Contains(out, "Namespaces: shared with witr (pid 1 unreadable)") || strings.Contains(out, "host") {
+		t.Errorf("expected namespaces shared with witr\n---\n%s", out)
+	}
+
+	proc.Namespaces = []model.Namespace{{Type: "net", Inode: 4026532301, Isolated: true}}
+	res.Ancestry[len(res.Ancestry)-1] = proc
+	buf.Reset()
+	RenderStandard(&buf, res, false, true)
+	if !strings.Contains(buf.String(), "Namespaces: compared with witr's (pid 1 unreadable)\n  net    : 4026532301 (isolated)") {
+		t.Errorf("expected witr comparison header\n---\n%s", buf.String())
+	}
+}
@@ -0,0 +1,109 @@
+package output
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"github.com/pranshuparmar/witr/pkg/model"
+)
+
+// maxPathUsagePaths caps the paths listed per process.
+const maxPathUsagePaths = 3
+
+// pathUsageTitles heads each PathUsageGroup reason.
+var pathUsageTitles = map[string]string{
+	"cwd":    "Working directory",
+	"open":   "Open files",
+	"mapped": "Mapped executables and libraries",
+	"root":   "Root directory",
+}
+
+// RenderPathUsage prints the processes keeping a directory or mount point
+// busy, grouped by how they use it, with what started each one.
+func RenderPathUsage(w io.Writer, u model.PathUsage, colorEnabled bool) {
+	out := NewPrinter(w)
+
+	blue, reset := ansiString(""), ansiString("")
+	if colorEnabled {
+		blue, reset = ColorBlue, ColorReset
+	}
+
+	where := u.Path
+	switch {
+	case u.Mount != "" && u.Mount == u.Path:
+		where += fmt.Sprintf(" (mount point, %s)", u.FSType)
+	case u.Mount != "":
+		where += fmt.Sprintf(" (on %s, %s)", u.Mount, u.FSType)
+	}
+	out.Printf("%sPath%s        : %s\n", blue, reset, where)
+
+	n := u.Count()
+	if n == 0 {
+		if colorEnabled {
+			out.Printf("%sIn use by%s   : %snothing%s (%d processes scanned)\n", blue, reset, ColorGreen, ColorReset, u.Scanned)
+		} else {
+			out.Printf("In use by   : nothing (%d processes scanned)\n", u.Scanned)
+		}
+		renderUnreadable(out, u.Unreadable, colorEnabled)
+		return
+	}
+	noun := "processes"
+	if n == 1 {
+		noun = "process"
+	}
+	if colorEnabled {
+		out.Printf("%sIn use by%s   : %s%d %s%s\n", blue, reset, ColorRed, n, noun, ColorReset)
+	} else {
+		out.Printf("In use by   : %d %s\n", n, noun)
+	}
+
+	for _, g := range u.Groups {
+		out.Printf("\n%s%s%s:\n", blue, pathUsageTitles[g.Reason], reset)
+		for _, p := range g.Processes {
+			renderProcessOrigin(out, "  ", p.Command, p.PID, p.User, p.StartedBy, colorEnabled)
+			for i, path := range p.Paths {
+				if i == maxPathUsagePaths {
+					out.Printf("    +%d more\n", len(p.Paths)-i)
+					break
+				}
+				out.Printf("    %s\n", path)
+			}
+		}
+	}
+	if u.Unreadable > 0 {
+		out.Println()
+		renderUnreadable(out, u.Unreadable, colorEnabled)
+	}
+}
+
+// renderProcessOrigin prints a "name (pid N, user)  started by X" line for
+// a process in a system-wide report.
+func renderProcessOrigin(out Printer, indent, command string, pid int, user, startedBy string, colorEnabled bool) {
+	who := fmt.Sprintf("pid %d", pid)
+	if user != "" {
+		who += ", " + user
+	}
+	if command == "" {
+		command = "(exited)"
+	}
+	switch {
+	case startedBy != "" && colorEnabled:
+		out.Printf("%s%s%s%s (%s)  %sstarted by %s%s\n", indent, ColorGreen, command, ColorReset, who, ColorDim, startedBy, ColorReset)
+	case startedBy != "":
+		out.Printf("%s%s (%s)  started by %s\n", indent, command, who, startedBy)
+	case colorEnabled:
+		out.Printf("%s%s%s%s (%s)\n", indent, ColorGreen, command, ColorReset, who)
+	default:
+		out.Printf("%s%s (%s)\n", indent, command, who)
+	}
+}
+
+// PathUsageToJSON renders a directory or mount point's users as JSON.
+func PathUsageToJSON(u model.PathUsage) (string, error) {
+	data, err := json.MarshalIndent(u, "", "  ")
+	if err != nil {
+		return "", err
+	}
+	return string(data), nil
+}
@@ -0,0 +1,92 @@
+package output
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/pranshuparmar/witr/pkg/model"
+)
+
+func samplePathUsage() model.PathUsage {
+	return model.PathUsage{
+		Path:   "/mnt/data",
+		Mount:  "/mnt/data",
+		FSType: "ext4",
+		Groups: []model.PathUsageGroup{
+			{Reason: "cwd", Processes: []model.PathUser{
+				{PID: 4100, Command: "bash", User: "alice", Source: model.SourceSSH, StartedBy: "SSH session from 10.0.0.5 (alice@pts/0)", Paths: []string{"/mnt/data/projects"}},
+			}},
+			{Reason: "open", Processes: []model.PathUser{
+				{PID: 812, Command: "postgres", User: "postgres", Source: model.SourceSystemd, StartedBy: "postgresql.service", Paths: []string{
+					"/mnt/data/pg/base/1", "/mnt/data/pg/base/2", "/mnt/data/pg/base/3", "/mnt/data/pg/base/4", "/mnt/data/pg/base/5",
+				}},
+				{PID: 4100, Command: "bash", User: "alice", Paths: []string{"/mnt/data/projects/notes.txt"}},
+			}},
+			{Reason: "root", Processes: []model.PathUser{
+				{PID: 900, Command: "sh", Paths: []string{"/mnt/data/chroot"}},
+			}},
+		},
+		Scanned:    180,
+		Unreadable: 2,
+	}
+}
+
+func TestRenderPathUsage(t *testing.T) {
+	var buf bytes.Buffer
+	RenderPathUsage(&buf, samplePathUsage(), false)
+	out := buf.String()
+	for _, want := range []string{
+		"Path        : /mnt/data (mount point, ext4)\n",
+		"In use by   : 3 processes\n",
+		"\nWorking directory:\n  bash (pid 4100, alice)  started by SSH session from 10.0.0.5 (alice@pts/0)\n    /mnt/data/projects\n",
+		"\nOpen files:\n  postgres (pid 812, postgres)  started by postgresql.service\n",
+		"    /mnt/data/pg/base/3\n    +2 more\n",
+		"\nRoot directory:\n  sh (pid 900)\n    /mnt/data/chroot\n",
+		"2 processes could not be inspected; run with sudo",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("output missing %q\n---\n%s", want, out)
+		}
+	}
+	if strings.Contains(out, "Mapped") {
+		t.Errorf("empty group rendered:\n%s", out)
+	}
+}
+
+func TestRenderPathUsageNothing(t *testing.T) {
+	var buf bytes.Buffer
+	RenderPathUsage(&buf, model.PathUsage{Path: "/srv/app", Mount: "/", FSType: "xfs", Scanned: 42}, false)
+	want := "Path        : /srv/app (on /, xfs)\nIn use by   : nothing (42 processes scanned)\n"
+	if got := buf.String(); got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+}
+
+func TestRenderPathUsageColored(t *testing.T) {
+	var buf bytes.Buffer
+	RenderPathUsage(&buf, samplePathUsage(), true)
+	out := buf.String()
+	if !strings.Contains(out, string(ColorGreen)+"postgres"+string(ColorReset)) ||
+		!strings.Contains(out, string(ColorDim)+"started by postgresql.service"+string(ColorReset)) {
+		t.Errorf("colored output missing highlights:\n%q", out)
+	}
+}
+
+func TestPathUsageToJSON(t *testing.T) {
+	s, err := PathUsageToJSON(samplePathUsage())
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got model.PathUsage
+	if err := json.Unmarshal([]byte(s), &got); err != nil {
+		t.Fatalf("invalid JSON: %v\n%s", err, s)
+	}
+	if len(got.Groups) != 3 || got.Groups[1].Reason != "open" || got.Groups[1].Processes[0].StartedBy != "postgresql.service" {
+		t.Errorf("unexpected JSON:\n%s", s)
+	}
+	if got.Count() != 3 {
+		t.Errorf("Count() = %d, want 3", got.Count())
+	}
+}
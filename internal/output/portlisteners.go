@@ -0,0 +1,100 @@
+package output
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/pranshuparmar/witr/pkg/model"
+)
+
+// RenderPortListeners prints a compact table of the processes listening on
+// the ports a query selects, one row per listener with the ancestry that
+// explains why it runs.
+func RenderPortListeners(w io.Writer, pl model.PortListeners, colorEnabled bool) {
+	out := NewPrinter(w)
+
+	blue, reset := ansiString(""), ansiString("")
+	if colorEnabled {
+		blue, reset = ColorBlue, ColorReset
+	}
+	out.Printf("%sPort%s        : %s\n", blue, reset, pl.Query)
+	if len(pl.Listeners) == 0 {
+		out.Printf("%sListeners%s   : none\n", blue, reset)
+		return
+	}
+	out.Printf("%sListeners%s   : %d\n\n", blue, reset, len(pl.Listeners))
+
+	addrs := make([]string, len(pl.Listeners))
+	protoWidth, addrWidth, pidWidth := len("PROTO"), len("ADDRESS"), len("PID")
+	for i, l := range pl.Listeners {
+		addrs[i] = listenerAddress(l)
+		protoWidth = max(protoWidth, len(l.Protocol))
+		addrWidth = max(addrWidth, len(addrs[i]))
+		pidWidth = max(pidWidth, len(strconv.Itoa(l.PID)))
+	}
+
+	header := fmt.Sprintf("%-*s  %-*s  %-*s  %s", protoWidth, "PROTO", addrWidth, "ADDRESS", pidWidth, "PID", "WHY")
+	if colorEnabled {
+		out.Printf("%s%s%s\n", ColorDim, header, ColorReset)
+	} else {
+		out.Printf("%s\n", header)
+	}
+	for i, l := range pl.Listeners {
+		out.Printf("%-*s  %-*s  %-*d  ", protoWidth, l.Protocol, addrWidth, addrs[i], pidWidth, l.PID)
+		renderChain(out, l, colorEnabled)
+	}
+}
+
+// renderChain prints a listener's ancestry by name, ending with the
+// listener itself, followed by what started it.
+func renderChain(out Printer, l model.PortListener, colorEnabled bool) {
+	chain := l.Chain
+	if len(chain) == 0 {
+		chain = []model.ChainLink{{PID: l.PID, Command: l.Command}}
+	}
+	for i, link := range chain {
+		name := link.Command
+		if name == "" {
+			name = "(unknown)"
+		}
+		switch {
+		case i > 0 && colorEnabled:
+			out.Printf("%s → %s", ColorMagenta, ColorReset)
+		case i > 0:
+			out.Print(" → ")
+		}
+		if colorEnabled && i == len(chain)-1 {
+			out.Printf("%s%s%s", ColorGreen, name, ColorReset)
+		} else {
+			out.Printf("%s", name)
+		}
+	}
+	switch {
+	case l.StartedBy != "" && colorEnabled:
+		out.Printf("  %sstarted by %s%s\n", ColorDim, l.StartedBy, ColorReset)
+	case l.StartedBy != "":
+		out.Printf("  started by %s\n", l.StartedBy)
+	default:
+		out.Println()
+	}
+}
+
+// listenerAddress formats an address and port, bracketing IPv6 addresses.
+func listenerAddress(l model.PortListener) string {
+	if strings.Contains(l.Address, ":") && !strings.HasPrefix(l.Address, "[") {
+		return fmt.Sprintf("[%s]:%d", l.Address, l.Port)
+	}
+	return fmt.Sprintf("%s:%d", l.Address, l.Port)
+}
+
+// PortListenersToJSON renders the listener table as JSON.
+func PortListenersToJSON(pl model.PortListeners) (string, error) {
+	data, err := json.MarshalIndent(pl, "", "  ")
+	if err != nil {
+		return "", err
+	}
+	return string(data), nil
+}
@@ -0,0 +1,70 @@
+package output
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/pranshuparmar/witr/pkg/model"
+)
+
+func samplePortListeners() model.PortListeners {
+	systemd := model.ChainLink{PID: 1, Command: "systemd"}
+	return model.PortListeners{
+		Query: "8000-8100",
+		Listeners: []model.PortListener{
+			{Protocol: "tcp", Address: "0.0.0.0", Port: 8000, PID: 812, Command: "nginx", Source: model.SourceSystemd, StartedBy: "nginx.service",
+				Chain: []model.ChainLink{systemd, {PID: 812, Command: "nginx"}}},
+			{Protocol: "tcp6", Address: "::1", Port: 8080, PID: 14001, Command: "node", Source: model.SourceShell, StartedBy: "bash session (alice)",
+				Chain: []model.ChainLink{systemd, {PID: 900, Command: "sshd"}, {PID: 950, Command: "bash"}, {PID: 14001, Command: "node"}}},
+			{Protocol: "udp", Address: "127.0.0.1", Port: 8053, PID: 77, Command: "dnsmasq"},
+		},
+	}
+}
+
+func TestRenderPortListeners(t *testing.T) {
+	var buf bytes.Buffer
+	RenderPortListeners(&buf, samplePortListeners(), false)
+	want := "Port        : 8000-8100\n" +
+		"Listeners   : 3\n\n" +
+		"PROTO  ADDRESS         PID    WHY\n" +
+		"tcp    0.0.0.0:8000    812    systemd → nginx  started by nginx.service\n" +
+		"tcp6   [::1]:8080      14001  systemd → sshd → bash → node  started by bash session (alice)\n" +
+		"udp    127.0.0.1:8053  77     dnsmasq\n"
+	if got := buf.String(); got != want {
+		t.Errorf("got:\n%s\nwant:\n%s", got, want)
+	}
+}
+
+func TestRenderPortListenersColored(t *testing.T) {
+	var buf bytes.Buffer
+	RenderPortListeners(&buf, samplePortListeners(), true)
+	out := buf.String()
+	if !strings.Contains(out, string(ColorMagenta)+" → "+string(ColorReset)+string(ColorGreen)+"nginx"+string(ColorReset)) {
+		t.Errorf("colored chain missing highlights:\n%q", out)
+	}
+}
+
+func TestRenderPortListenersNone(t *testing.T) {
+	var buf bytes.Buffer
+	RenderPortListeners(&buf, model.PortListeners{Query: "udp/9000-9010"}, false)
+	want := "Port        : udp/9000-9010\nListeners   : none\n"
+	if got := buf.String(); got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+}
+
+func TestPortListenersToJSON(t *testing.T) {
+	s, err := PortListenersToJSON(samplePortListeners())
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got model.PortListeners
+	if err := json.Unmarshal([]byte(s), &got); err != nil {
+		t.Fatalf("invalid JSON: %v\n%s", err, s)
+	}
+	if got.Query != "8000-8100" || len(got.Listeners) != 3 || len(got.Listeners[1].Chain) != 4 {
+		t.Errorf("unexpected JSON:\n%s", s)
+	}
+}
@@ -0,0 +1,139 @@
+package output
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"path/filepath"
+	"strings"
+
+	"github.com/pranshuparmar/witr/pkg/model"
+)
+
+// maxRestartLibraries caps the library names listed per process.
+const maxRestartLibraries = 3
+
+// restartKindTitles heads each kind of RestartGroup, in the singular and
+// plural used by the summary line.
+var restartKindTitles = map[string]struct{ section, one, many string }{
+	"service":   {"Services", "service", "services"},
+	"container": {"Containers", "container", "containers"},
+	"session":   {"User sessions", "user session", "user sessions"},
+	"other":     {"Other", "other group", "other groups"},
+}
+
+// RenderRestartAudit prints the --needs-restart report: a summary line, then
+// the affected services, containers and user sessions with their processes
+// and the stale files each one still runs.
+func RenderRestartAudit(w io.Writer, audit model.RestartAudit, colorEnabled bool) {
+	out := NewPrinter(w)
+
+	if len(audit.Groups) == 0 {
+		if colorEnabled {
+			out.Printf("%sNo process needs a restart%s (%d scanned)\n", ColorGreen, ColorReset, audit.Scanned)
+		} else {
+			out.Printf("No process needs a restart (%d scanned)\n", audit.Scanned)
+		}
+		renderUnreadable(out, audit.Unreadable, colorEnabled)
+		return
+	}
+
+	var counts []string
+	procs := 0
+	for i, g := range audit.Groups {
+		procs += len(g.Processes)
+		if i > 0 && audit.Groups[i-1].Kind == g.Kind {
+			continue
+		}
+		n := 0
+		for _, other := range audit.Groups[i:] {
+			if other.Kind == g.Kind {
+				n++
+			}
+		}
+		title := restartKindTitles[g.Kind]
+		noun := title.many
+		if n == 1 {
+			noun = title.one
+		}
+		counts = append(counts, fmt.Sprintf("%d %s", n, noun))
+	}
+	summary := fmt.Sprintf("%s (%d of %d processes)", strings.Join(counts, ", "), procs, audit.Scanned)
+	if colorEnabled {
+		out.Printf("%sNeeds restart%s: %s\n", ColorRed, ColorReset, summary)
+	} else {
+		out.Printf("Needs restart: %s\n", summary)
+	}
+
+	for i, g := range audit.Groups {
+		if i == 0 || audit.Groups[i-1].Kind != g.Kind {
+			if colorEnabled {
+				out.Printf("\n%s%s%s:\n", ColorBlue, restartKindTitles[g.Kind].section, ColorReset)
+			} else {
+				out.Printf("\n%s:\n", restartKindTitles[g.Kind].section)
+			}
+		}
+		switch {
+		case g.Restart != "" && colorEnabled:
+			out.Printf("  %s%s%s  %s(%s)%s\n", ColorGreen, g.Name, ColorReset, ColorDim, g.Restart, ColorReset)
+		case g.Restart != "":
+			out.Printf("  %s  (%s)\n", g.Name, g.Restart)
+		case colorEnabled:
+			out.Printf("  %s%s%s\n", ColorGreen, g.Name, ColorReset)
+		default:
+			out.Printf("  %s\n", g.Name)
+		}
+		for _, p := range g.Processes {
+			who := fmt.Sprintf("pid %d", p.PID)
+			if p.User != "" {
+				who += ", " + p.User
+			}
+			out.Printf("    %s (%s): %s\n", p.Command, who, formatStaleCode(p))
+		}
+	}
+	if audit.Unreadable > 0 {
+		out.Println()
+		renderUnreadable(out, audit.Unreadable, colorEnabled)
+	}
+}
+
+// renderUnreadable notes processes the audit could not inspect.
+func renderUnreadable(out Printer, n int, colorEnabled bool) {
+	if n == 0 {
+		return
+	}
+	msg := fmt.Sprintf("%d processes could not be inspected; run with sudo to include them", n)
+	if n == 1 {
+		msg = "1 process could not be inspected; run with sudo to include it"
+	}
+	if colorEnabled {
+		out.Printf("%s%s%s\n", ColorDimYellow, msg, ColorReset)
+	} else {
+		out.Printf("%s\n", msg)
+	}
+}
+
+// formatStaleCode lists a process's stale executable and library names.
+func formatStaleCode(p model.RestartProcess) string {
+	var parts []string
+	if p.Executable != "" {
+		parts = append(parts, "executable "+p.Executable)
+	}
+	for i, lib := range p.Libraries {
+		if i == maxRestartLibraries {
+			parts = append(parts, fmt.Sprintf("+%d more", len(p.Libraries)-maxRestartLibraries))
+			break
+		}
+		parts = append(parts, filepath.Base(lib.Path))
+	}
+	return strings.Join(parts, ", ")
+}
+
+// RestartAuditToJSON renders the --needs-restart report as JSON.
+func RestartAuditToJSON(audit model.RestartAudit) (string, error) {
+	data, err := json.MarshalIndent(audit, "", "  ")
+	if err != nil {
+		return "", err
+	}
+	return string(data), nil
+}
@@ -0,0 +1,70 @@
+package output
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/pranshuparmar/witr/pkg/model"
+)
+
+func sampleRestartAudit() model.RestartAudit {
+	ssl := model.Library{Path: "/usr/lib/x86_64-linux-gnu/libssl.so.3", Deleted: true}
+	crypto := model.Library{Path: "/usr/lib/x86_64-linux-gnu/libcrypto.so.3", Deleted: true}
+	return model.RestartAudit{
+		Scanned:    240,
+		Unreadable: 3,
+		Groups: []model.RestartGroup{
+			{Kind: "service", Source: model.SourceSystemd, Name: "nginx.service", Restart: "systemctl restart nginx.service", Processes: []model.RestartProcess{
+				{PID: 100, Command: "nginx", User: "root", Libraries: []model.Library{ssl, crypto}},
+				{PID: 101, Command: "nginx", User: "www-data", Libraries: []model.Library{ssl, crypto, {Path: "/lib/libz.so.1", Replaced: true}, {Path: "/lib/libpcre2-8.so.0", Deleted: true}}},
+			}},
+			{Kind: "service", Source: model.SourceSystemd, Name: "sshd.service", Restart: "systemctl restart sshd.service", Processes: []model.RestartProcess{
+				{PID: 200, Command: "sshd", Executable: "/usr/sbin/sshd"},
+			}},
+			{Kind: "session", Source: model.SourceSSH, Name: "SSH session from 10.0.0.5 (alice@pts/0)", Processes: []model.RestartProcess{
+				{PID: 300, Command: "vim", User: "alice", Libraries: []model.Library{crypto}},
+			}},
+		},
+	}
+}
+
+func TestRenderRestartAudit(t *testing.T) {
+	var buf bytes.Buffer
+	RenderRestartAudit(&buf, sampleRestartAudit(), false)
+	out := buf.String()
+	for _, want := range []string{
+		"Needs restart: 2 services, 1 user session (4 of 240 processes)\n",
+		"\nServices:\n  nginx.service  (systemctl restart nginx.service)\n",
+		"    nginx (pid 100, root): libssl.so.3, libcrypto.so.3\n",
+		"    nginx (pid 101, www-data): libssl.so.3, libcrypto.so.3, libz.so.1, +1 more\n",
+		"    sshd (pid 200): executable /usr/sbin/sshd\n",
+		"\nUser sessions:\n  SSH session from 10.0.0.5 (alice@pts/0)\n",
+		"3 processes could not be inspected; run with sudo",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("output missing %q\n---\n%s", want, out)
+		}
+	}
+
+	buf.Reset()
+	RenderRestartAudit(&buf, model.RestartAudit{Scanned: 12}, false)
+	if got := buf.String(); got != "No process needs a restart (12 scanned)\n" {
+		t.Errorf("clean audit = %q", got)
+	}
+}
+
+func TestRestartAuditToJSON(t *testing.T) {
+	s, err := RestartAuditToJSON(sampleRestartAudit())
+	if err != nil {
+		t.Fatal(err)
+	}
+	var back model.RestartAudit
+	if err := json.Unmarshal([]byte(s), &back); err != nil {
+		t.Fatalf("invalid JSON: %v", err)
+	}
+	if len(back.Groups) != 3 || back.Groups[0].Restart != "systemctl restart nginx.service" || back.Groups[1].Processes[0].Executable != "/usr/sbin/sshd" {
+		t.Errorf("round trip lost data: %+v", back)
+	}
+}
@@ -0,0 +1,109 @@
+package output
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/pranshuparmar/witr/pkg/model"
+)
+
+// renderScheduling prints the verbose Scheduling section: CPU policy and
+// priorities, CPU and NUMA placement, I/O priority and OOM ranking.
+func renderScheduling(out Printer, s *model.SchedulingInfo, colorEnabled bool) {
+	if s == nil {
+		return
+	}
+
+	if colorEnabled {
+		out.Printf("\n%sScheduling%s:\n", ColorGreen, ColorReset)
+	} else {
+		out.Printf("\nScheduling:\n")
+	}
+
+	policy := s.Policy
+	if s.RTPriority > 0 {
+		policy += fmt.Sprintf(", RT priority %d", s.RTPriority)
+	}
+	out.Printf("  Policy     : %s, nice %d\n", policy, s.Nice)
+	if s.CPUAffinity != "" {
+		out.Printf("  CPUs       : %s\n", s.CPUAffinity)
+	}
+	if s.MemoryNodes != "" || s.NUMAPolicy != "" {
+		var numa []string
+		if s.MemoryNodes != "" {
+			numa = append(numa, "nodes "+s.MemoryNodes)
+		}
+		if s.NUMAPolicy != "" {
+			numa = append(numa, "policy "+s.NUMAPolicy)
+		}
+		out.Printf("  NUMA       : %s\n", strings.Join(numa, ", "))
+	}
+	if s.IOClass != "" {
+		// The idle class has no levels.
+		io := s.IOClass
+		if s.IOClass != "idle" {
+			io += " " + strconv.Itoa(s.IOPriority)
+		}
+		if s.IOPriorityFromNice {
+			io += " (from nice)"
+		}
+		out.Printf("  I/O        : %s\n", io)
+	}
+	out.Printf("  OOM        : score %d, adj %d\n", s.OOMScore, s.OOMScoreAdj)
+}
+
+// renderLimits prints the verbose Limits section: the full resource limits
+// table with current usage where measured.
+func renderLimits(out Printer, limits []model.ResourceLimit, colorEnabled bool) {
+	if len(limits) == 0 {
+		return
+	}
+
+	if colorEnabled {
+		out.Printf("\n%sLimits%s:\n", ColorGreen, ColorReset)
+	} else {
+		out.Printf("\nLimits:\n")
+	}
+
+	nameWidth := len("LIMIT")
+	for _, l := range limits {
+		nameWidth = max(nameWidth, len(l.Name))
+	}
+	header := fmt.Sprintf("  %-*s  %-12s %-12s %s", nameWidth, "LIMIT", "SOFT", "HARD", "USED")
+	if colorEnabled {
+		out.Printf("%s%s%s\n", ColorDim, header, ColorReset)
+	} else {
+		out.Printf("%s\n", header)
+	}
+	for _, l := range limits {
+		used := "-"
+		if l.Usage != nil {
+			used = formatLimitValue(strconv.FormatUint(*l.Usage, 10), l.Unit)
+			if soft, ok := l.SoftValue(); ok && soft > 0 {
+				used += fmt.Sprintf(" (%.0f%%)", float64(*l.Usage)/float64(soft)*100)
+			}
+		}
+		row := fmt.Sprintf("  %-*s  %-12s %-12s %s", nameWidth, l.Name,
+			formatLimitValue(l.Soft, l.Unit), formatLimitValue(l.Hard, l.Unit), used)
+		out.Printf("%s\n", strings.TrimRight(row, " "))
+	}
+}
+
+// formatLimitValue renders a limits table value: byte counts in binary
+// units, times with their unit, everything else as is.
+func formatLimitValue(v, unit string) string {
+	n, err := strconv.ParseUint(v, 10, 64)
+	if err != nil {
+		return v
+	}
+	switch unit {
+	case "bytes":
+		return formatBytes(n)
+	case "seconds":
+		return v + "s"
+	case "us":
+		return v + "us"
+	}
+	return v
+}
@@ -0,0 +1,63 @@
+package output
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/pranshuparmar/witr/pkg/model"
+)
+
+func TestRenderSchedulingAndLimitsVerbose(t *testing.T) {
+	res := richVerboseResult()
+	openFiles := uint64(950)
+	stack := uint64(136 * 1024)
+	proc := &res.Ancestry[len(res.Ancestry)-1]
+	proc.Scheduling = &model.SchedulingInfo{
+		Policy: "SCHED_RR", RTPriority: 20, Nice: -5,
+		CPUAffinity: "0-3,8", MemoryNodes: "0-1", NUMAPolicy: "interleave:0-1",
+		IOClass: "realtime", IOPriority: 3, IOPriorityFromNice: true,
+		OOMScore: 12, OOMScoreAdj: -500,
+	}
+	proc.Limits = []model.ResourceLimit{
+		{Name: "Max cpu time", Soft: "unlimited", Hard: "unlimited", Unit: "seconds"},
+		{Name: "Max stack size", Soft: "8388608", Hard: "unlimited", Unit: "bytes", Usage: &stack},
+		{Name: "Max open files", Soft: "1024", Hard: "524288", Unit: "files", Usage: &openFiles},
+		{Name: "Max nice priority", Soft: "0", Hard: "0"},
+	}
+
+	var buf bytes.Buffer
+	RenderStandard(&buf, res, false, true)
+	out := buf.String()
+	for _, want := range []string{
+		"Scheduling:\n",
+		"  Policy     : SCHED_RR, RT priority 20, nice -5\n",
+		"  CPUs       : 0-3,8\n",
+		"  NUMA       : nodes 0-1, policy interleave:0-1\n",
+		"  I/O        : realtime 3 (from nice)\n",
+		"  OOM        : score 12, adj -500\n",
+		"Limits:\n",
+		"  LIMIT              SOFT         HARD         USED\n",
+		"  Max cpu time       unlimited    unlimited    -\n",
+		"  Max stack size     8.0 MB       unlimited    136.0 KB (2%)\n",
+		"  Max open files     1024         524288       950 (93%)\n",
+		"  Max nice priority  0            0            -\n",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("verbose output missing %q\n---\n%s", want, out)
+		}
+	}
+
+	proc.Scheduling.IOClass = "idle"
+	buf.Reset()
+	RenderStandard(&buf, res, false, true)
+	if !strings.Contains(buf.String(), "  I/O        : idle (from nice)\n") {
+		t.Errorf("idle class should have no level\n---\n%s", buf.String())
+	}
+
+	buf.Reset()
+	RenderStandard(&buf, res, false, false)
+	if strings.Contains(buf.String(), "Scheduling:") || strings.Contains(buf.String(), "Limits:") {
+		t.Error("Scheduling and Limits sections should only appear in verbose mode")
+	}
+}
@@ -0,0 +1,75 @@
+package output
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/pranshuparmar/witr/pkg/model"
+)
+
+// renderSecurity prints the verbose Security section: credentials, seccomp,
+// no_new_privs, LSM label and the bounding/ambient capability sets.
+func renderSecurity(out Printer, sc *model.SecurityContext, colorEnabled bool) {
+	if sc == nil {
+		return
+	}
+
+	if colorEnabled {
+		out.Printf("\n%sSecurity%s:\n", ColorGreen, ColorReset)
+	} else {
+		out.Printf("\nSecurity:\n")
+	}
+
+	out.Printf("  UID        : %s\n", formatIDSet(sc.RealUID, sc.EffectiveUID, sc.SavedUID, sc.FSUID))
+	out.Printf("  GID        : %s\n", formatIDSet(sc.RealGID, sc.EffectiveGID, sc.SavedGID, sc.FSGID))
+	if len(sc.Groups) > 0 {
+		groups := make([]string, len(sc.Groups))
+		for i, g := range sc.Groups {
+			groups[i] = g.String()
+		}
+		out.Printf("  Groups     : %s\n", strings.Join(groups, ", "))
+	}
+
+	seccomp := sc.SeccompMode
+	if sc.SeccompMode == "filter" && sc.SeccompFilters > 0 {
+		seccomp = fmt.Sprintf("filter (%d filters)", sc.SeccompFilters)
+	}
+	out.Printf("  Seccomp    : %s\n", seccomp)
+	if sc.NoNewPrivs {
+		out.Printf("  NoNewPrivs : yes\n")
+	} else {
+		out.Printf("  NoNewPrivs : no\n")
+	}
+
+	switch sc.LSM {
+	case "apparmor":
+		out.Printf("  AppArmor   : %s\n", sc.LSMLabel)
+	case "selinux":
+		out.Printf("  SELinux    : %s\n", sc.LSMLabel)
+	}
+
+	// A bounding set is usually nearly full, so name whichever side is shorter.
+	switch {
+	case len(sc.CapBounding) == 0:
+		out.Printf("  Bounding   : none\n")
+	case len(sc.CapBoundingDropped) == 0:
+		out.Printf("  Bounding   : all\n")
+	case len(sc.CapBoundingDropped) < len(sc.CapBounding):
+		out.Printf("  Bounding   : all except %s\n", strings.Join(sc.CapBoundingDropped, ", "))
+	default:
+		out.Printf("  Bounding   : %s\n", strings.Join(sc.CapBounding, ", "))
+	}
+	if len(sc.CapAmbient) > 0 {
+		out.Printf("  Ambient    : %s\n", strings.Join(sc.CapAmbient, ", "))
+	}
+}
+
+// formatIDSet renders a real/effective/saved/fs ID quadruple, collapsing to a
+// single value in the common case where all four agree.
+func formatIDSet(real, effective, saved, fs model.Credential) string {
+	if real.ID == effective.ID && real.ID == saved.ID && real.ID == fs.ID {
+		return real.String()
+	}
+	return fmt.Sprintf("real %s, effective %s, saved %s, fs %s",
+		real, effective, saved, fs)
+}
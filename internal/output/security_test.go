@@ -0,0 +1,51 @@
+package output
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/pranshuparmar/witr/pkg/model"
+)
+
+func TestRenderSecurityVerbose(t *testing.T) {
+	res := richVerboseResult()
+	res.Ancestry[len(res.Ancestry)-1].Security = &model.SecurityContext{
+		RealUID:            model.Credential{ID: 1000, Name: "alice"},
+		EffectiveUID:       model.Credential{ID: 0, Name: "root"},
+		SavedUID:           model.Credential{ID: 0, Name: "root"},
+		FSUID:              model.Credential{ID: 0, Name: "root"},
+		RealGID:            model.Credential{ID: 1000, Name: "alice"},
+		EffectiveGID:       model.Credential{ID: 1000, Name: "alice"},
+		SavedGID:           model.Credential{ID: 1000, Name: "alice"},
+		FSGID:              model.Credential{ID: 1000, Name: "alice"},
+		Groups:             []model.Credential{{ID: 27, Name: "sudo"}, {ID: 999}},
+		NoNewPrivs:         true,
+		SeccompMode:        "filter",
+		SeccompFilters:     2,
+		LSM:                "apparmor",
+		LSMLabel:           "/usr/sbin/nginx (enforce)",
+		CapBounding:        []string{"CAP_CHOWN", "CAP_KILL", "CAP_NET_BIND_SERVICE"},
+		CapBoundingDropped: []string{"CAP_SYS_ADMIN"},
+		CapAmbient:         []string{"CAP_NET_BIND_SERVICE"},
+	}
+
+	var buf bytes.Buffer
+	RenderStandard(&buf, res, false, true)
+	out := buf.String()
+	for _, want := range []string{
+		"Security:",
+		"UID        : real 1000 (alice), effective 0 (root), saved 0 (root), fs 0 (root)",
+		"GID        : 1000 (alice)",
+		"Groups     : 27 (sudo), 999",
+		"Seccomp    : filter (2 filters)",
+		"NoNewPrivs : yes",
+		"AppArmor   : /usr/sbin/nginx (enforce)",
+		"Bounding   : all except CAP_SYS_ADMIN",
+		"Ambient    : CAP_NET_BIND_SERVICE",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("verbose output missing %q\n---\n%s", want, out)
+		}
+	}
+}
@@ -23,9 +23,9 @@ func RenderShort(w io.Writer, r model.Result, colorEnabled bool) {
 			if i == len(r.Ancestry)-1 {
 				nameColor = ColorGreen
 			}
-			p.Printf("%s%s%s (%spid %d%s)", nameColor, ChainName(proc), ColorReset, ColorDim, proc.PID, ColorReset)
+			p.Printf("%s%s%s (%s%s%s)", nameColor, ChainName(proc), ColorReset, ColorDim, chainPID(proc), ColorReset)
 		} else {
-			p.Printf("%s (pid %d)", ChainName(proc), proc.PID)
+			p.Printf("%s (%s)", ChainName(proc), chainPID(proc))
 		}
 	}
 	p.Println()
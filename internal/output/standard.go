@@ -15,10 +15,17 @@ import (
 const MaxDisplayItems = 10
 
 var detailLabels = map[string]string{
-	"type":      "              Type",
-	"plist":     "              Plist",
-	"triggers":  "              Trigger",
-	"keepalive": "              KeepAlive",
+	"type":        "              Type",
+	"plist":       "              Plist",
+	"triggers":    "              Trigger",
+	"keepalive":   "              KeepAlive",
+	"revision":    "              Revision",
+	"channel":     "              Channel",
+	"confinement": "              Confinement",
+	"branch":      "              Branch",
+	"runtime":     "              Runtime",
+	"permissions": "              Permissions",
+	"denied":      "              Denied",
 }
 
 func formatDetailLabel(key string) string {
@@ -170,8 +177,9 @@ func RenderStandard(w io.Writer, r model.Result, colorEnabled bool, verbose bool
 	}
 
 	// Restart count (sourced from systemd's NRestarts); shown only when the
-	// managing system has restarted the unit at least once.
-	if r.RestartCount > 0 {
+	// managing system has restarted the unit at least once, or always for a
+	// --unit target.
+	if r.RestartCount > 0 || r.Unit != nil {
 		if colorEnabled {
 			out.Printf("%sRestarts%s    : %d\n", ColorMagenta, ColorReset, r.RestartCount)
 		} else {
@@ -197,7 +205,7 @@ func RenderStandard(w io.Writer, r model.Result, colorEnabled bool, verbose bool
 			if i == len(r.Ancestry)-1 {
 				nameColor = ColorGreen
 			}
-			out.Printf("%s%s%s (%spid %d%s)", nameColor, name, ColorReset, ColorDim, p.PID, ColorReset)
+			out.Printf("%s%s%s (%s%s%s)", nameColor, name, ColorReset, ColorDim, chainPID(p), ColorReset)
 			if i < len(r.Ancestry)-1 {
 				out.Printf(" %s\u2192%s ", ColorMagenta, ColorReset)
 			}
@@ -207,7 +215,7 @@ func RenderStandard(w io.Writer, r model.Result, colorEnabled bool, verbose bool
 		out.Printf("\nWhy It Exists :\n  ")
 		for i, p := range r.Ancestry {
 			name := SanitizeTerminal(ChainName(p))
-			out.Printf("%s (pid %d)", name, p.PID)
+			out.Printf("%s (%s)", name, chainPID(p))
 			if i < len(r.Ancestry)-1 {
 				out.Printf(" \u2192 ")
 			}
@@ -267,10 +275,15 @@ func RenderStandard(w io.Writer, r model.Result, colorEnabled bool, verbose bool
 		}
 	}
 
+	// State and other processes of a --unit target
+	if r.Unit != nil {
+		renderUnit(out, *r.Unit, colorEnabled)
+	}
+
 	// Source details (launchd triggers, plist path, etc.)
 	if len(r.Source.Details) > 0 {
 		// Display in consistent order
-		detailKeys := []string{"type", "plist", "triggers", "keepalive"}
+		detailKeys := []string{"type", "plist", "triggers", "keepalive", "revision", "channel", "confinement", "branch", "runtime", "permissions", "denied"}
 		for _, key := range detailKeys {
 			if val, ok := r.Source.Details[key]; ok {
 				label := formatDetailLabel(key)
@@ -308,6 +321,15 @@ func RenderStandard(w io.Writer, r model.Result, colorEnabled bool, verbose bool
 		}
 	}
 
+	// Environment entry an --env-match query matched
+	if r.MatchedEnv != "" {
+		if colorEnabled {
+			out.Printf("%sMatched Env%s : %s\n", ColorGreen, ColorReset, r.MatchedEnv)
+		} else {
+			out.Printf("Matched Env : %s\n", r.MatchedEnv)
+		}
+	}
+
 	// Sockets section (address:port (proto | state))
 	if len(proc.Sockets) > 0 {
 		visible := visibleSockets(proc.Sockets)
@@ -330,6 +352,40 @@ func RenderStandard(w io.Writer, r model.Result, colorEnabled bool, verbose bool
 		}
 	}
 
+	// Connections to a --remote endpoint (local -> remote (proto | state))
+	for i, s := range r.Connections {
+		if i >= MaxDisplayItems {
+			out.Printf("              ... and %d more\n", len(r.Connections)-i)
+			break
+		}
+		line := SanitizeTerminal(formatConnection(s))
+		switch {
+		case i == 0 && colorEnabled:
+			out.Printf("%sConnections%s : %s\n", ColorGreen, ColorReset, line)
+		case i == 0:
+			out.Printf("Connections : %s\n", line)
+		default:
+			out.Printf("              %s\n", line)
+		}
+	}
+
+	// Unix sockets served or connected to by path
+	for i, s := range proc.UnixSockets {
+		if i >= MaxDisplayItems {
+			out.Printf("              ... and %d more\n", len(proc.UnixSockets)-i)
+			break
+		}
+		line := SanitizeTerminal(formatUnixSocket(s))
+		switch {
+		case i == 0 && colorEnabled:
+			out.Printf("%sUnix Sockets%s: %s\n", ColorGreen, ColorReset, line)
+		case i == 0:
+			out.Printf("Unix Sockets: %s\n", line)
+		default:
+			out.Printf("              %s\n", line)
+		}
+	}
+
 	// Warnings
 	if len(r.Warnings) > 0 {
 		if colorEnabled {
@@ -424,6 +480,8 @@ func RenderStandard(w io.Writer, r model.Result, colorEnabled bool, verbose bool
 			}
 		}
 
+		renderCgroup(out, proc.Cgroup, colorEnabled)
+
 		// File context (open files, locks)
 		if r.FileContext != nil {
 			if r.FileContext.OpenFiles > 0 && r.FileContext.FileLimit == 0 {
@@ -553,6 +611,13 @@ func RenderStandard(w io.Writer, r model.Result, colorEnabled bool, verbose bool
 			}
 		}
 
+		renderNamespaces(out, proc, colorEnabled)
+		renderSecurity(out, proc.Security, colorEnabled)
+		renderScheduling(out, proc.Scheduling, colorEnabled)
+		renderLimits(out, proc.Limits, colorEnabled)
+		renderLibraries(out, proc.Libraries, colorEnabled)
+		renderBlocking(out, proc.Blocking, colorEnabled)
+
 		// Threads
 		if proc.ThreadCount > 1 {
 			if colorEnabled {
@@ -586,7 +651,8 @@ func formatBytes(n uint64) string {
 }
 
 // formatSocket renders one row of the Sockets section as
-// "<address>:<port> (<PROTO> | <STATE>)".
+// "<address>:<port> (<PROTO> | <STATE>)", followed by the local process on
+// the other end when the connection stays on this host.
 func formatSocket(s model.Socket) string {
 	addr := s.Address
 	hostPort := net.JoinHostPort(addr, strconv.Itoa(s.Port))
@@ -595,7 +661,58 @@ func formatSocket(s model.Socket) string {
 		proto = "?"
 	}
 	state := displayState(s.State)
-	return fmt.Sprintf("%s (%s | %s)", hostPort, proto, state)
+	line := fmt.Sprintf("%s (%s | %s)", hostPort, proto, state)
+	if s.Peer != nil {
+		remote := net.JoinHostPort(s.RemoteAddress, strconv.Itoa(s.RemotePort))
+		line += fmt.Sprintf(" connected to %s (pid %d) via %s", ChainName(model.Process{Command: s.Peer.Command}), s.Peer.PID, remote)
+	}
+	return line
+}
+
+// formatConnection renders one row of the Connections section as
+// "<local> → <remote> (<PROTO> | <STATE>)".
+func formatConnection(s model.Socket) string {
+	local := net.JoinHostPort(s.Address, strconv.Itoa(s.Port))
+	remote := net.JoinHostPort(s.RemoteAddress, strconv.Itoa(s.RemotePort))
+	proto := s.Protocol
+	if proto == "" {
+		proto = "?"
+	}
+	return fmt.Sprintf("%s → %s (%s | %s)", local, remote, proto, displayState(s.State))
+}
+
+// maxUnixSocketPeers caps how many connected clients are named on a served
+// unix socket's row; busy sockets such as the D-Bus system bus have hundreds.
+const maxUnixSocketPeers = 3
+
+// formatUnixSocket renders one row of the Unix Sockets section: a served
+// socket as "<path> (<TYPE> | <STATE>), clients: ...", a client connection
+// as "<path> (<TYPE> | <STATE>) connected to <server>".
+func formatUnixSocket(s model.UnixSocket) string {
+	path := s.Path
+	if path == "" {
+		path = "(unnamed)"
+	}
+	typ := s.Type
+	if typ == "" {
+		typ = "?"
+	}
+	line := fmt.Sprintf("%s (%s | %s)", path, typ, displayState(s.State))
+	if len(s.Peers) == 0 {
+		return line
+	}
+	names := make([]string, 0, maxUnixSocketPeers)
+	for i, p := range s.Peers {
+		if i == maxUnixSocketPeers {
+			names = append(names, fmt.Sprintf("+%d more", len(s.Peers)-i))
+			break
+		}
+		names = append(names, fmt.Sprintf("%s (pid %d)", ChainName(model.Process{Command: p.Command}), p.PID))
+	}
+	if s.Client {
+		return line + " connected to " + strings.Join(names, ", ")
+	}
+	return line + ", clients: " + strings.Join(names, ", ")
 }
 
 // displayState pretty-prints socket states. The kernel-style "LISTEN" reads
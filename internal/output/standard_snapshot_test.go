@@ -163,3 +163,55 @@ func TestRenderStandardOmitsEmptyOptionalSections(t *testing.T) {
 		}
 	}
 }
+
+// TestRenderStandardConnections verifies the Connections section lists the
+// connections to a --remote endpoint, one per row, and is omitted otherwise.
+func TestRenderStandardConnections(t *testing.T) {
+	t.Parallel()
+
+	var plain bytes.Buffer
+	RenderStandard(&plain, fixedFixture(), false, false)
+	if strings.Contains(plain.String(), "Connections") {
+		t.Errorf("Connections row should be omitted without a --remote target; output:\n%s", plain.String())
+	}
+
+	res := fixedFixture()
+	res.Connections = []model.Socket{
+		{Protocol: "TCP", Address: "10.0.0.2", Port: 41000, State: "ESTABLISHED", RemoteAddress: "10.0.4.12", RemotePort: 5432},
+		{Protocol: "TCP6", Address: "fd00::2", Port: 41001, State: "SYN_SENT", RemoteAddress: "fd00::12", RemotePort: 5432},
+	}
+	var got bytes.Buffer
+	RenderStandard(&got, res, false, false)
+	for _, row := range []string{
+		"Connections : 10.0.0.2:41000 → 10.0.4.12:5432 (TCP | ESTABLISHED)\n",
+		"              [fd00::2]:41001 → [fd00::12]:5432 (TCP6 | SYN_SENT)\n",
+	} {
+		if !strings.Contains(got.String(), row) {
+			t.Errorf("missing row %q in output:\n%s", row, got.String())
+		}
+	}
+}
+
+func TestRenderStandardMatchedEnv(t *testing.T) {
+	t.Parallel()
+
+	var plain bytes.Buffer
+	RenderStandard(&plain, fixedFixture(), false, false)
+	if strings.Contains(plain.String(), "Matched Env") {
+		t.Errorf("Matched Env row should be omitted without an --env-match target; output:\n%s", plain.String())
+	}
+
+	res := fixedFixture()
+	res.MatchedEnv = "AWS_PROFILE=prod"
+	var got bytes.Buffer
+	RenderStandard(&got, res, false, false)
+	if !strings.Contains(got.String(), "Matched Env : AWS_PROFILE=prod\n") {
+		t.Errorf("missing Matched Env row in output:\n%s", got.String())
+	}
+
+	var colored bytes.Buffer
+	RenderStandard(&colored, res, true, false)
+	if want := string(ColorGreen) + "Matched Env" + string(ColorReset) + " : AWS_PROFILE=prod\n"; !strings.Contains(colored.String(), want) {
+		t.Errorf("missing colored Matched Env row in output:\n%q", colored.String())
+	}
+}
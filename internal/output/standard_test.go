@@ -84,6 +84,15 @@ func TestFormatSocket(t *testing.T) {
 			s:    model.Socket{Address: "127.0.0.1", Port: 9999, Protocol: "TCP", State: ""},
 			want: "127.0.0.1:9999 (TCP | ?)",
 		},
+		{
+			name: "local peer is named",
+			s: model.Socket{
+				Address: "127.0.0.1", Port: 43525, Protocol: "TCP", State: "ESTABLISHED",
+				RemoteAddress: "127.0.0.1", RemotePort: 5432,
+				Peer: &model.SocketPeer{PID: 812, Command: "postgres"},
+			},
+			want: "127.0.0.1:43525 (TCP | ESTABLISHED) connected to postgres (pid 812) via 127.0.0.1:5432",
+		},
 	}
 
 	for _, tt := range tests {
@@ -97,6 +106,57 @@ func TestFormatSocket(t *testing.T) {
 	}
 }
 
+func TestFormatUnixSocket(t *testing.T) {
+	t.Parallel()
+
+	peers := func(n int) []model.SocketPeer {
+		var ps []model.SocketPeer
+		for i := 1; i <= n; i++ {
+			ps = append(ps, model.SocketPeer{PID: 100 + i, Command: "client"})
+		}
+		return ps
+	}
+	tests := []struct {
+		name string
+		s    model.UnixSocket
+		want string
+	}{
+		{
+			name: "listener without clients",
+			s:    model.UnixSocket{Path: "/run/foo.sock", Type: "STREAM", State: "LISTEN"},
+			want: "/run/foo.sock (STREAM | LISTENING)",
+		},
+		{
+			name: "listener with clients",
+			s:    model.UnixSocket{Path: "/run/docker.sock", Type: "STREAM", State: "LISTEN", Peers: peers(2)},
+			want: "/run/docker.sock (STREAM | LISTENING), clients: client (pid 101), client (pid 102)",
+		},
+		{
+			name: "busy listener is capped",
+			s:    model.UnixSocket{Path: "@bus", Type: "STREAM", State: "LISTEN", Peers: peers(5)},
+			want: "@bus (STREAM | LISTENING), clients: client (pid 101), client (pid 102), client (pid 103), +2 more",
+		},
+		{
+			name: "client connection names the server",
+			s: model.UnixSocket{
+				Path: "/run/systemd/journal/stdout", Type: "STREAM", State: "ESTABLISHED", Client: true,
+				Peers: []model.SocketPeer{{PID: 301, Command: "systemd-journal"}},
+			},
+			want: "/run/systemd/journal/stdout (STREAM | ESTABLISHED) connected to systemd-journal (pid 301)",
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if got := formatUnixSocket(tt.s); got != tt.want {
+				t.Errorf("formatUnixSocket(%+v) = %q, want %q", tt.s, got, tt.want)
+			}
+		})
+	}
+}
+
 func TestVisibleSocketsDropsIncomplete(t *testing.T) {
 	t.Parallel()
 
@@ -0,0 +1,76 @@
+package output
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/pranshuparmar/witr/pkg/model"
+)
+
+// RenderThreads prints the --threads view: one row per thread, busiest first,
+// with recent CPU% (over the pipeline's short sample), lifetime CPU time,
+// state, last CPU and wait channel.
+func RenderThreads(w io.Writer, r model.Result, colorEnabled bool) {
+	p := NewPrinter(w)
+	proc := r.Process
+
+	if colorEnabled {
+		p.Printf("%sProcess%s     : %s%s%s (%spid %d%s)\n", ColorBlue, ColorReset, ColorGreen, ChainName(proc), ColorReset, ColorDim, proc.PID, ColorReset)
+	} else {
+		p.Printf("Process     : %s (pid %d)\n", ChainName(proc), proc.PID)
+	}
+	if len(proc.Threads) == 0 {
+		p.Printf("Threads     : not available (Linux only)\n")
+		return
+	}
+
+	running := 0
+	for _, t := range proc.Threads {
+		if t.State == "R" {
+			running++
+		}
+	}
+	p.Printf("Threads     : %d (%d running)\n\n", len(proc.Threads), running)
+
+	nameWidth := len("NAME")
+	for _, t := range proc.Threads {
+		nameWidth = max(nameWidth, len(SanitizeTerminal(t.Name)))
+	}
+	header := fmt.Sprintf("%-8s %6s %10s  %-5s %3s  %-*s  %s", "TID", "CPU%", "CPU TIME", "STATE", "CPU", nameWidth, "NAME", "WAIT")
+	if colorEnabled {
+		p.Printf("%s%s%s\n", ColorDim, header, ColorReset)
+	} else {
+		p.Printf("%s\n", header)
+	}
+	for _, t := range proc.Threads {
+		wait := t.WaitChannel
+		if wait == "" {
+			wait = "-"
+		}
+		row := fmt.Sprintf("%-8d %6.1f %10s  %-5s %3d  %-*s  %s", t.TID, t.CPUPercent, FormatCPUTime(t.CPUSeconds), t.State, t.Processor, nameWidth, SanitizeTerminal(t.Name), SanitizeTerminal(wait))
+		if colorEnabled && t.CPUPercent >= BusyThreadPercent {
+			p.Printf("%s%s%s\n", ColorRed, strings.TrimRight(row, " "), ColorReset)
+		} else {
+			p.Printf("%s\n", strings.TrimRight(row, " "))
+		}
+	}
+}
+
+// BusyThreadPercent is the recent CPU% above which a thread is highlighted
+// as spinning.
+const BusyThreadPercent = 50
+
+// FormatCPUTime renders CPU seconds compactly: "0.42s", "3m05s", "2h14m".
+func FormatCPUTime(seconds float64) string {
+	switch {
+	case seconds < 60:
+		return fmt.Sprintf("%.2fs", seconds)
+	case seconds < 3600:
+		s := int(seconds)
+		return fmt.Sprintf("%dm%02ds", s/60, s%60)
+	default:
+		s := int(seconds)
+		return fmt.Sprintf("%dh%02dm", s/3600, s%3600/60)
+	}
+}
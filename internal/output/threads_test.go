@@ -0,0 +1,77 @@
+package output
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/pranshuparmar/witr/pkg/model"
+)
+
+func threadsResult() model.Result {
+	proc := model.Process{PID: 4200, Command: "java", Threads: []model.Thread{
+		{TID: 4242, Name: "C2 CompilerThre", State: "R", CPUSeconds: 3725, CPUPercent: 98.5, Processor: 3},
+		{TID: 4200, Name: "java", State: "S", CPUSeconds: 0.42, Processor: 0, WaitChannel: "futex_wait_queue"},
+	}}
+	return model.Result{Process: proc, Ancestry: []model.Process{proc}}
+}
+
+func TestRenderThreads(t *testing.T) {
+	var buf bytes.Buffer
+	RenderThreads(&buf, threadsResult(), false)
+	out := buf.String()
+	for _, want := range []string{
+		"Process     : java (pid 4200)\n",
+		"Threads     : 2 (1 running)\n",
+		"TID        CPU%   CPU TIME  STATE CPU  NAME             WAIT\n",
+		"4242       98.5      1h02m  R       3  C2 CompilerThre  -\n",
+		"4200        0.0      0.42s  S       0  java             futex_wait_queue\n",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("output missing %q\n---\n%s", want, out)
+		}
+	}
+
+	buf.Reset()
+	RenderThreads(&buf, model.Result{Process: model.Process{PID: 1, Command: "init"}}, false)
+	if !strings.Contains(buf.String(), "not available") {
+		t.Errorf("empty thread list should say so, got %q", buf.String())
+	}
+}
+
+func TestFormatCPUTime(t *testing.T) {
+	for in, want := range map[float64]string{
+		0.42: "0.42s",
+		59.9: "59.90s",
+		185:  "3m05s",
+		8040: "2h14m",
+	} {
+		if got := FormatCPUTime(in); got != want {
+			t.Errorf("FormatCPUTime(%v) = %q, want %q", in, got, want)
+		}
+	}
+}
+
+func TestToThreadsJSON(t *testing.T) {
+	s, err := ToThreadsJSON(threadsResult())
+	if err != nil {
+		t.Fatal(err)
+	}
+	var back struct {
+		PID     int
+		Process string
+		Threads []model.Thread
+	}
+	if err := json.Unmarshal([]byte(s), &back); err != nil {
+		t.Fatalf("invalid JSON: %v", err)
+	}
+	if back.PID != 4200 || len(back.Threads) != 2 || back.Threads[1].WaitChannel != "futex_wait_queue" {
+		t.Errorf("round trip lost data: %+v", back)
+	}
+
+	s, _ = ToThreadsJSON(model.Result{})
+	if !strings.Contains(s, `"Threads": []`) {
+		t.Errorf("missing threads should encode as an empty list: %s", s)
+	}
+}
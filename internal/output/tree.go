@@ -25,9 +25,9 @@ func PrintTree(w io.Writer, chain []model.Process, children []model.Process, col
 			if i == len(chain)-1 {
 				cmdColor = ColorGreen
 			}
-			p.Printf("%s%s%s (%spid %d%s)\n", cmdColor, ChainName(proc), ColorReset, ColorDim, proc.PID, ColorReset)
+			p.Printf("%s%s%s (%s%s%s)\n", cmdColor, ChainName(proc), ColorReset, ColorDim, chainPID(proc), ColorReset)
 		} else {
-			p.Printf("%s (pid %d)\n", ChainName(proc), proc.PID)
+			p.Printf("%s (%s)\n", ChainName(proc), chainPID(proc))
 		}
 	}
 
@@ -0,0 +1,122 @@
+package output
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/pranshuparmar/witr/pkg/model"
+)
+
+// maxMemberPIDs caps how many PIDs are listed for one command in the
+// Members section; a web server can run hundreds of identical workers.
+const maxMemberPIDs = 5
+
+// unitState describes a unit's state the way systemctl status does:
+// "active (running) since Mon 2026-10-12 08:00:01 +00:00 (3 days ago)",
+// with how the last run ended when it didn't succeed.
+func unitState(u model.UnitStatus) string {
+	if u.ActiveState == "" {
+		return "unknown (systemd D-Bus API unavailable)"
+	}
+	state := u.ActiveState
+	if u.SubState != "" {
+		state += " (" + u.SubState + ")"
+	}
+	if u.Result != "" && u.Result != "success" {
+		state += ", last run: " + u.Result
+	}
+	if !u.Since.IsZero() {
+		rel, abs := FormatStartedAt(u.Since)
+		state += " since " + abs + " (" + rel + ")"
+	}
+	return state
+}
+
+// summarizeMembers groups a unit's processes by command, in order of
+// first appearance: "nginx (pids 1235, 1236, 1237)".
+func summarizeMembers(members []model.Process) []string {
+	var order []string
+	pids := make(map[string][]int)
+	for _, m := range members {
+		name := ChainName(m)
+		if _, seen := pids[name]; !seen {
+			order = append(order, name)
+		}
+		pids[name] = append(pids[name], m.PID)
+	}
+
+	lines := make([]string, 0, len(order))
+	for _, name := range order {
+		list := pids[name]
+		if len(list) == 1 {
+			lines = append(lines, fmt.Sprintf("%s (pid %d)", name, list[0]))
+			continue
+		}
+		shown := make([]string, 0, maxMemberPIDs)
+		for i, pid := range list {
+			if i == maxMemberPIDs {
+				shown = append(shown, fmt.Sprintf("+%d more", len(list)-i))
+				break
+			}
+			shown = append(shown, strconv.Itoa(pid))
+		}
+		lines = append(lines, fmt.Sprintf("%s (pids %s)", name, strings.Join(shown, ", ")))
+	}
+	return lines
+}
+
+// renderUnit prints the state and other processes of the unit named by a
+// --unit target, as part of the main process's report.
+func renderUnit(out Printer, u model.UnitStatus, colorEnabled bool) {
+	cyan, reset := ansiString(""), ansiString("")
+	if colorEnabled {
+		cyan, reset = ColorCyan, ColorReset
+	}
+	out.Printf("%sUnit State%s  : %s\n", cyan, reset, SanitizeTerminal(unitState(u)))
+
+	if len(u.Members) == 0 {
+		out.Printf("%sMembers%s     : no other processes\n", cyan, reset)
+		return
+	}
+	out.Printf("%sMembers%s     : %s\n", cyan, reset, plural(len(u.Members), "other process", "other processes"))
+	lines := summarizeMembers(u.Members)
+	for i, line := range lines {
+		if i >= MaxDisplayItems {
+			out.Printf("              ... and %d more\n", len(lines)-i)
+			break
+		}
+		out.Printf("              %s\n", SanitizeTerminal(line))
+	}
+}
+
+// RenderUnitStatus prints a unit that has no running processes: its state,
+// how its last run ended and how often it was restarted.
+func RenderUnitStatus(w io.Writer, u model.UnitStatus, colorEnabled bool) {
+	out := NewPrinter(w)
+	blue, cyan, reset := ansiString(""), ansiString(""), ansiString("")
+	if colorEnabled {
+		blue, cyan, reset = ColorBlue, ColorCyan, ColorReset
+	}
+	out.Printf("%sUnit%s        : %s\n", blue, reset, SanitizeTerminal(u.Name))
+	if u.Description != "" {
+		out.Printf("%sDescription%s : %s\n", cyan, reset, SanitizeTerminal(u.Description))
+	}
+	if u.UnitFile != "" {
+		out.Printf("%sUnit File%s   : %s\n", cyan, reset, SanitizeTerminal(u.UnitFile))
+	}
+	out.Printf("%sUnit State%s  : %s\n", cyan, reset, SanitizeTerminal(unitState(u)))
+	out.Printf("%sRestarts%s    : %d\n", cyan, reset, u.Restarts)
+	out.Printf("%sProcesses%s   : none running\n", cyan, reset)
+}
+
+// UnitStatusToJSON renders a unit with no running processes as JSON.
+func UnitStatusToJSON(u model.UnitStatus) (string, error) {
+	data, err := json.MarshalIndent(u, "", "  ")
+	if err != nil {
+		return "", err
+	}
+	return string(data), nil
+}
@@ -0,0 +1,122 @@
+package output
+
+import (
+	"bytes"
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/pranshuparmar/witr/pkg/model"
+)
+
+func TestUnitState(t *testing.T) {
+	t.Parallel()
+
+	since := time.Now().Add(-3 * 24 * time.Hour)
+	_, abs := FormatStartedAt(since)
+	tests := []struct {
+		name string
+		u    model.UnitStatus
+		want string
+	}{
+		{"running", model.UnitStatus{ActiveState: "active", SubState: "running", Result: "success", Since: since}, "active (running) since " + abs + " (3 days ago)"},
+		{"failed", model.UnitStatus{ActiveState: "failed", SubState: "failed", Result: "exit-code"}, "failed (failed), last run: exit-code"},
+		{"no bus", model.UnitStatus{}, "unknown (systemd D-Bus API unavailable)"},
+	}
+	for _, tt := range tests {
+		if got := unitState(tt.u); got != tt.want {
+			t.Errorf("%s: unitState = %q, want %q", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestSummarizeMembers(t *testing.T) {
+	t.Parallel()
+
+	var members []model.Process
+	for pid := 1235; pid <= 1241; pid++ {
+		members = append(members, model.Process{PID: pid, Command: "nginx"})
+	}
+	members = append(members[:2], append([]model.Process{{PID: 1300, Command: "logrotate"}}, members[2:]...)...)
+
+	want := []string{
+		"nginx (pids 1235, 1236, 1237, 1238, 1239, +2 more)",
+		"logrotate (pid 1300)",
+	}
+	if got := summarizeMembers(members); !reflect.DeepEqual(got, want) {
+		t.Errorf("summarizeMembers = %q, want %q", got, want)
+	}
+}
+
+func TestRenderStandardUnit(t *testing.T) {
+	t.Parallel()
+
+	res := fixedFixture()
+	res.Unit = &model.UnitStatus{
+		Name:        "nginx.service",
+		ActiveState: "active",
+		SubState:    "running",
+		Members:     []model.Process{{PID: 1235, Command: "nginx"}, {PID: 1236, Command: "nginx"}},
+	}
+	var buf bytes.Buffer
+	RenderStandard(&buf, res, false, false)
+	out := buf.String()
+	for _, want := range []string{
+		"Restarts    : 0\n",
+		"Unit State  : active (running)\n",
+		"Members     : 2 other processes\n              nginx (pids 1235, 1236)\n",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("missing %q in output:\n%s", want, out)
+		}
+	}
+
+	res.Unit.Members = nil
+	buf.Reset()
+	RenderStandard(&buf, res, false, false)
+	if !strings.Contains(buf.String(), "Members     : no other processes\n") {
+		t.Errorf("expected a unit with only its main process to say so; output:\n%s", buf.String())
+	}
+}
+
+func TestRenderUnitStatus(t *testing.T) {
+	t.Parallel()
+
+	u := model.UnitStatus{
+		Name:        "backup.service",
+		Description: "Nightly backup",
+		UnitFile:    "/etc/systemd/system/backup.service",
+		ActiveState: "failed",
+		SubState:    "failed",
+		Result:      "exit-code",
+		Restarts:    3,
+	}
+	var buf bytes.Buffer
+	RenderUnitStatus(&buf, u, false)
+	want := "Unit        : backup.service\n" +
+		"Description : Nightly backup\n" +
+		"Unit File   : /etc/systemd/system/backup.service\n" +
+		"Unit State  : failed (failed), last run: exit-code\n" +
+		"Restarts    : 3\n" +
+		"Processes   : none running\n"
+	if got := buf.String(); got != want {
+		t.Errorf("RenderUnitStatus =\n%s\nwant\n%s", got, want)
+	}
+
+	buf.Reset()
+	RenderUnitStatus(&buf, u, true)
+	if !strings.Contains(buf.String(), string(ColorCyan)+"Unit State") {
+		t.Errorf("colored output should highlight labels: %q", buf.String())
+	}
+
+	js, err := UnitStatusToJSON(u)
+	if err != nil {
+		t.Fatalf("UnitStatusToJSON: %v", err)
+	}
+	var back model.UnitStatus
+	if err := json.Unmarshal([]byte(js), &back); err != nil || back.Name != u.Name || back.Result != "exit-code" {
+		t.Errorf("UnitStatusToJSON round trip = %+v, %v", back, err)
+	}
+}
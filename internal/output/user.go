@@ -0,0 +1,94 @@
+package output
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/pranshuparmar/witr/pkg/model"
+)
+
+// maxTopConsumers caps the processes listed per group as its top CPU and
+// memory consumers.
+const maxTopConsumers = 3
+
+// RenderUserSummary prints the --user report: the user's process count and
+// usage, then their services, containers and sessions, each with its top
+// CPU and memory consumers and the ports it listens on.
+func RenderUserSummary(w io.Writer, s model.UserSummary, colorEnabled bool) {
+	out := NewPrinter(w)
+
+	blue, green, dim, reset := ansiString(""), ansiString(""), ansiString(""), ansiString("")
+	if colorEnabled {
+		blue, green, dim, reset = ColorBlue, ColorGreen, ColorDim, ColorReset
+	}
+
+	who := s.User
+	if s.UID != "" && s.UID != s.User {
+		who += " (uid " + s.UID + ")"
+	}
+	out.Printf("%sUser%s        : %s\n", blue, reset, who)
+	if s.Processes == 0 {
+		out.Printf("%sProcesses%s   : none\n", blue, reset)
+		return
+	}
+	out.Printf("%sProcesses%s   : %d (%s)\n", blue, reset, s.Processes, formatUsage(s.CPUPercent, s.MemoryRSS))
+
+	for i, g := range s.Groups {
+		if i == 0 || s.Groups[i-1].Kind != g.Kind {
+			out.Printf("\n%s%s%s:\n", blue, restartKindTitles[g.Kind].section, reset)
+		}
+		out.Printf("  %s%s%s  %s(%s, %s)%s\n", green, g.Name, reset, dim,
+			plural(len(g.Processes), "process", "processes"), formatUsage(g.CPUPercent, g.MemoryRSS), reset)
+
+		out.Printf("    Top CPU    : %s\n", topConsumers(g.Processes, func(p model.UserProcess) (float64, string) {
+			return p.CPUPercent, fmt.Sprintf("%.1f%%", p.CPUPercent)
+		}))
+		out.Printf("    Top memory : %s\n", topConsumers(g.Processes, func(p model.UserProcess) (float64, string) {
+			return float64(p.MemoryRSS), formatBytes(p.MemoryRSS)
+		}))
+		for j, l := range g.Listening {
+			label := "Listening  :"
+			if j > 0 {
+				label = "            "
+			}
+			out.Printf("    %s %s %s (%s, pid %d)\n", label, l.Protocol, listenerAddress(model.PortListener{Address: l.Address, Port: l.Port}), l.Command, l.PID)
+		}
+	}
+}
+
+// formatUsage formats a CPU share and resident memory: "2.1% CPU, 210.5 MB memory".
+func formatUsage(cpu float64, rss uint64) string {
+	return fmt.Sprintf("%.1f%% CPU, %s memory", cpu, formatBytes(rss))
+}
+
+// topConsumers lists the processes with the highest value of one measure,
+// as "syncthing (pid 3101, 1.9%)".
+func topConsumers(procs []model.UserProcess, measure func(model.UserProcess) (float64, string)) string {
+	ranked := append([]model.UserProcess(nil), procs...)
+	sort.SliceStable(ranked, func(i, j int) bool {
+		a, _ := measure(ranked[i])
+		b, _ := measure(ranked[j])
+		return a > b
+	})
+	if len(ranked) > maxTopConsumers {
+		ranked = ranked[:maxTopConsumers]
+	}
+	parts := make([]string, len(ranked))
+	for i, p := range ranked {
+		_, value := measure(p)
+		parts[i] = fmt.Sprintf("%s (pid %d, %s)", p.Command, p.PID, value)
+	}
+	return strings.Join(parts, ", ")
+}
+
+// UserSummaryToJSON renders the --user report as JSON.
+func UserSummaryToJSON(s model.UserSummary) (string, error) {
+	data, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return "", err
+	}
+	return string(data), nil
+}
@@ -0,0 +1,95 @@
+package output
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/pranshuparmar/witr/pkg/model"
+)
+
+func userSummaryFixture() model.UserSummary {
+	return model.UserSummary{
+		User: "alice", UID: "1000",
+		Processes: 6, CPUPercent: 14.2, MemoryRSS: 900 << 20,
+		Groups: []model.UserGroup{
+			{
+				Kind: "service", Source: model.SourceSystemd, Name: "syncthing.service",
+				CPUPercent: 2.1, MemoryRSS: 200 << 20,
+				Processes: []model.UserProcess{
+					{PID: 3101, Command: "syncthing", CPUPercent: 1.9, MemoryRSS: 180 << 20},
+					{PID: 3150, Command: "rclone", CPUPercent: 0.2, MemoryRSS: 20 << 20},
+				},
+				Listening: []model.UserListener{
+					{Protocol: "tcp", Address: "127.0.0.1", Port: 8384, PID: 3101, Command: "syncthing"},
+					{Protocol: "udp6", Address: "::", Port: 21027, PID: 3101, Command: "syncthing"},
+				},
+			},
+			{
+				Kind: "session", Source: model.SourceSSH, Name: "SSH session from 10.0.0.5 (alice@pts/0)",
+				CPUPercent: 12.1, MemoryRSS: 700 << 20,
+				Processes: []model.UserProcess{
+					{PID: 4001, Command: "node", CPUPercent: 11.5, MemoryRSS: 150 << 20},
+					{PID: 4002, Command: "java", CPUPercent: 0.5, MemoryRSS: 540 << 20},
+					{PID: 4003, Command: "bash", CPUPercent: 0.1, MemoryRSS: 6 << 20},
+					{PID: 4000, Command: "sshd", CPUPercent: 0, MemoryRSS: 4 << 20},
+				},
+			},
+		},
+	}
+}
+
+func TestRenderUserSummary(t *testing.T) {
+	t.Parallel()
+
+	var buf bytes.Buffer
+	RenderUserSummary(&buf, userSummaryFixture(), false)
+
+	want := strings.Join([]string{
+		"User        : alice (uid 1000)",
+		"Processes   : 6 (14.2% CPU, 900.0 MB memory)",
+		"",
+		"Services:",
+		"  syncthing.service  (2 processes, 2.1% CPU, 200.0 MB memory)",
+		"    Top CPU    : syncthing (pid 3101, 1.9%), rclone (pid 3150, 0.2%)",
+		"    Top memory : syncthing (pid 3101, 180.0 MB), rclone (pid 3150, 20.0 MB)",
+		"    Listening  : tcp 127.0.0.1:8384 (syncthing, pid 3101)",
+		"                 udp6 [::]:21027 (syncthing, pid 3101)",
+		"",
+		"User sessions:",
+		"  SSH session from 10.0.0.5 (alice@pts/0)  (4 processes, 12.1% CPU, 700.0 MB memory)",
+		"    Top CPU    : node (pid 4001, 11.5%), java (pid 4002, 0.5%), bash (pid 4003, 0.1%)",
+		"    Top memory : java (pid 4002, 540.0 MB), node (pid 4001, 150.0 MB), bash (pid 4003, 6.0 MB)",
+		"",
+	}, "\n")
+	if got := buf.String(); got != want {
+		t.Fatalf("unexpected output:\n%s\nwant:\n%s", got, want)
+	}
+}
+
+func TestRenderUserSummaryNoProcesses(t *testing.T) {
+	t.Parallel()
+
+	var buf bytes.Buffer
+	RenderUserSummary(&buf, model.UserSummary{User: "4242", UID: "4242"}, false)
+	if got, want := buf.String(), "User        : 4242\nProcesses   : none\n"; got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+}
+
+func TestUserSummaryToJSON(t *testing.T) {
+	t.Parallel()
+
+	out, err := UserSummaryToJSON(userSummaryFixture())
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got model.UserSummary
+	if err := json.Unmarshal([]byte(out), &got); err != nil {
+		t.Fatalf("invalid JSON: %v\n%s", err, out)
+	}
+	if got.UID != "1000" || len(got.Groups) != 2 || len(got.Groups[0].Listening) != 2 || got.Groups[1].Source != model.SourceSSH {
+		t.Errorf("unexpected round trip: %+v", got)
+	}
+}
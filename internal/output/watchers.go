@@ -0,0 +1,75 @@
+package output
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/pranshuparmar/witr/pkg/model"
+)
+
+// watchScopeLabels describe what a watch is placed on.
+var watchScopeLabels = map[string]string{
+	"path":       "path",
+	"parent":     "parent directory",
+	"mount":      "whole mount",
+	"filesystem": "whole filesystem",
+}
+
+// RenderWatchers prints the processes holding inotify or fanotify watches
+// on a path, each watch with what it covers and the events it asks for.
+func RenderWatchers(w io.Writer, fw model.FileWatchers, colorEnabled bool) {
+	out := NewPrinter(w)
+
+	blue, reset := ansiString(""), ansiString("")
+	if colorEnabled {
+		blue, reset = ColorBlue, ColorReset
+	}
+	out.Printf("%sPath%s        : %s\n", blue, reset, fw.Path)
+
+	if len(fw.Watchers) == 0 {
+		if colorEnabled {
+			out.Printf("%sWatched by%s  : %snobody%s (%d processes scanned)\n", blue, reset, ColorGreen, ColorReset, fw.Scanned)
+		} else {
+			out.Printf("Watched by  : nobody (%d processes scanned)\n", fw.Scanned)
+		}
+		renderUnreadable(out, fw.Unreadable, colorEnabled)
+		return
+	}
+	noun := "processes"
+	if len(fw.Watchers) == 1 {
+		noun = "process"
+	}
+	out.Printf("%sWatched by%s  : %d %s\n", blue, reset, len(fw.Watchers), noun)
+
+	for _, watcher := range fw.Watchers {
+		out.Println()
+		renderProcessOrigin(out, "", watcher.Command, watcher.PID, watcher.User, watcher.StartedBy, colorEnabled)
+		for _, watch := range watcher.Watches {
+			id := fmt.Sprintf("fd %d", watch.FD)
+			if watch.API == "inotify" {
+				id += fmt.Sprintf(" wd %d", watch.WD)
+			}
+			scope := watchScopeLabels[watch.Scope]
+			if colorEnabled {
+				out.Printf("  %s%s %s%s  %s: %s\n", ColorDim, watch.API, id, ColorReset, scope, strings.Join(watch.Events, ", "))
+			} else {
+				out.Printf("  %s %s  %s: %s\n", watch.API, id, scope, strings.Join(watch.Events, ", "))
+			}
+		}
+	}
+	if fw.Unreadable > 0 {
+		out.Println()
+		renderUnreadable(out, fw.Unreadable, colorEnabled)
+	}
+}
+
+// WatchersToJSON renders a path's watchers as JSON.
+func WatchersToJSON(fw model.FileWatchers) (string, error) {
+	data, err := json.MarshalIndent(fw, "", "  ")
+	if err != nil {
+		return "", err
+	}
+	return string(data), nil
+}
@@ -0,0 +1,81 @@
+package output
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/pranshuparmar/witr/pkg/model"
+)
+
+func sampleWatchers() model.FileWatchers {
+	return model.FileWatchers{
+		Path: "/etc/nginx/nginx.conf",
+		Watchers: []model.FileWatcher{
+			{PID: 1312, Command: "nginx-reloader", User: "root", Source: model.SourceSystemd, StartedBy: "nginx-reloader.service", Watches: []model.Watch{
+				{API: "inotify", FD: 3, WD: 1, Scope: "parent", Mask: 0x308, Events: []string{"close_write", "create", "delete"}},
+				{API: "inotify", FD: 3, WD: 2, Scope: "path", Mask: 0x2, Events: []string{"modify"}},
+			}},
+			{PID: 640, Command: "auditor", Watches: []model.Watch{
+				{API: "fanotify", FD: 5, Scope: "mount", Mask: 0x20, Events: []string{"open"}},
+			}},
+		},
+		Scanned:    96,
+		Unreadable: 1,
+	}
+}
+
+func TestRenderWatchers(t *testing.T) {
+	var buf bytes.Buffer
+	RenderWatchers(&buf, sampleWatchers(), false)
+	out := buf.String()
+	for _, want := range []string{
+		"Path        : /etc/nginx/nginx.conf\n",
+		"Watched by  : 2 processes\n",
+		"nginx-reloader (pid 1312, root)  started by nginx-reloader.service\n",
+		"  inotify fd 3 wd 1  parent directory: close_write, create, delete\n",
+		"  inotify fd 3 wd 2  path: modify\n",
+		"auditor (pid 640)\n  fanotify fd 5  whole mount: open\n",
+		"1 process could not be inspected",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("output missing %q\n---\n%s", want, out)
+		}
+	}
+}
+
+func TestRenderWatchersNobody(t *testing.T) {
+	var buf bytes.Buffer
+	RenderWatchers(&buf, model.FileWatchers{Path: "/srv/app.yaml", Scanned: 42}, false)
+	want := "Path        : /srv/app.yaml\nWatched by  : nobody (42 processes scanned)\n"
+	if got := buf.String(); got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+}
+
+func TestRenderWatchersColored(t *testing.T) {
+	var buf bytes.Buffer
+	RenderWatchers(&buf, sampleWatchers(), true)
+	out := buf.String()
+	if !strings.Contains(out, string(ColorDim)+"inotify fd 3 wd 1"+string(ColorReset)) {
+		t.Errorf("colored output missing dimmed watch id:\n%q", out)
+	}
+}
+
+func TestWatchersToJSON(t *testing.T) {
+	s, err := WatchersToJSON(sampleWatchers())
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got model.FileWatchers
+	if err := json.Unmarshal([]byte(s), &got); err != nil {
+		t.Fatalf("invalid JSON: %v\n%s", err, s)
+	}
+	if len(got.Watchers) != 2 || got.Watchers[0].Watches[1].Scope != "path" || got.Watchers[1].Watches[0].API != "fanotify" {
+		t.Errorf("unexpected JSON:\n%s", s)
+	}
+	if strings.Contains(s, `"WD": 0`) {
+		t.Errorf("fanotify watch should omit WD:\n%s", s)
+	}
+}
@@ -4,17 +4,30 @@ import (
 	"sort"
 	"strconv"
 	"strings"
+	"time"
 
 	procpkg "github.com/pranshuparmar/witr/internal/proc"
 	"github.com/pranshuparmar/witr/internal/source"
 	"github.com/pranshuparmar/witr/pkg/model"
 )
 
+// ThreadSampleInterval is how long the threads view watches a process to
+// measure per-thread CPU usage: long enough to catch a spinning thread, short
+// enough not to stall the command.
+const ThreadSampleInterval = 250 * time.Millisecond
+
 type AnalyzeConfig struct {
 	PID     int
 	Verbose bool
 	Tree    bool
-	Target  model.Target
+	Threads bool
+	// JSON output carries every field, including those the standard output
+	// only shows in verbose mode.
+	JSON bool
+	// Quick skips resampling a blocked process, which pauses for up to half
+	// a second. Set when many processes are analysed in a row.
+	Quick  bool
+	Target model.Target
 }
 
 func AnalyzePID(cfg AnalyzeConfig) (model.Result, error) {
@@ -60,6 +73,27 @@ func AnalyzePID(cfg AnalyzeConfig) (model.Result, error) {
 		}
 	}
 
+	// Per-target diagnostics. Most feed warnings, so the cheap ones (a few
+	// small /proc reads each) always run. Only the costly parts are gated:
+	// unix socket peers scan every process's fds and are listed in verbose
+	// mode or for a socket target, library build IDs open every mapped ELF and
+	// are read for verbose or JSON output, and blocking diagnostics skip
+	// resampling when Quick and list file offsets only in verbose mode.
+	if proc.PID > 0 {
+		proc.Namespaces, proc.NamespacesComparedTo = procpkg.ReadNamespaces(proc.PID)
+		proc.Cgroup = procpkg.ReadCgroupStats(proc.PID)
+		proc.Security = procpkg.ReadSecurityContext(proc)
+		proc.Scheduling = procpkg.ReadScheduling(proc)
+		proc.Limits = procpkg.ReadLimits(proc)
+		proc.Sockets = procpkg.ResolveSocketPeers(proc.Sockets)
+		if cfg.Verbose || cfg.Target.Type == model.TargetSocket {
+			proc.UnixSockets = procpkg.ReadUnixSockets(proc.PID)
+		}
+		proc.Libraries = procpkg.ReadLibraries(proc.PID, cfg.Verbose || cfg.JSON)
+		proc.Blocking = procpkg.ReadBlocking(proc, cfg.Verbose, !cfg.Quick)
+		ancestry[len(ancestry)-1] = proc
+	}
+
 	// Collect child PIDs once and reuse for both extended info and tree output
 	var childPIDs []int
 	var childProcesses []model.Process
@@ -93,6 +127,14 @@ func AnalyzePID(cfg AnalyzeConfig) (model.Result, error) {
 		}
 	}
 
+	if cfg.Threads && len(ancestry) > 0 {
+		if threads, err := procpkg.SampleThreads(cfg.PID, ThreadSampleInterval); err == nil {
+			proc.Threads = threads
+			proc.ThreadCount = len(threads)
+			ancestry[len(ancestry)-1] = proc
+		}
+	}
+
 	var resCtx *model.ResourceContext
 	var fileCtx *model.FileContext
 	if cfg.Verbose {
@@ -109,6 +151,9 @@ func AnalyzePID(cfg AnalyzeConfig) (model.Result, error) {
 		}
 	}
 
+	warnings := source.Warnings(ancestry, restartCount, src.Type)
+	warnings = append(warnings, source.SourceWarnings(src)...)
+
 	res := model.Result{
 		Target:          cfg.Target,
 		ResolvedTarget:  resolvedTarget,
@@ -116,7 +161,7 @@ func AnalyzePID(cfg AnalyzeConfig) (model.Result, error) {
 		RestartCount:    restartCount,
 		Ancestry:        ancestry,
 		Source:          src,
-		Warnings:        source.Warnings(ancestry, restartCount, src.Type),
+		Warnings:        warnings,
 		ResourceContext: resCtx,
 		FileContext:     fileCtx,
 		Children:        childProcesses,
@@ -1,8 +1,13 @@
 package pipeline
 
 import (
+	"net"
 	"os"
+	"path/filepath"
+	"runtime"
 	"testing"
+
+	"github.com/pranshuparmar/witr/pkg/model"
 )
 
 // TestAnalyzePID_Self drives the full pipeline (ancestry walk → source
@@ -48,6 +53,51 @@ func TestAnalyzePID_Verbose(t *testing.T) {
 	}
 }
 
+// TestAnalyzePID_UnixSocketsGated checks that unix sockets, whose peer
+// lookup scans every process, are only listed in verbose mode or for a
+// socket target.
+func TestAnalyzePID_UnixSocketsGated(t *testing.T) {
+	if runtime.GOOS != "linux" {
+		t.Skip("unix socket listing is Linux-only")
+	}
+	self := os.Getpid()
+	path := filepath.Join(t.TempDir(), "gate.sock")
+	ln, err := net.Listen("unix", path)
+	if err != nil {
+		t.Skipf("listen %s: %v", path, err)
+	}
+	defer ln.Close()
+
+	serves := func(res model.Result) bool {
+		for _, s := range res.Process.UnixSockets {
+			if s.Path == path {
+				return true
+			}
+		}
+		return false
+	}
+
+	res, err := AnalyzePID(AnalyzeConfig{PID: self})
+	if err != nil {
+		t.Fatalf("AnalyzePID(self=%d): %v", self, err)
+	}
+	if len(res.Process.UnixSockets) != 0 {
+		t.Errorf("expected no unix sockets without --verbose, got %v", res.Process.UnixSockets)
+	}
+	for _, cfg := range []AnalyzeConfig{
+		{PID: self, Verbose: true},
+		{PID: self, Target: model.Target{Type: model.TargetSocket, Value: path}},
+	} {
+		res, err := AnalyzePID(cfg)
+		if err != nil {
+			t.Fatalf("AnalyzePID(%+v): %v", cfg, err)
+		}
+		if !serves(res) {
+			t.Errorf("AnalyzePID(%+v): %s not among %v", cfg, path, res.Process.UnixSockets)
+		}
+	}
+}
+
 // TestAnalyzePID_Nonexistent confirms the pipeline surfaces an error rather than
 // returning a zero-value result for a PID that doesn't exist.
 func TestAnalyzePID_Nonexistent(t *testing.T) {
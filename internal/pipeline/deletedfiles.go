@@ -0,0 +1,25 @@
+package pipeline
+
+import (
+	procpkg "github.com/pranshuparmar/witr/internal/proc"
+	"github.com/pranshuparmar/witr/pkg/model"
+)
+
+// AuditDeletedFiles finds the processes holding deleted files open and
+// names each one along with the unit, container or session to restart to
+// free the space.
+func AuditDeletedFiles() (model.DeletedFilesAudit, error) {
+	audit, err := procpkg.ScanDeletedFiles()
+	if err != nil {
+		return audit, err
+	}
+
+	origins := make(processOrigins)
+	for i := range audit.Processes {
+		h := &audit.Processes[i]
+		o := origins.lookup(h.PID)
+		h.Command, h.User = o.command, o.user
+		h.Source, h.StartedBy = o.source, o.startedBy
+	}
+	return audit, nil
+}
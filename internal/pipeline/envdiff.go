@@ -0,0 +1,141 @@
+package pipeline
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	procpkg "github.com/pranshuparmar/witr/internal/proc"
+	"github.com/pranshuparmar/witr/internal/source"
+	"github.com/pranshuparmar/witr/pkg/model"
+)
+
+// serviceManagerEnv lists variables systemd sets for services on top of
+// their declared environment, along with the LC_* locale variables. They
+// count as part of the unit baseline unless the unit declares them itself.
+var serviceManagerEnv = map[string]bool{
+	"PATH":                       true,
+	"LANG":                       true,
+	"LANGUAGE":                   true,
+	"USER":                       true,
+	"LOGNAME":                    true,
+	"HOME":                       true,
+	"SHELL":                      true,
+	"INVOCATION_ID":              true,
+	"JOURNAL_STREAM":             true,
+	"SYSTEMD_EXEC_PID":           true,
+	"NOTIFY_SOCKET":              true,
+	"MAINPID":                    true,
+	"MANAGERPID":                 true,
+	"LISTEN_PID":                 true,
+	"LISTEN_FDS":                 true,
+	"LISTEN_FDNAMES":             true,
+	"WATCHDOG_PID":               true,
+	"WATCHDOG_USEC":              true,
+	"RUNTIME_DIRECTORY":          true,
+	"STATE_DIRECTORY":            true,
+	"CACHE_DIRECTORY":            true,
+	"LOGS_DIRECTORY":             true,
+	"CONFIGURATION_DIRECTORY":    true,
+	"CREDENTIALS_DIRECTORY":      true,
+	"MEMORY_PRESSURE_WATCH":      true,
+	"MEMORY_PRESSURE_WRITE":      true,
+	"TERM":                       true,
+	"SYSTEMD_NSS_DYNAMIC_BYPASS": true,
+}
+
+func setByServiceManager(name string) bool {
+	return serviceManagerEnv[name] || strings.HasPrefix(name, "LC_")
+}
+
+// DiffEnvironment compares pid's environment to a baseline. A systemd
+// service's main process is compared to the environment its unit declares;
+// anything else, or a service whose unit can't be queried, is compared to
+// its parent's environment.
+func DiffEnvironment(pid int) (model.Result, error) {
+	ancestry, err := procpkg.ResolveAncestry(pid)
+	if err != nil {
+		return model.Result{}, err
+	}
+	proc := ancestry[len(ancestry)-1]
+	res := model.Result{Process: proc, Ancestry: ancestry}
+	if len(proc.Env) == 0 {
+		return res, fmt.Errorf("environment of pid %d is not readable: insufficient permissions (try sudo)", pid)
+	}
+
+	src := source.Detect(ancestry)
+	if src.Type == model.SourceSystemd && src.Name != "" && proc.PPID == 1 {
+		if declared, ok := source.UnitEnvironment(src.Name); ok {
+			diff := diffEnv(proc.Env, declared, setByServiceManager)
+			diff.Baseline = "unit"
+			diff.BaselineName = src.Name
+			res.EnvDiff = &diff
+			return res, nil
+		}
+	}
+
+	if len(ancestry) < 2 {
+		return res, fmt.Errorf("pid %d has no parent process to compare against", pid)
+	}
+	parent := ancestry[len(ancestry)-2]
+	if len(parent.Env) == 0 {
+		return res, fmt.Errorf("environment of parent pid %d is not readable: insufficient permissions (try sudo)", parent.PID)
+	}
+	diff := diffEnv(proc.Env, parent.Env, nil)
+	diff.Baseline = "parent"
+	diff.BaselinePID = parent.PID
+	diff.BaselineName = parent.Command
+	res.EnvDiff = &diff
+	return res, nil
+}
+
+// diffEnv compares env to baseline, both as KEY=VALUE lists where a later
+// entry overrides an earlier one. Variables implicit reports that are missing
+// from the baseline are taken as given: they are neither reported as added
+// nor counted as removed.
+func diffEnv(env, baseline []string, implicit func(string) bool) model.EnvDiff {
+	current := envMap(env)
+	base := envMap(baseline)
+
+	diff := model.EnvDiff{Changes: []model.EnvChange{}}
+	for name, value := range current {
+		old, ok := base[name]
+		switch {
+		case !ok && implicit != nil && implicit(name):
+			diff.Unchanged++
+		case !ok:
+			diff.Changes = append(diff.Changes, model.EnvChange{Name: name, Kind: "added", Value: value})
+		case old != value:
+			diff.Changes = append(diff.Changes, model.EnvChange{Name: name, Kind: "changed", Value: value, BaselineValue: old})
+		default:
+			diff.Unchanged++
+		}
+	}
+	for name, old := range base {
+		if _, ok := current[name]; !ok {
+			diff.Changes = append(diff.Changes, model.EnvChange{Name: name, Kind: "removed", BaselineValue: old})
+		}
+	}
+
+	kindOrder := map[string]int{"added": 0, "changed": 1, "removed": 2}
+	sort.Slice(diff.Changes, func(i, j int) bool {
+		a, b := diff.Changes[i], diff.Changes[j]
+		if a.Kind != b.Kind {
+			return kindOrder[a.Kind] < kindOrder[b.Kind]
+		}
+		return a.Name < b.Name
+	})
+	return diff
+}
+
+// envMap indexes a KEY=VALUE list by name. "_" is left out: shells set it
+// to the path of each program they run, so it differs for every child.
+func envMap(env []string) map[string]string {
+	m := make(map[string]string, len(env))
+	for _, kv := range env {
+		if name, value, ok := strings.Cut(kv, "="); ok && name != "" && name != "_" {
+			m[name] = value
+		}
+	}
+	return m
+}
@@ -0,0 +1,48 @@
+package pipeline
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/pranshuparmar/witr/pkg/model"
+)
+
+func TestDiffEnv(t *testing.T) {
+	t.Parallel()
+
+	parent := []string{"PATH=/usr/bin", "HOME=/root", "LANG=C", "EDITOR=vi", "EDITOR=nano", "_=/bin/bash"}
+	env := []string{"PATH=/usr/bin", "HOME=/home/app", "LD_PRELOAD=/tmp/x.so", "EDITOR=nano", "API_KEY=k", "_=/usr/bin/node"}
+
+	got := diffEnv(env, parent, nil)
+	want := model.EnvDiff{
+		Changes: []model.EnvChange{
+			{Name: "API_KEY", Kind: "added", Value: "k"},
+			{Name: "LD_PRELOAD", Kind: "added", Value: "/tmp/x.so"},
+			{Name: "HOME", Kind: "changed", Value: "/home/app", BaselineValue: "/root"},
+			{Name: "LANG", Kind: "removed", BaselineValue: "C"},
+		},
+		Unchanged: 2,
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("diffEnv =\n%+v\nwant\n%+v", got, want)
+	}
+}
+
+func TestDiffEnvUnitBaseline(t *testing.T) {
+	t.Parallel()
+
+	declared := []string{"PORT=8080", "PATH=/opt/app/bin"}
+	env := []string{"PORT=8080", "PATH=/usr/bin", "INVOCATION_ID=abc", "LC_ALL=C.UTF-8", "LD_PRELOAD=/tmp/x.so"}
+
+	got := diffEnv(env, declared, setByServiceManager)
+	want := model.EnvDiff{
+		Changes: []model.EnvChange{
+			{Name: "LD_PRELOAD", Kind: "added", Value: "/tmp/x.so"},
+			{Name: "PATH", Kind: "changed", Value: "/usr/bin", BaselineValue: "/opt/app/bin"},
+		},
+		Unchanged: 3,
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("diffEnv =\n%+v\nwant\n%+v", got, want)
+	}
+}
@@ -0,0 +1,23 @@
+package pipeline
+
+import (
+	procpkg "github.com/pranshuparmar/witr/internal/proc"
+	"github.com/pranshuparmar/witr/pkg/model"
+)
+
+// AuditHidden looks for processes missing from the /proc listing and
+// resolves the ancestry of each one it finds. A hidden process's /proc
+// entries can usually still be read by number even though the listing
+// leaves them out.
+func AuditHidden() (model.HiddenAudit, error) {
+	audit, err := procpkg.ScanHiddenPIDs()
+	if err != nil {
+		return audit, err
+	}
+	for i := range audit.Hidden {
+		if ancestry, err := procpkg.ResolveAncestry(audit.Hidden[i].PID); err == nil {
+			audit.Hidden[i].Ancestry = ancestry
+		}
+	}
+	return audit, nil
+}
@@ -0,0 +1,63 @@
+package pipeline
+
+import (
+	procpkg "github.com/pranshuparmar/witr/internal/proc"
+	"github.com/pranshuparmar/witr/internal/source"
+	"github.com/pranshuparmar/witr/pkg/model"
+)
+
+// AnalyzePathUsage finds the processes keeping dir busy and names each one
+// along with the unit, container or session that started it.
+func AnalyzePathUsage(dir string) (model.PathUsage, error) {
+	usage, err := procpkg.ScanPathUsers(dir)
+	if err != nil {
+		return usage, err
+	}
+
+	origins := make(processOrigins)
+	for gi := range usage.Groups {
+		users := usage.Groups[gi].Processes
+		for i := range users {
+			o := origins.lookup(users[i].PID)
+			users[i].Command, users[i].User = o.command, o.user
+			users[i].Source, users[i].StartedBy = o.source, o.startedBy
+		}
+	}
+	return usage, nil
+}
+
+// processOrigin is a process's name and owner and what started it.
+type processOrigin struct {
+	command, user string
+	source        model.SourceType
+	// Unit, container or session name, as in the --needs-restart report;
+	// empty when the source is unknown.
+	startedBy string
+	// Ancestry, oldest first.
+	chain []model.ChainLink
+}
+
+// processOrigins caches origins by PID across the entries of a report.
+type processOrigins map[int]processOrigin
+
+// lookup resolves pid's ancestry and source once. A process that exited in
+// the meantime gets an empty origin.
+func (c processOrigins) lookup(pid int) processOrigin {
+	if o, ok := c[pid]; ok {
+		return o
+	}
+	var o processOrigin
+	if ancestry, err := procpkg.ResolveAncestry(pid); err == nil {
+		proc := ancestry[len(ancestry)-1]
+		src := source.Detect(ancestry)
+		o = processOrigin{command: proc.Command, user: proc.User, source: src.Type}
+		for _, p := range ancestry {
+			o.chain = append(o.chain, model.ChainLink{PID: p.PID, Command: p.Command})
+		}
+		if src.Type != model.SourceUnknown {
+			o.startedBy = restartGroup(src, proc).Name
+		}
+	}
+	c[pid] = o
+	return o
+}
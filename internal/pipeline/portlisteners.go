@@ -0,0 +1,44 @@
+package pipeline
+
+import (
+	"strings"
+
+	procpkg "github.com/pranshuparmar/witr/internal/proc"
+	"github.com/pranshuparmar/witr/pkg/model"
+)
+
+// AnalyzePortListeners lists every process listening on the ports q
+// selects, each with its ancestry and what started it.
+func AnalyzePortListeners(q model.PortQuery) (model.PortListeners, error) {
+	result := model.PortListeners{Query: q.String(), Listeners: []model.PortListener{}}
+	ports, err := procpkg.ListPortListeners(q)
+	if err != nil {
+		return result, err
+	}
+
+	origins := make(processOrigins)
+	for _, p := range ports {
+		if p.PID <= 0 {
+			continue
+		}
+		o := origins.lookup(p.PID)
+		result.Listeners = append(result.Listeners, model.PortListener{
+			Protocol:  listenerProtocol(p.Protocol),
+			Address:   p.Address,
+			Port:      p.Port,
+			PID:       p.PID,
+			Command:   o.command,
+			User:      o.user,
+			Source:    o.source,
+			StartedBy: o.startedBy,
+			Chain:     o.chain,
+		})
+	}
+	return result, nil
+}
+
+// listenerProtocol normalizes the platforms' protocol labels ("TCP6",
+// "TCPv6", "UDP") to "tcp6", "udp" and so on.
+func listenerProtocol(protocol string) string {
+	return strings.ReplaceAll(strings.ToLower(protocol), "v", "")
+}
@@ -0,0 +1,144 @@
+package pipeline
+
+import (
+	"fmt"
+	"os"
+	"slices"
+	"sort"
+	"strings"
+
+	procpkg "github.com/pranshuparmar/witr/internal/proc"
+	"github.com/pranshuparmar/witr/internal/source"
+	"github.com/pranshuparmar/witr/pkg/model"
+)
+
+// restartKinds maps sources to the kind of thing that gets restarted.
+// Anything else is "other".
+var restartKinds = map[model.SourceType]string{
+	model.SourceSystemd:    "service",
+	model.SourceBsdRc:      "service",
+	model.SourceSupervisor: "service",
+	model.SourceContainer:  "container",
+	model.SourceSSH:        "session",
+	model.SourceShell:      "session",
+}
+
+// restartKindOrder lists services first, then containers, then sessions.
+var restartKindOrder = []string{"service", "container", "session", "other"}
+
+// AuditRestarts scans every process for deleted or replaced executables and
+// shared libraries, and groups the affected ones by their detected source.
+// Only the affected processes pay for ancestry and source detection.
+func AuditRestarts() (model.RestartAudit, error) {
+	snapshot, err := procpkg.ListProcessSnapshot()
+	if err != nil {
+		return model.RestartAudit{}, err
+	}
+
+	audit := model.RestartAudit{Groups: []model.RestartGroup{}, Scanned: len(snapshot)}
+	groups := make(map[string]*model.RestartGroup)
+	self := os.Getpid()
+	for _, p := range snapshot {
+		if p.PID == self {
+			continue
+		}
+		exe, libs, ok := procpkg.ReadStaleCode(p.PID)
+		if !ok {
+			audit.Unreadable++
+			continue
+		}
+		if exe == "" && len(libs) == 0 {
+			continue
+		}
+		ancestry, err := procpkg.ResolveAncestry(p.PID)
+		if err != nil {
+			continue // exited mid-scan
+		}
+		proc := ancestry[len(ancestry)-1]
+
+		group := restartGroup(source.Detect(ancestry), proc)
+		key := string(group.Source) + "\x00" + group.Name
+		g, ok := groups[key]
+		if !ok {
+			g = &group
+			groups[key] = g
+		}
+		g.Processes = append(g.Processes, model.RestartProcess{
+			PID:        proc.PID,
+			Command:    proc.Command,
+			User:       proc.User,
+			Executable: exe,
+			Libraries:  libs,
+		})
+	}
+
+	for _, g := range groups {
+		sort.Slice(g.Processes, func(i, j int) bool {
+			return g.Processes[i].PID < g.Processes[j].PID
+		})
+		audit.Groups = append(audit.Groups, *g)
+	}
+	sortRestartGroups(audit.Groups)
+	return audit, nil
+}
+
+// restartGroup names the unit, container or session proc belongs to and, where
+// witr knows it, the command that restarts it.
+func restartGroup(src model.Source, proc model.Process) model.RestartGroup {
+	g := model.RestartGroup{Kind: restartKinds[src.Type], Source: src.Type, Name: src.Name}
+	if g.Kind == "" {
+		g.Kind = "other"
+	}
+	switch src.Type {
+	case model.SourceSystemd:
+		// Session and user scopes have no restart; their processes do.
+		if strings.HasSuffix(src.Name, ".service") {
+			g.Restart = "systemctl restart " + src.Name
+		}
+	case model.SourceContainer:
+		if proc.Container != "" {
+			g.Name = proc.Container
+		}
+		switch proc.ContainerRuntime {
+		case "docker", "podman", "nerdctl":
+			if id := proc.ContainerID; id != "" {
+				if len(id) > 12 {
+					id = id[:12]
+				}
+				g.Restart = proc.ContainerRuntime + " restart " + id
+			}
+		}
+	case model.SourceSSH:
+		g.Name = src.Description
+	case model.SourceShell:
+		g.Name = src.Description
+		if g.Name == "" {
+			g.Name = fmt.Sprintf("%s session", src.Name)
+			if proc.User != "" {
+				g.Name += " (" + proc.User + ")"
+			}
+		}
+	}
+	if proc.PID == 1 && proc.Command == "systemd" {
+		g.Restart = "systemctl daemon-reexec"
+	}
+	if g.Name == "" {
+		g.Name = string(src.Type)
+	}
+	return g
+}
+
+func sortRestartGroups(groups []model.RestartGroup) {
+	sort.SliceStable(groups, func(i, j int) bool {
+		return groupLess(groups[i].Kind, groups[i].Name, groups[j].Kind, groups[j].Name)
+	})
+}
+
+// groupLess orders groups by kind, as restartKindOrder lists them, then by
+// name.
+func groupLess(kindI, nameI, kindJ, nameJ string) bool {
+	if kindI != kindJ {
+		return slices.Index(restartKindOrder, kindI) < slices.Index(restartKindOrder, kindJ)
+	}
+	return nameI < nameJ
+}
@@ -0,0 +1,89 @@
+package pipeline
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/pranshuparmar/witr/pkg/model"
+)
+
+func TestRestartGroup(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		src  model.Source
+		proc model.Process
+		want model.RestartGroup
+	}{
+		{
+			name: "systemd service",
+			src:  model.Source{Type: model.SourceSystemd, Name: "nginx.service"},
+			proc: model.Process{PID: 100, Command: "nginx"},
+			want: model.RestartGroup{Kind: "service", Source: model.SourceSystemd, Name: "nginx.service", Restart: "systemctl restart nginx.service"},
+		},
+		{
+			name: "systemd scope has no restart",
+			src:  model.Source{Type: model.SourceSystemd, Name: "session-3.scope"},
+			proc: model.Process{PID: 101},
+			want: model.RestartGroup{Kind: "service", Source: model.SourceSystemd, Name: "session-3.scope"},
+		},
+		{
+			name: "docker container",
+			src:  model.Source{Type: model.SourceContainer, Name: "docker"},
+			proc: model.Process{PID: 102, Container: "redis", ContainerRuntime: "docker", ContainerID: "3f2a1b4c5d6e7f8091a2b3c4d5e6f708"},
+			want: model.RestartGroup{Kind: "container", Source: model.SourceContainer, Name: "redis", Restart: "docker restart 3f2a1b4c5d6e"},
+		},
+		{
+			name: "ssh session",
+			src:  model.Source{Type: model.SourceSSH, Name: "sshd", Description: "SSH session from 10.0.0.5 (alice@pts/0)"},
+			proc: model.Process{PID: 103, User: "alice"},
+			want: model.RestartGroup{Kind: "session", Source: model.SourceSSH, Name: "SSH session from 10.0.0.5 (alice@pts/0)"},
+		},
+		{
+			name: "shell session",
+			src:  model.Source{Type: model.SourceShell, Name: "bash"},
+			proc: model.Process{PID: 104, User: "alice"},
+			want: model.RestartGroup{Kind: "session", Source: model.SourceShell, Name: "bash session (alice)"},
+		},
+		{
+			name: "systemd as pid 1",
+			src:  model.Source{Type: model.SourceInit, Name: "systemd"},
+			proc: model.Process{PID: 1, Command: "systemd"},
+			want: model.RestartGroup{Kind: "other", Source: model.SourceInit, Name: "systemd", Restart: "systemctl daemon-reexec"},
+		},
+		{
+			name: "unknown source",
+			src:  model.Source{Type: model.SourceUnknown},
+			proc: model.Process{PID: 105},
+			want: model.RestartGroup{Kind: "other", Source: model.SourceUnknown, Name: "unknown"},
+		},
+	}
+	for _, tt := range tests {
+		if got := restartGroup(tt.src, tt.proc); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("%s: restartGroup = %+v, want %+v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestSortRestartGroups(t *testing.T) {
+	t.Parallel()
+
+	groups := []model.RestartGroup{
+		{Kind: "other", Name: "init"},
+		{Kind: "session", Name: "bash session (alice)"},
+		{Kind: "container", Name: "redis"},
+		{Kind: "service", Name: "sshd.service"},
+		{Kind: "service", Name: "nginx.service"},
+	}
+	sortRestartGroups(groups)
+
+	var got []string
+	for _, g := range groups {
+		got = append(got, g.Name)
+	}
+	want := []string{"nginx.service", "sshd.service", "redis", "bash session (alice)", "init"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("order = %v, want %v", got, want)
+	}
+}
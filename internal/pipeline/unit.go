@@ -0,0 +1,84 @@
+package pipeline
+
+import (
+	"fmt"
+	"strings"
+
+	procpkg "github.com/pranshuparmar/witr/internal/proc"
+	"github.com/pranshuparmar/witr/internal/source"
+	"github.com/pranshuparmar/witr/pkg/model"
+)
+
+// unitSuffixes are the systemd unit types; a --unit value without one
+// names a service.
+var unitSuffixes = []string{
+	".service", ".socket", ".device", ".mount", ".automount", ".swap",
+	".target", ".path", ".timer", ".slice", ".scope",
+}
+
+// UnitName completes a --unit value to a full unit name: "nginx" becomes
+// "nginx.service".
+func UnitName(name string) string {
+	name = strings.TrimSpace(name)
+	for _, suffix := range unitSuffixes {
+		if strings.HasSuffix(name, suffix) {
+			return name
+		}
+	}
+	return name + ".service"
+}
+
+// ResolveUnit reads a systemd unit's state over D-Bus and lists the
+// processes in its control group. Without a usable bus the cgroup is found
+// by name in the cgroup filesystem, and the main process is taken to be
+// the lowest-numbered member whose parent is outside the unit. MainPID is 0
+// when the unit has no running processes.
+func ResolveUnit(name string) (model.UnitStatus, error) {
+	unit := UnitName(name)
+	if !source.IsSystemdRunning() {
+		return model.UnitStatus{Name: unit}, fmt.Errorf("systemd is not running on this host")
+	}
+
+	status, err := source.UnitStatus(unit)
+	if err != nil || status.LoadState == "not-found" {
+		// Units of a user's service manager aren't on the system bus, but
+		// their cgroups are named after them like any other.
+		status = model.UnitStatus{Name: unit, ControlGroup: procpkg.FindUnitCgroup(unit)}
+		if status.ControlGroup == "" {
+			if err != nil {
+				return status, fmt.Errorf("unit %q not found (systemd D-Bus API unavailable: %v)", unit, err)
+			}
+			return status, fmt.Errorf("unit %q not found", unit)
+		}
+	}
+
+	var procs []model.Process
+	if status.ControlGroup != "" {
+		procs = procpkg.CgroupProcesses(status.ControlGroup)
+	}
+	if status.MainPID == 0 {
+		status.MainPID = rootMember(procs)
+	}
+	for _, p := range procs {
+		if p.PID != status.MainPID {
+			status.Members = append(status.Members, p)
+		}
+	}
+	return status, nil
+}
+
+// rootMember returns the first process (by PID) whose parent is outside
+// procs: the one systemd started, for a service whose main PID it doesn't
+// track or couldn't report.
+func rootMember(procs []model.Process) int {
+	inUnit := make(map[int]bool, len(procs))
+	for _, p := range procs {
+		inUnit[p.PID] = true
+	}
+	for _, p := range procs {
+		if !inUnit[p.PPID] {
+			return p.PID
+		}
+	}
+	return 0
+}
@@ -0,0 +1,60 @@
+package pipeline
+
+import (
+	"testing"
+
+	"github.com/pranshuparmar/witr/pkg/model"
+)
+
+func TestUnitName(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct{ in, want string }{
+		{"nginx", "nginx.service"},
+		{" nginx.service ", "nginx.service"},
+		{"session-4.scope", "session-4.scope"},
+		{"docker.socket", "docker.socket"},
+		{"user-1000.slice", "user-1000.slice"},
+		{"getty@tty1", "getty@tty1.service"},
+		{"node.js-app", "node.js-app.service"},
+	}
+	for _, tt := range tests {
+		if got := UnitName(tt.in); got != tt.want {
+			t.Errorf("UnitName(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestRootMember(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name  string
+		procs []model.Process
+		want  int
+	}{
+		{"empty", nil, 0},
+		{
+			name: "forking daemon and its workers",
+			procs: []model.Process{
+				{PID: 812, PPID: 1, Command: "nginx"},
+				{PID: 813, PPID: 812, Command: "nginx"},
+				{PID: 814, PPID: 812, Command: "nginx"},
+			},
+			want: 812,
+		},
+		{
+			name: "worker with a lower PID than its reparented parent",
+			procs: []model.Process{
+				{PID: 300, PPID: 4000, Command: "worker"},
+				{PID: 4000, PPID: 1, Command: "supervisor"},
+			},
+			want: 4000,
+		},
+	}
+	for _, tt := range tests {
+		if got := rootMember(tt.procs); got != tt.want {
+			t.Errorf("%s: rootMember = %d, want %d", tt.name, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,128 @@
+package pipeline
+
+import (
+	"fmt"
+	"os"
+	"os/user"
+	"sort"
+	"strconv"
+	"strings"
+
+	procpkg "github.com/pranshuparmar/witr/internal/proc"
+	"github.com/pranshuparmar/witr/internal/source"
+	"github.com/pranshuparmar/witr/pkg/model"
+)
+
+// SummarizeUser collects every process the user named by value (a name or
+// UID) owns and groups them by the unit, container or session that started
+// them, with each group's CPU, memory and listening ports.
+func SummarizeUser(value string) (model.UserSummary, error) {
+	name, uid, err := lookupAccount(strings.TrimSpace(value))
+	if err != nil {
+		return model.UserSummary{User: value}, err
+	}
+	summary := model.UserSummary{User: name, UID: uid, Groups: []model.UserGroup{}}
+
+	procs, err := procpkg.ListProcesses()
+	if err != nil {
+		return summary, err
+	}
+
+	groups := make(map[string]*model.UserGroup)
+	byPID := make(map[int]*model.UserGroup)
+	commands := make(map[int]string)
+	self := os.Getpid()
+	for _, p := range procs {
+		if p.PID == self || (p.User != name && p.User != uid) {
+			continue
+		}
+		ancestry, err := procpkg.ResolveAncestry(p.PID)
+		if err != nil {
+			continue // exited mid-scan
+		}
+		rg := restartGroup(source.Detect(ancestry), ancestry[len(ancestry)-1])
+		key := string(rg.Source) + "\x00" + rg.Name
+		g, ok := groups[key]
+		if !ok {
+			g = &model.UserGroup{Kind: rg.Kind, Source: rg.Source, Name: rg.Name}
+			groups[key] = g
+		}
+		g.Processes = append(g.Processes, model.UserProcess{
+			PID:           p.PID,
+			Command:       p.Command,
+			CPUPercent:    p.CPUPercent,
+			MemoryRSS:     p.MemoryRSS,
+			MemoryPercent: p.MemoryPercent,
+		})
+		g.CPUPercent += p.CPUPercent
+		g.MemoryRSS += p.MemoryRSS
+		g.MemoryPercent += p.MemoryPercent
+		byPID[p.PID], commands[p.PID] = g, p.Command
+
+		summary.Processes++
+		summary.CPUPercent += p.CPUPercent
+		summary.MemoryRSS += p.MemoryRSS
+		summary.MemoryPercent += p.MemoryPercent
+	}
+
+	// Listening ports only add detail; a failed listing leaves them out.
+	// They come sorted by port.
+	if ports, err := procpkg.ListPortListeners(model.PortQuery{Low: 1, High: 65535}); err == nil {
+		for _, port := range ports {
+			g, ok := byPID[port.PID]
+			if !ok {
+				continue
+			}
+			g.Listening = append(g.Listening, model.UserListener{
+				Protocol: listenerProtocol(port.Protocol),
+				Address:  port.Address,
+				Port:     port.Port,
+				PID:      port.PID,
+				Command:  commands[port.PID],
+			})
+		}
+	}
+
+	for _, g := range groups {
+		sortUserProcesses(g.Processes)
+		summary.Groups = append(summary.Groups, *g)
+	}
+	sort.SliceStable(summary.Groups, func(i, j int) bool {
+		a, b := summary.Groups[i], summary.Groups[j]
+		return groupLess(a.Kind, a.Name, b.Kind, b.Name)
+	})
+	return summary, nil
+}
+
+// lookupAccount resolves a --user value, a name or a UID, to the account's
+// name and UID. A UID without an account entry stands for itself, as it
+// does in a process listing.
+func lookupAccount(value string) (name, uid string, err error) {
+	if value == "" {
+		return "", "", fmt.Errorf("invalid user: empty name")
+	}
+	if u, err := user.Lookup(value); err == nil {
+		return u.Username, u.Uid, nil
+	}
+	if u, err := user.LookupId(value); err == nil {
+		return u.Username, u.Uid, nil
+	}
+	if n, err := strconv.Atoi(value); err == nil && n >= 0 {
+		return value, value, nil
+	}
+	return "", "", fmt.Errorf("user %q not found", value)
+}
+
+// sortUserProcesses orders processes by CPU, then memory, busiest first.
+func sortUserProcesses(procs []model.UserProcess) {
+	sort.SliceStable(procs, func(i, j int) bool {
+		a, b := procs[i], procs[j]
+		if a.CPUPercent != b.CPUPercent {
+			return a.CPUPercent > b.CPUPercent
+		}
+		if a.MemoryRSS != b.MemoryRSS {
+			return a.MemoryRSS > b.MemoryRSS
+		}
+		return a.PID < b.PID
+	})
+}
@@ -0,0 +1,90 @@
+package pipeline
+
+import (
+	"os"
+	"os/exec"
+	"os/user"
+	"runtime"
+	"strings"
+	"testing"
+)
+
+func TestLookupAccount(t *testing.T) {
+	t.Parallel()
+
+	if runtime.GOOS == "windows" {
+		t.Skip("no root account on windows")
+	}
+	for _, value := range []string{"root", "0"} {
+		name, uid, err := lookupAccount(value)
+		if err != nil || name != "root" || uid != "0" {
+			t.Errorf("lookupAccount(%q) = %q, %q, %v; want root, 0", value, name, uid, err)
+		}
+	}
+
+	// A UID without an account still names the processes running as it.
+	if _, err := user.LookupId("48213"); err != nil {
+		if name, uid, err := lookupAccount("48213"); err != nil || name != "48213" || uid != "48213" {
+			t.Errorf("lookupAccount(48213) = %q, %q, %v; want the bare UID", name, uid, err)
+		}
+	}
+
+	if _, _, err := lookupAccount("witr-no-such-user"); err == nil || !strings.Contains(err.Error(), "not found") {
+		t.Errorf("unknown user error = %v, want not found", err)
+	}
+	if _, _, err := lookupAccount(""); err == nil || !strings.Contains(err.Error(), "invalid") {
+		t.Errorf("empty user error = %v, want invalid", err)
+	}
+}
+
+func TestSummarizeUserFindsOwnChild(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("sleep is not available on windows")
+	}
+	me, err := user.Current()
+	if err != nil {
+		t.Skipf("cannot look up current user: %v", err)
+	}
+
+	c := exec.Command("sleep", "30")
+	if err := c.Start(); err != nil {
+		t.Skipf("cannot spawn child: %v", err)
+	}
+	defer func() {
+		_ = c.Process.Kill()
+		_ = c.Wait()
+	}()
+
+	summary, err := SummarizeUser(me.Uid)
+	if err != nil {
+		t.Fatalf("SummarizeUser(%s): %v", me.Uid, err)
+	}
+	if summary.User != me.Username || summary.UID != me.Uid {
+		t.Errorf("summary names %q (uid %q), want %q (uid %q)", summary.User, summary.UID, me.Username, me.Uid)
+	}
+
+	found, counted := false, 0
+	for _, g := range summary.Groups {
+		if g.Kind == "" || g.Name == "" {
+			t.Errorf("group without kind or name: %+v", g)
+		}
+		counted += len(g.Processes)
+		for i, p := range g.Processes {
+			if p.PID == os.Getpid() {
+				t.Error("summary includes witr itself")
+			}
+			if p.PID == c.Process.Pid {
+				found = true
+			}
+			if i > 0 && p.CPUPercent > g.Processes[i-1].CPUPercent {
+				t.Errorf("group %q is not ordered busiest first", g.Name)
+			}
+		}
+	}
+	if !found {
+		t.Errorf("summary does not include the spawned child %d", c.Process.Pid)
+	}
+	if counted != summary.Processes {
+		t.Errorf("groups hold %d processes, summary counts %d", counted, summary.Processes)
+	}
+}
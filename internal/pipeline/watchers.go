@@ -0,0 +1,22 @@
+package pipeline
+
+import (
+	procpkg "github.com/pranshuparmar/witr/internal/proc"
+	"github.com/pranshuparmar/witr/pkg/model"
+)
+
+// AnalyzeWatchers finds the processes watching path through inotify or
+// fanotify and names each one along with what started it.
+func AnalyzeWatchers(path string) (model.FileWatchers, error) {
+	watchers, err := procpkg.ScanWatchers(path)
+	if err != nil {
+		return watchers, err
+	}
+	origins := make(processOrigins)
+	for i := range watchers.Watchers {
+		w := &watchers.Watchers[i]
+		o := origins.lookup(w.PID)
+		w.Command, w.User, w.Source, w.StartedBy = o.command, o.user, o.source, o.startedBy
+	}
+	return watchers, nil
+}
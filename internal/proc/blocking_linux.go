@@ -0,0 +1,518 @@
+//go:build linux
+
+package proc
+
+import (
+	"fmt"
+	"net"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pranshuparmar/witr/pkg/model"
+	"golang.org/x/sys/unix"
+)
+
+// A process in uninterruptible sleep, or waiting on a network filesystem, is
+// sampled this many times, this far apart: a disk read passes through D
+// state in milliseconds and a FUSE read returns as soon as the daemon
+// answers, while a hung server keeps the process there for every sample.
+const (
+	blockedSamples        = 5
+	blockedSampleInterval = 100 * time.Millisecond
+)
+
+// ReadBlocking reports what p waits for while it sleeps: its wait channel,
+// the system call it is in with its arguments, the kernel stack when
+// readable, and the file, socket or lock that call operates on. It returns
+// nil unless p is in S or D state. resample re-reads a process in D state,
+// or waiting on a network filesystem, to see whether it stays there.
+// withFiles also lists the offsets of every open regular file.
+//
+// p's sockets, when already collected, are used to describe a socket wait.
+func ReadBlocking(p model.Process, withFiles, resample bool) *model.BlockingInfo {
+	state := readState(p.PID)
+	if state != "S" && state != "D" {
+		return nil
+	}
+
+	b := &model.BlockingInfo{State: state}
+	if data, err := os.ReadFile(fmt.Sprintf("/proc/%d/wchan", p.PID)); err == nil {
+		b.WaitChannel = parseWaitChannel(string(data))
+	}
+	if data, err := os.ReadFile(fmt.Sprintf("/proc/%d/stack", p.PID)); err == nil {
+		b.Stack = parseKernelStack(string(data))
+	}
+	var call string
+	if data, err := os.ReadFile(fmt.Sprintf("/proc/%d/syscall", p.PID)); err == nil {
+		call = string(data)
+		if nr, args, ok := parseSyscall(string(data)); ok {
+			b.Syscall = syscallName(nr)
+			for _, a := range args {
+				b.SyscallArgs = append(b.SyscallArgs, "0x"+strconv.FormatUint(a, 16))
+			}
+			describeBlockingCall(b, p, args)
+		}
+	}
+	detectNetworkFS(b, p.PID)
+
+	if resample && (state == "D" || b.FSType != "") {
+		b.Samples, b.UninterruptibleSamples, b.SameCallSamples = sampleBlocked(p.PID, state, call, blockedSamples, blockedSampleInterval)
+	}
+	if withFiles {
+		b.Files = readFilePositions(p.PID)
+	}
+	return b
+}
+
+// readState returns the scheduler state letter from /proc/<pid>/stat.
+func readState(pid int) string {
+	data, err := os.ReadFile(fmt.Sprintf("/proc/%d/stat", pid))
+	if err != nil {
+		return ""
+	}
+	stat := string(data)
+	closeParen := strings.LastIndex(stat, ")")
+	if closeParen == -1 {
+		return ""
+	}
+	return processState(strings.Fields(stat[closeParen+1:]))
+}
+
+// sampleBlocked re-reads pid up to n times, the first sample being the
+// caller's own reading of its state and /proc/<pid>/syscall line. It returns
+// how many samples were taken, how many in a row found it in D state, and
+// how many in a row found it asleep in the same call: the syscall line,
+// stack and instruction pointers included, unchanged. Sampling stops once
+// the process has left both.
+func sampleBlocked(pid int, state, call string, n int, interval time.Duration) (samples, inD, sameCall int) {
+	samples, sameCall = 1, 1
+	if state == "D" {
+		inD = 1
+	}
+	for samples < n && (inD == samples || sameCall == samples) {
+		time.Sleep(interval)
+		now := readState(pid)
+		var nowCall string
+		if data, err := os.ReadFile(fmt.Sprintf("/proc/%d/syscall", pid)); err == nil {
+			nowCall = string(data)
+		}
+		if inD == samples && now == "D" {
+			inD++
+		}
+		if sameCall == samples && (now == "S" || now == "D") && nowCall == call {
+			sameCall++
+		}
+		samples++
+	}
+	return samples, inD, sameCall
+}
+
+// parseSyscall decodes /proc/<pid>/syscall: the call number followed by six
+// arguments, then the stack and instruction pointers. A running task reads
+// "running" and one sleeping outside a system call "-1 <sp> <pc>"; neither
+// is reported.
+func parseSyscall(line string) (nr uint64, args []uint64, ok bool) {
+	fields := strings.Fields(line)
+	if len(fields) < 7 || fields[0] == "-1" {
+		return 0, nil, false
+	}
+	nr, err := strconv.ParseUint(fields[0], 10, 64)
+	if err != nil {
+		return 0, nil, false
+	}
+	args = make([]uint64, 6)
+	for i := range args {
+		v, err := strconv.ParseUint(strings.TrimPrefix(fields[i+1], "0x"), 16, 64)
+		if err != nil {
+			return 0, nil, false
+		}
+		args[i] = v
+	}
+	return nr, args, true
+}
+
+func syscallName(nr uint64) string {
+	if name, ok := syscallNames[nr]; ok {
+		return name
+	}
+	return fmt.Sprintf("syscall %d", nr)
+}
+
+// parseKernelStack turns /proc/<pid>/stack lines such as
+// "[<0>] do_select+0x5e0/0x7c0" or "[<0>] nfs_file_read+0x6b/0xa0 [nfs]"
+// into bare function names.
+func parseKernelStack(content string) []string {
+	var frames []string
+	for _, line := range strings.Split(content, "\n") {
+		fields := strings.Fields(line)
+		if len(fields) < 2 {
+			continue
+		}
+		fn := fields[1]
+		if i := strings.IndexByte(fn, '+'); i > 0 {
+			fn = fn[:i]
+		}
+		frames = append(frames, fn)
+	}
+	return frames
+}
+
+// fdCalls are the system calls whose first argument is the descriptor they
+// block on.
+var fdCalls = map[string]bool{
+	"read": true, "write": true, "readv": true, "writev": true,
+	"pread64": true, "pwrite64": true, "preadv": true, "pwritev": true,
+	"recvfrom": true, "recvmsg": true, "recvmmsg": true,
+	"sendto": true, "sendmsg": true, "sendmmsg": true,
+	"accept": true, "accept4": true, "connect": true,
+	"sendfile": true, "splice": true, "tee": true,
+	"ioctl": true, "fcntl": true, "flock": true, "close": true,
+	"fsync": true, "fdatasync": true, "syncfs": true,
+	"fstat": true, "getdents": true, "getdents64": true, "lseek": true,
+	"fallocate": true, "ftruncate": true,
+}
+
+// blockingCall classifies a system call by what it waits on. For a call on
+// a descriptor it returns that descriptor and whether the call waits for a
+// file lock; for anything else, a description of the wait (empty when the
+// call says nothing useful about it). fd is -1 when the call takes none.
+func blockingCall(name string, args []uint64) (fd int, lock bool, what string) {
+	if fdCalls[name] {
+		fd = int(int32(args[0]))
+		switch name {
+		case "flock":
+			lock = args[1]&unix.LOCK_NB == 0
+		case "fcntl":
+			lock = args[1] == unix.F_SETLKW || args[1] == unix.F_OFD_SETLKW
+		}
+		return fd, lock, ""
+	}
+
+	switch name {
+	case "poll", "ppoll":
+		what = fmt.Sprintf("I/O readiness on %d %s", args[1], plural(int(args[1]), "descriptor", "descriptors"))
+	case "select", "pselect6":
+		what = "I/O readiness (select)"
+	case "epoll_wait", "epoll_pwait", "epoll_pwait2":
+		what = fmt.Sprintf("I/O readiness on epoll fd %d", int32(args[0]))
+	case "futex":
+		what = "a futex (mutex or condition variable shared with another thread)"
+	case "wait4":
+		if pid := int32(args[0]); pid > 0 {
+			what = fmt.Sprintf("child PID %d to exit", pid)
+		} else {
+			what = "a child process to exit"
+		}
+	case "waitid":
+		if args[0] == unix.P_PID {
+			what = fmt.Sprintf("child PID %d to exit", int32(args[1]))
+		} else {
+			what = "a child process to exit"
+		}
+	case "nanosleep", "clock_nanosleep":
+		what = "a timer (sleeping)"
+	case "pause", "rt_sigsuspend", "rt_sigtimedwait":
+		what = "a signal"
+	case "io_getevents", "io_pgetevents", "io_uring_enter":
+		what = "asynchronous I/O completions"
+	case "semop", "semtimedop", "msgrcv", "mq_timedreceive", "mq_timedsend":
+		what = "an IPC semaphore or message queue"
+	}
+	return -1, false, what
+}
+
+func plural(n int, one, many string) string {
+	if n == 1 {
+		return one
+	}
+	return many
+}
+
+// describeBlockingCall fills in b.WaitingOn and b.FD from the blocking call
+// and its arguments.
+func describeBlockingCall(b *model.BlockingInfo, p model.Process, args []uint64) {
+	fd, lock, what := blockingCall(b.Syscall, args)
+	if fd < 0 {
+		b.WaitingOn = what
+		return
+	}
+
+	fdPath := fmt.Sprintf("/proc/%d/fd/%d", p.PID, fd)
+	target, err := os.Readlink(fdPath)
+	if err != nil {
+		return
+	}
+	b.FD = &model.FilePosition{FD: fd, Path: target}
+	if data, err := os.ReadFile(fmt.Sprintf("/proc/%d/fdinfo/%d", p.PID, fd)); err == nil {
+		b.FD.Pos, b.FD.Access = parseFDInfo(string(data))
+	}
+
+	switch {
+	case lock:
+		b.WaitingOn = "lock on " + target
+		if info, err := os.Stat(fdPath); err == nil {
+			if data, err := os.ReadFile("/proc/locks"); err == nil {
+				if holders := lockHolders(string(data), statKey(info), p.PID); len(holders) > 0 {
+					b.WaitingOn += fmt.Sprintf(" held by PID %d", holders[0])
+					if name := lockProcessName(holders[0], make(map[int]string)); name != "" {
+						b.WaitingOn += " (" + name + ")"
+					}
+				}
+			}
+		}
+	case strings.HasPrefix(target, "socket:["):
+		b.WaitingOn = describeSocketWait(strings.TrimSuffix(strings.TrimPrefix(target, "socket:["), "]"), p)
+	case strings.HasPrefix(target, "/"):
+		b.WaitingOn = "file " + target
+	default:
+		b.WaitingOn = target
+	}
+}
+
+// describeSocketWait names a socket by its endpoints when it is among the
+// process's collected TCP, UDP or unix sockets.
+func describeSocketWait(inode string, p model.Process) string {
+	for _, s := range p.Sockets {
+		if s.Inode != inode {
+			continue
+		}
+		local := net.JoinHostPort(s.Address, strconv.Itoa(s.Port))
+		if s.State == "LISTEN" {
+			return fmt.Sprintf("%s socket listening on %s", s.Protocol, local)
+		}
+		if s.RemoteAddress != "" {
+			return fmt.Sprintf("%s socket %s -> %s (%s)", s.Protocol, local,
+				net.JoinHostPort(s.RemoteAddress, strconv.Itoa(s.RemotePort)), s.State)
+		}
+		return fmt.Sprintf("%s socket %s", s.Protocol, local)
+	}
+	for _, s := range p.UnixSockets {
+		if s.Inode == inode {
+			return fmt.Sprintf("unix socket %s (%s)", s.Path, s.State)
+		}
+	}
+	return "socket:[" + inode + "]"
+}
+
+// parseFDInfo extracts the offset and access mode from an fdinfo file.
+func parseFDInfo(content string) (pos int64, access string) {
+	for _, line := range strings.Split(content, "\n") {
+		key, value, ok := strings.Cut(line, ":")
+		if !ok {
+			continue
+		}
+		value = strings.TrimSpace(value)
+		switch key {
+		case "pos":
+			pos, _ = strconv.ParseInt(value, 10, 64)
+		case "flags":
+			flags, err := strconv.ParseInt(value, 8, 64)
+			if err != nil {
+				continue
+			}
+			switch flags & 3 { // O_ACCMODE = 3; 0=RDONLY, 1=WRONLY, 2=RDWR
+			case 0:
+				access = "R"
+			case 1:
+				access = "W"
+			case 2:
+				access = "RW"
+			}
+		}
+	}
+	return pos, access
+}
+
+// lockHolders returns the PIDs holding a lock on inode according to
+// /proc/locks, excluding self. Lines for blocked waiters ("->") are skipped,
+// as are OFD locks, which are owned by an open file rather than a PID.
+func lockHolders(content, inode string, self int) []int {
+	if inode == "" {
+		return nil
+	}
+	var pids []int
+	for _, line := range strings.Split(content, "\n") {
+		fields := strings.Fields(line)
+		// <id>: <type> <kind> <access> <pid> <maj:min:inode> <start> <end>
+		if len(fields) < 8 || fields[1] == "->" {
+			continue
+		}
+		devInode := fields[5]
+		if devInode[strings.LastIndexByte(devInode, ':')+1:] != inode {
+			continue
+		}
+		pid, err := strconv.Atoi(fields[4])
+		if err != nil || pid <= 0 || pid == self {
+			continue
+		}
+		pids = append(pids, pid)
+	}
+	return pids
+}
+
+// readFilePositions lists pid's open regular files with their offsets,
+// ordered by descriptor.
+func readFilePositions(pid int) []model.FilePosition {
+	fdDir := fmt.Sprintf("/proc/%d/fd", pid)
+	entries, err := os.ReadDir(fdDir)
+	if err != nil {
+		return nil
+	}
+	var files []model.FilePosition
+	for _, e := range entries {
+		fd, err := strconv.Atoi(e.Name())
+		if err != nil {
+			continue
+		}
+		target, err := os.Readlink(fdDir + "/" + e.Name())
+		if err != nil || !strings.HasPrefix(target, "/") || !isInterestingFile(target) {
+			continue
+		}
+		f := model.FilePosition{FD: fd, Path: target}
+		if data, err := os.ReadFile(fmt.Sprintf("/proc/%d/fdinfo/%d", pid, fd)); err == nil {
+			f.Pos, f.Access = parseFDInfo(string(data))
+		}
+		files = append(files, f)
+	}
+	sort.Slice(files, func(i, j int) bool { return files[i].FD < files[j].FD })
+	return files
+}
+
+// mountEntry is a mount point and its filesystem type from mountinfo.
+type mountEntry struct {
+	ID     int
+	Point  string
+	FSType string
+}
+
+// parseMountInfo reads the mount points and filesystem types from a
+// /proc/<pid>/mountinfo file. Paths are relative to the process's root,
+// like the fd and cwd links they are matched against.
+func parseMountInfo(content string) []mountEntry {
+	var mounts []mountEntry
+	for _, line := range strings.Split(content, "\n") {
+		fields := strings.Fields(line)
+		// <id> <parent> <maj:min> <root> <mount point> <options> [optional...] - <fstype> <source> <super options>
+		sep := -1
+		for i := 6; i < len(fields); i++ {
+			if fields[i] == "-" {
+				sep = i
+				break
+			}
+		}
+		if sep == -1 || sep+1 >= len(fields) {
+			continue
+		}
+		id, _ := strconv.Atoi(fields[0])
+		mounts = append(mounts, mountEntry{ID: id, Point: unescapeMountPath(fields[4]), FSType: fields[sep+1]})
+	}
+	return mounts
+}
+
+// unescapeMountPath decodes the octal escapes (\040 for a space, etc.) the
+// kernel writes in mount paths.
+func unescapeMountPath(s string) string {
+	if !strings.Contains(s, `\`) {
+		return s
+	}
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		if s[i] == '\\' && i+3 < len(s) {
+			if v, err := strconv.ParseUint(s[i+1:i+4], 8, 8); err == nil {
+				b.WriteByte(byte(v))
+				i += 3
+				continue
+			}
+		}
+		b.WriteByte(s[i])
+	}
+	return b.String()
+}
+
+// mountFor returns the mount that contains path: the longest mount point
+// that is path itself or one of its parents. Later entries win ties, as
+// they are mounted on top.
+func mountFor(mounts []mountEntry, path string) (mountEntry, bool) {
+	var best mountEntry
+	found := false
+	for _, m := range mounts {
+		if path != m.Point && m.Point != "/" && !strings.HasPrefix(path, m.Point+"/") {
+			continue
+		}
+		if !found || len(m.Point) >= len(best.Point) {
+			best, found = m, true
+		}
+	}
+	return best, found
+}
+
+// networkFSFamily groups filesystem types a process can hang on because
+// another machine or a user-space daemon serves them. It returns "" for
+// local filesystems.
+func networkFSFamily(fsType string) string {
+	switch {
+	case strings.HasPrefix(fsType, "nfs"):
+		return "nfs"
+	case strings.HasPrefix(fsType, "fuse"):
+		return "fuse"
+	case fsType == "cifs" || fsType == "smb3" || fsType == "smbfs":
+		return "cifs"
+	case fsType == "ceph" || fsType == "9p" || fsType == "glusterfs" || fsType == "afs" || fsType == "lustre":
+		return fsType
+	}
+	return ""
+}
+
+// kernelFSHint infers a network filesystem from the kernel functions the
+// process sleeps in, for waits that don't go through a descriptor (a stat
+// or open on an NFS path, a page fault on a FUSE-backed mapping).
+func kernelFSHint(wchan string, stack []string) string {
+	for _, fn := range append([]string{wchan}, stack...) {
+		switch {
+		case strings.HasPrefix(fn, "nfs"), strings.HasPrefix(fn, "rpc_"), strings.HasPrefix(fn, "__rpc_"):
+			return "nfs"
+		case strings.HasPrefix(fn, "fuse_"), fn == "request_wait_answer":
+			return "fuse"
+		case strings.HasPrefix(fn, "cifs_"), strings.HasPrefix(fn, "smb2_"):
+			return "cifs"
+		}
+	}
+	return ""
+}
+
+// detectNetworkFS sets b.FSType and b.Mount when the process is blocked on
+// a network or FUSE filesystem: the blocking descriptor is a file on one,
+// or the kernel stack points into one, in which case the mount holding the
+// working directory is named if it is of that kind.
+func detectNetworkFS(b *model.BlockingInfo, pid int) {
+	hint := kernelFSHint(b.WaitChannel, b.Stack)
+	fdFile := b.FD != nil && strings.HasPrefix(b.FD.Path, "/")
+	if !fdFile && hint == "" {
+		return
+	}
+	data, err := os.ReadFile(fmt.Sprintf("/proc/%d/mountinfo", pid))
+	if err != nil {
+		b.FSType = hint
+		return
+	}
+	mounts := parseMountInfo(string(data))
+
+	if fdFile {
+		if m, ok := mountFor(mounts, b.FD.Path); ok && networkFSFamily(m.FSType) != "" {
+			b.FSType, b.Mount = m.FSType, m.Point
+			return
+		}
+	}
+	if hint == "" {
+		return
+	}
+	b.FSType = hint
+	if cwd, err := os.Readlink(fmt.Sprintf("/proc/%d/cwd", pid)); err == nil {
+		if m, ok := mountFor(mounts, cwd); ok && networkFSFamily(m.FSType) == hint {
+			b.FSType, b.Mount = m.FSType, m.Point
+		}
+	}
+}
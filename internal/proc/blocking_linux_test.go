@@ -0,0 +1,307 @@
+//go:build linux
+
+package proc
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"reflect"
+	"strconv"
+	"strings"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/pranshuparmar/witr/pkg/model"
+	"golang.org/x/sys/unix"
+)
+
+func TestParseSyscall(t *testing.T) {
+	t.Parallel()
+
+	nr, args, ok := parseSyscall("0 0x3 0x7ffd2c000000 0x1000 0x0 0x0 0x0 0x7ffd2bfffe88 0x7f1c9a2f1a5d\n")
+	if !ok || nr != 0 || !reflect.DeepEqual(args, []uint64{3, 0x7ffd2c000000, 0x1000, 0, 0, 0}) {
+		t.Errorf("parseSyscall = %d, %#x, %v", nr, args, ok)
+	}
+	for _, line := range []string{"running\n", "-1 0x7ffd2bfffe88 0x7f1c9a2f1a5d\n", "", "7 0x1 zz 0x0 0x0 0x0 0x0 0x0 0x0"} {
+		if _, _, ok := parseSyscall(line); ok {
+			t.Errorf("parseSyscall(%q) should not report a call", line)
+		}
+	}
+}
+
+func TestSyscallName(t *testing.T) {
+	t.Parallel()
+
+	if syscallNames == nil {
+		t.Skip("no syscall table for this architecture")
+	}
+	if got := syscallName(unix.SYS_FUTEX); got != "futex" {
+		t.Errorf("syscallName(SYS_FUTEX) = %q", got)
+	}
+	if got := syscallName(100000); got != "syscall 100000" {
+		t.Errorf("unknown number = %q", got)
+	}
+}
+
+func TestParseKernelStack(t *testing.T) {
+	t.Parallel()
+
+	// Frames from modules carry a "[module]" suffix after the symbol.
+	stack := "[<0>] rpc_wait_bit_killable+0x1e/0xa0 [sunrpc]\n[<0>] __rpc_execute+0x110/0x3a0\n[<0>] nfs_file_read+0x6b/0xa0 [nfs]\n"
+	want := []string{"rpc_wait_bit_killable", "__rpc_execute", "nfs_file_read"}
+	if got := parseKernelStack(stack); !reflect.DeepEqual(got, want) {
+		t.Errorf("parseKernelStack = %v, want %v", got, want)
+	}
+}
+
+func TestBlockingCall(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		args []uint64
+		fd   int
+		lock bool
+		what string
+	}{
+		{"read", []uint64{5, 0, 0, 0, 0, 0}, 5, false, ""},
+		{"flock", []uint64{3, unix.LOCK_EX, 0, 0, 0, 0}, 3, true, ""},
+		{"flock", []uint64{3, unix.LOCK_EX | unix.LOCK_NB, 0, 0, 0, 0}, 3, false, ""},
+		{"fcntl", []uint64{4, unix.F_SETLKW, 0, 0, 0, 0}, 4, true, ""},
+		{"fcntl", []uint64{4, unix.F_GETFL, 0, 0, 0, 0}, 4, false, ""},
+		{"epoll_pwait", []uint64{7, 0, 0, 0, 0, 0}, -1, false, "I/O readiness on epoll fd 7"},
+		{"ppoll", []uint64{0, 1, 0, 0, 0, 0}, -1, false, "I/O readiness on 1 descriptor"},
+		{"wait4", []uint64{0xffffffffffffffff, 0, 0, 0, 0, 0}, -1, false, "a child process to exit"},
+		{"wait4", []uint64{812, 0, 0, 0, 0, 0}, -1, false, "child PID 812 to exit"},
+		{"waitid", []uint64{unix.P_PID, 812, 0, 0, 0, 0}, -1, false, "child PID 812 to exit"},
+		{"clock_nanosleep", []uint64{0, 0, 0, 0, 0, 0}, -1, false, "a timer (sleeping)"},
+		{"openat", []uint64{0xffffff9c, 0, 0, 0, 0, 0}, -1, false, ""},
+	}
+	for _, tt := range tests {
+		fd, lock, what := blockingCall(tt.name, tt.args)
+		if fd != tt.fd || lock != tt.lock || what != tt.what {
+			t.Errorf("blockingCall(%s, %#x) = %d, %v, %q; want %d, %v, %q", tt.name, tt.args[:2], fd, lock, what, tt.fd, tt.lock, tt.what)
+		}
+	}
+}
+
+func TestParseFDInfo(t *testing.T) {
+	t.Parallel()
+
+	pos, access := parseFDInfo("pos:\t4096\nflags:\t0102002\nmnt_id:\t29\nino:\t1234\n")
+	if pos != 4096 || access != "RW" {
+		t.Errorf("parseFDInfo = %d, %q; want 4096, RW", pos, access)
+	}
+	if pos, access := parseFDInfo("pos:\t0\nflags:\t02000001\n"); pos != 0 || access != "W" {
+		t.Errorf("parseFDInfo = %d, %q; want 0, W", pos, access)
+	}
+}
+
+func TestLockHolders(t *testing.T) {
+	t.Parallel()
+
+	locks := `1: FLOCK  ADVISORY  WRITE 812 08:01:5678 0 EOF
+1: -> FLOCK  ADVISORY  WRITE 900 08:01:5678 0 EOF
+2: POSIX  ADVISORY  READ 900 08:01:5678 0 EOF
+3: OFDLCK ADVISORY  WRITE -1 08:01:5678 0 EOF
+4: POSIX  ADVISORY  WRITE 700 08:01:9999 0 EOF
+`
+	if got := lockHolders(locks, "5678", 900); !reflect.DeepEqual(got, []int{812}) {
+		t.Errorf("lockHolders = %v, want [812]", got)
+	}
+	if got := lockHolders(locks, "", 900); got != nil {
+		t.Errorf("unknown inode should match nothing, got %v", got)
+	}
+}
+
+func TestParseMountInfoAndMountFor(t *testing.T) {
+	t.Parallel()
+
+	mountinfo := `22 1 8:1 / / rw,relatime shared:1 - ext4 /dev/sda1 rw
+40 22 0:45 / /mnt/share rw,relatime shared:20 - nfs4 server:/export rw,vers=4.2
+41 22 0:46 / /mnt/my\040drive rw,nosuid - fuse.sshfs user@host: rw
+42 40 0:47 / /mnt/share/local rw - tmpfs tmpfs rw
+`
+	mounts := parseMountInfo(mountinfo)
+	if len(mounts) != 4 || mounts[2].Point != "/mnt/my drive" || mounts[2].FSType != "fuse.sshfs" {
+		t.Fatalf("parseMountInfo = %+v", mounts)
+	}
+
+	tests := map[string]string{
+		"/mnt/share/data.bin":    "nfs4",
+		"/mnt/share":             "nfs4",
+		"/mnt/sharex/file":       "ext4",
+		"/mnt/share/local/f":     "tmpfs",
+		"/mnt/my drive/notes.md": "fuse.sshfs",
+		"/etc/hosts":             "ext4",
+	}
+	for path, want := range tests {
+		if m, ok := mountFor(mounts, path); !ok || m.FSType != want {
+			t.Errorf("mountFor(%q) = %+v, %v; want %s", path, m, ok, want)
+		}
+	}
+}
+
+func TestNetworkFSDetection(t *testing.T) {
+	t.Parallel()
+
+	for fsType, want := range map[string]string{"nfs": "nfs", "nfs4": "nfs", "fuse.sshfs": "fuse", "fuseblk": "fuse", "cifs": "cifs", "ceph": "ceph", "ext4": "", "tmpfs": ""} {
+		if got := networkFSFamily(fsType); got != want {
+			t.Errorf("networkFSFamily(%q) = %q, want %q", fsType, got, want)
+		}
+	}
+	if got := kernelFSHint("rpc_wait_bit_killable", nil); got != "nfs" {
+		t.Errorf("NFS wait channel hint = %q", got)
+	}
+	if got := kernelFSHint("", []string{"request_wait_answer", "fuse_simple_request"}); got != "fuse" {
+		t.Errorf("FUSE stack hint = %q", got)
+	}
+	if got := kernelFSHint("do_epoll_wait", []string{"do_epoll_wait", "__x64_sys_epoll_wait"}); got != "" {
+		t.Errorf("epoll wait should not hint a filesystem, got %q", got)
+	}
+}
+
+func TestDescribeSocketWait(t *testing.T) {
+	t.Parallel()
+
+	p := model.Process{
+		Sockets: []model.Socket{
+			{Inode: "10", Protocol: "TCP", Address: "0.0.0.0", Port: 80, State: "LISTEN"},
+			{Inode: "11", Protocol: "TCP6", Address: "::1", Port: 41022, State: "ESTABLISHED", RemoteAddress: "::1", RemotePort: 5432},
+		},
+		UnixSockets: []model.UnixSocket{{Inode: "12", Path: "/run/app.sock", State: "LISTEN"}},
+	}
+	for inode, want := range map[string]string{
+		"10": "TCP socket listening on 0.0.0.0:80",
+		"11": "TCP6 socket [::1]:41022 -> [::1]:5432 (ESTABLISHED)",
+		"12": "unix socket /run/app.sock (LISTEN)",
+		"13": "socket:[13]",
+	} {
+		if got := describeSocketWait(inode, p); got != want {
+			t.Errorf("describeSocketWait(%s) = %q, want %q", inode, got, want)
+		}
+	}
+}
+
+// waitForSyscall polls pid until it sleeps in the named system call.
+func waitForSyscall(t *testing.T, pid int, name string) *model.BlockingInfo {
+	t.Helper()
+	var b *model.BlockingInfo
+	for deadline := time.Now().Add(3 * time.Second); time.Now().Before(deadline); time.Sleep(20 * time.Millisecond) {
+		b = ReadBlocking(model.Process{PID: pid}, true, false)
+		if b != nil && b.Syscall == name {
+			return b
+		}
+	}
+	if b == nil || b.Syscall == "" {
+		t.Skipf("cannot read the system call of PID %d", pid)
+	}
+	t.Fatalf("PID %d never blocked in %s, last seen %+v", pid, name, b)
+	return nil
+}
+
+func startBlocked(t *testing.T, cmd *exec.Cmd) int {
+	t.Helper()
+	if err := cmd.Start(); err != nil {
+		t.Skipf("cannot start %s: %v", cmd.Path, err)
+	}
+	t.Cleanup(func() {
+		_ = cmd.Process.Kill()
+		_ = cmd.Wait()
+	})
+	return cmd.Process.Pid
+}
+
+func TestReadBlockingPipeRead(t *testing.T) {
+	if syscallNames == nil {
+		t.Skip("no syscall table for this architecture")
+	}
+	cmd := exec.Command("cat")
+	stdin, err := cmd.StdinPipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer stdin.Close()
+	pid := startBlocked(t, cmd)
+
+	b := waitForSyscall(t, pid, "read")
+	if b.State != "S" || b.FD == nil || b.FD.FD != 0 || !strings.HasPrefix(b.FD.Path, "pipe:[") {
+		t.Errorf("cat blocked reading stdin = %+v (fd %+v)", b, b.FD)
+	}
+	if b.WaitingOn != b.FD.Path || len(b.SyscallArgs) != 6 || b.SyscallArgs[0] != "0x0" {
+		t.Errorf("WaitingOn = %q, args = %v", b.WaitingOn, b.SyscallArgs)
+	}
+}
+
+func TestSampleBlocked(t *testing.T) {
+	if syscallNames == nil {
+		t.Skip("no syscall table for this architecture")
+	}
+	cmd := exec.Command("cat")
+	stdin, err := cmd.StdinPipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer stdin.Close()
+	pid := startBlocked(t, cmd)
+	waitForSyscall(t, pid, "read")
+
+	call, err := os.ReadFile(fmt.Sprintf("/proc/%d/syscall", pid))
+	if err != nil {
+		t.Skipf("cannot read the system call of PID %d: %v", pid, err)
+	}
+	samples, inD, sameCall := sampleBlocked(pid, "S", string(call), 3, 10*time.Millisecond)
+	if samples != 3 || inD != 0 || sameCall != 3 {
+		t.Errorf("cat blocked on a pipe: samples=%d inD=%d sameCall=%d, want 3, 0, 3", samples, inD, sameCall)
+	}
+
+	// A call that no longer matches stops the sampling.
+	samples, _, sameCall = sampleBlocked(pid, "S", "0 0x0 0x0 0x0 0x0 0x0 0x0 0x0 0x0\n", 3, 10*time.Millisecond)
+	if samples != 2 || sameCall != 1 {
+		t.Errorf("changed call: samples=%d sameCall=%d, want 2, 1", samples, sameCall)
+	}
+}
+
+func TestReadBlockingFlockHolder(t *testing.T) {
+	if syscallNames == nil {
+		t.Skip("no syscall table for this architecture")
+	}
+	flockBin, err := exec.LookPath("flock")
+	if err != nil {
+		t.Skip("flock not found")
+	}
+	path := filepath.Join(t.TempDir(), "witr.lock")
+	f, err := os.Create(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	if err := syscall.Flock(int(f.Fd()), syscall.LOCK_EX); err != nil {
+		t.Skipf("cannot take flock: %v", err)
+	}
+
+	pid := startBlocked(t, exec.Command(flockBin, path, "true"))
+	b := waitForSyscall(t, pid, "flock")
+	want := "lock on " + path + " held by PID " + strconv.Itoa(os.Getpid())
+	if !strings.HasPrefix(b.WaitingOn, want) {
+		t.Errorf("WaitingOn = %q, want prefix %q", b.WaitingOn, want)
+	}
+	found := false
+	for _, file := range b.Files {
+		if file.Path == path && file.FD == b.FD.FD {
+			found = true
+		}
+	}
+	if !found {
+		t.Errorf("open files %+v should include the lock file", b.Files)
+	}
+}
+
+func TestReadBlockingSkipsRunningProcesses(t *testing.T) {
+	if b := ReadBlocking(model.Process{PID: os.Getpid() + 1_000_000}, false, false); b != nil {
+		t.Errorf("missing process = %+v, want nil", b)
+	}
+}
@@ -0,0 +1,11 @@
+//go:build !linux
+
+package proc
+
+import "github.com/pranshuparmar/witr/pkg/model"
+
+// ReadBlocking returns nil on non-Linux platforms, which have no
+// /proc/<pid>/syscall or wchan to show what a process waits for.
+func ReadBlocking(p model.Process, withFiles, resample bool) *model.BlockingInfo {
+	return nil
+}
@@ -60,8 +60,12 @@ func ReadCapabilities(pid int) []string {
 	if err != nil {
 		return nil
 	}
+	return parseCapabilities(string(data))
+}
 
-	for _, line := range strings.Split(string(data), "\n") {
+// parseCapabilities decodes the CapEff line of /proc/<pid>/status.
+func parseCapabilities(status string) []string {
+	for _, line := range strings.Split(status, "\n") {
 		if strings.HasPrefix(line, "CapEff:\t") {
 			hex := strings.TrimSpace(strings.TrimPrefix(line, "CapEff:"))
 			return decodeCapabilities(hex)
@@ -0,0 +1,373 @@
+//go:build linux
+
+package proc
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/pranshuparmar/witr/pkg/model"
+)
+
+// cgroupRoot is where the cgroup v2 unified hierarchy is mounted; a variable
+// so tests can point it at a fixture tree.
+var cgroupRoot = "/sys/fs/cgroup"
+
+// ReadCgroupStats returns the cgroup v2 limits, usage and pressure for pid's
+// cgroup, or nil when the process isn't in a unified hierarchy (cgroup v1
+// hosts) or the cgroup directory isn't visible from witr's mount namespace.
+func ReadCgroupStats(pid int) *model.CgroupStats {
+	data, err := os.ReadFile(fmt.Sprintf("/proc/%d/cgroup", pid))
+	if err != nil {
+		return nil
+	}
+	path := unifiedCgroupPath(string(data))
+	if path == "" {
+		return nil
+	}
+	return readCgroupStats(path)
+}
+
+// unifiedCgroupPath returns the cgroup v2 path from /proc/<pid>/cgroup
+// content, i.e. the "0::<path>" line.
+func unifiedCgroupPath(content string) string {
+	for _, line := range strings.Split(content, "\n") {
+		if path, ok := strings.CutPrefix(line, "0::"); ok {
+			return strings.TrimSpace(path)
+		}
+	}
+	return ""
+}
+
+func readCgroupStats(path string) *model.CgroupStats {
+	dir := filepath.Join(cgroupRoot, path)
+	if _, err := os.Stat(dir); err != nil {
+		return nil
+	}
+
+	stats := &model.CgroupStats{Path: path}
+	stats.MemoryCurrent, _ = readCgroupUint(dir, "memory.current")
+	stats.PidsCurrent, _ = readCgroupUint(dir, "pids.current")
+
+	events := readCgroupKeyed(dir, "memory.events")
+	stats.OOMEvents = events["oom"]
+	stats.OOMKills = events["oom_kill"]
+
+	cpu := readCgroupKeyed(dir, "cpu.stat")
+	stats.NrPeriods = cpu["nr_periods"]
+	stats.NrThrottled = cpu["nr_throttled"]
+	stats.ThrottledUsec = cpu["throttled_usec"]
+
+	// Limits are inherited: a service's own cgroup may say "max" while its
+	// slice caps it. Walk up to the root and keep the tightest value. An
+	// ancestor's memory limit counts its whole subtree, so its own usage is
+	// recorded alongside.
+	for d := dir; ; d = filepath.Dir(d) {
+		if v, ok := readCgroupUint(d, "memory.max"); ok && (stats.MemoryMax == 0 || v < stats.MemoryMax) {
+			stats.MemoryMax = v
+			if d != dir {
+				stats.MemoryMaxPath = "/" + strings.TrimLeft(strings.TrimPrefix(d, cgroupRoot), "/")
+				stats.MemoryMaxCurrent, _ = readCgroupUint(d, "memory.current")
+			}
+		}
+		if v, ok := readCgroupUint(d, "pids.max"); ok && (stats.PidsMax == 0 || v < stats.PidsMax) {
+			stats.PidsMax = v
+		}
+		if quota, period, ok := readCPUMax(d); ok {
+			if stats.CPUQuota == 0 || float64(quota)/float64(period) < float64(stats.CPUQuota)/float64(stats.CPUPeriod) {
+				stats.CPUQuota, stats.CPUPeriod = quota, period
+			}
+		}
+		if d == cgroupRoot || len(d) <= len(cgroupRoot) {
+			break
+		}
+	}
+
+	stats.CPUPressure = readPressure(dir, "cpu.pressure")
+	stats.MemoryPressure = readPressure(dir, "memory.pressure")
+	stats.IOPressure = readPressure(dir, "io.pressure")
+
+	// The root cgroup, and hybrid hosts where the unified tree has no
+	// controllers enabled, expose none of these files.
+	if *stats == (model.CgroupStats{Path: path}) {
+		return nil
+	}
+	return stats
+}
+
+// readCgroupUint reads a single-value cgroup file. "max" (no limit) and a
+// missing file both report ok=false.
+func readCgroupUint(dir, name string) (uint64, bool) {
+	data, err := os.ReadFile(filepath.Join(dir, name))
+	if err != nil {
+		return 0, false
+	}
+	v, err := strconv.ParseUint(strings.TrimSpace(string(data)), 10, 64)
+	if err != nil {
+		return 0, false
+	}
+	return v, true
+}
+
+// readCgroupKeyed reads a flat keyed file such as memory.events or cpu.stat
+// ("key value" per line).
+func readCgroupKeyed(dir, name string) map[string]uint64 {
+	data, err := os.ReadFile(filepath.Join(dir, name))
+	if err != nil {
+		return nil
+	}
+	return parseCgroupKeyed(string(data))
+}
+
+func parseCgroupKeyed(content string) map[string]uint64 {
+	values := make(map[string]uint64)
+	for _, line := range strings.Split(content, "\n") {
+		fields := strings.Fields(line)
+		if len(fields) != 2 {
+			continue
+		}
+		if v, err := strconv.ParseUint(fields[1], 10, 64); err == nil {
+			values[fields[0]] = v
+		}
+	}
+	return values
+}
+
+// readCPUMax reads cpu.max ("<quota> <period>"); ok is false when the quota
+// is "max" or the controller isn't enabled.
+func readCPUMax(dir string) (quota, period uint64, ok bool) {
+	data, err := os.ReadFile(filepath.Join(dir, "cpu.max"))
+	if err != nil {
+		return 0, 0, false
+	}
+	return parseCPUMax(string(data))
+}
+
+func parseCPUMax(content string) (quota, period uint64, ok bool) {
+	fields := strings.Fields(content)
+	if len(fields) != 2 {
+		return 0, 0, false
+	}
+	quota, err := strconv.ParseUint(fields[0], 10, 64)
+	if err != nil {
+		return 0, 0, false
+	}
+	period, err = strconv.ParseUint(fields[1], 10, 64)
+	if err != nil || period == 0 {
+		return 0, 0, false
+	}
+	return quota, period, true
+}
+
+func readPressure(dir, name string) *model.Pressure {
+	data, err := os.ReadFile(filepath.Join(dir, name))
+	if err != nil {
+		return nil
+	}
+	return parsePressure(string(data))
+}
+
+// parsePressure parses a PSI file:
+//
+//	some avg10=0.12 avg60=0.05 avg300=0.01 total=123456
+//	full avg10=0.00 avg60=0.00 avg300=0.00 total=0
+func parsePressure(content string) *model.Pressure {
+	var p model.Pressure
+	found := false
+	for _, line := range strings.Split(content, "\n") {
+		fields := strings.Fields(line)
+		if len(fields) < 4 {
+			continue
+		}
+		var avg10, avg60, avg300 *float64
+		switch fields[0] {
+		case "some":
+			avg10, avg60, avg300 = &p.Some10, &p.Some60, &p.Some300
+		case "full":
+			avg10, avg60, avg300 = &p.Full10, &p.Full60, &p.Full300
+		default:
+			continue
+		}
+		for _, f := range fields[1:] {
+			key, value, ok := strings.Cut(f, "=")
+			if !ok {
+				continue
+			}
+			v, err := strconv.ParseFloat(value, 64)
+			if err != nil {
+				continue
+			}
+			switch key {
+			case "avg10":
+				*avg10 = v
+			case "avg60":
+				*avg60 = v
+			case "avg300":
+				*avg300 = v
+			}
+		}
+		found = true
+	}
+	if !found {
+		return nil
+	}
+	return &p
+}
+
+// errFound stops the cgroup walk in FindUnitCgroup at the first match.
+var errFound = errors.New("found")
+
+// FindUnitCgroup returns the cgroup v2 path, as /proc/<pid>/cgroup shows
+// it, of the systemd unit named unit, or "" when no cgroup has that name.
+// systemd names every unit's cgroup after the unit, whichever slice or user
+// manager it sits under, so this finds units without asking systemd.
+func FindUnitCgroup(unit string) string {
+	var found string
+	root := cgroupHierarchy()
+	_ = filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil || !d.IsDir() {
+			return nil
+		}
+		if d.Name() == unit {
+			found = "/" + strings.TrimPrefix(strings.TrimPrefix(path, root), "/")
+			return errFound
+		}
+		return nil
+	})
+	return found
+}
+
+// CgroupProcesses lists the processes in the cgroup at path (as
+// /proc/<pid>/cgroup shows it) and in every cgroup beneath it, ordered by
+// PID, with their parent PID, command name and command line.
+func CgroupProcesses(path string) []model.Process {
+	var procs []model.Process
+	_ = filepath.WalkDir(cgroupDir(path), func(dir string, d fs.DirEntry, err error) error {
+		if err == nil && d.IsDir() {
+			procs = append(procs, readCgroupProcs(dir)...)
+		}
+		return nil
+	})
+	sort.Slice(procs, func(i, j int) bool { return procs[i].PID < procs[j].PID })
+	return procs
+}
+
+// readCgroupProcs reads the processes directly in the cgroup directory dir,
+// skipping any that exit before their stat file is read.
+func readCgroupProcs(dir string) []model.Process {
+	data, err := os.ReadFile(filepath.Join(dir, "cgroup.procs"))
+	if err != nil {
+		return nil
+	}
+	var procs []model.Process
+	for _, line := range strings.Fields(string(data)) {
+		pid, err := strconv.Atoi(line)
+		if err != nil {
+			continue
+		}
+		stat, err := os.ReadFile("/proc/" + line + "/stat")
+		if err != nil {
+			continue
+		}
+		p, err := parseStatSnapshot(pid, stat)
+		if err != nil {
+			continue
+		}
+		p.Cmdline = GetCmdline(pid)
+		procs = append(procs, p)
+	}
+	return procs
+}
+
+// ReadCgroupTree returns the cgroup at path and the cgroups beneath it that
+// hold processes, each with the processes directly in it. path is a cgroup
+// path as /proc/<pid>/cgroup shows it ("/system.slice/cron.service"); a
+// path under the cgroup mount and one without the leading slash are
+// accepted too.
+func ReadCgroupTree(path string) (model.CgroupNode, error) {
+	path = filepath.Clean("/" + strings.TrimPrefix(strings.TrimPrefix(path, cgroupHierarchy()), cgroupRoot))
+	dir := cgroupDir(path)
+	if fi, err := os.Stat(dir); err != nil || !fi.IsDir() {
+		return model.CgroupNode{Path: path}, fmt.Errorf("cgroup %q not found", path)
+	}
+	return readCgroupNode(dir, path), nil
+}
+
+// readCgroupNode reads the cgroup in dir, dropping child subtrees without
+// any process.
+func readCgroupNode(dir, path string) model.CgroupNode {
+	node := model.CgroupNode{Path: path, Kind: cgroupKind(path)}
+	for _, p := range readCgroupProcs(dir) {
+		node.Processes = append(node.Processes, model.CgroupProcess{
+			PID:     p.PID,
+			Command: p.Command,
+			Cmdline: p.Cmdline,
+			User:    readUser(p.PID),
+		})
+	}
+	sort.Slice(node.Processes, func(i, j int) bool { return node.Processes[i].PID < node.Processes[j].PID })
+	node.Total = len(node.Processes)
+
+	entries, _ := os.ReadDir(dir)
+	for _, e := range entries {
+		if !e.IsDir() {
+			continue
+		}
+		child := readCgroupNode(filepath.Join(dir, e.Name()), filepath.Join(path, e.Name()))
+		if child.Total > 0 {
+			node.Children = append(node.Children, child)
+			node.Total += child.Total
+		}
+	}
+	return node
+}
+
+// containerCgroupPrefixes start the scope names container runtimes give
+// their containers under systemd's cgroup driver.
+var containerCgroupPrefixes = []string{"docker-", "libpod-", "cri-containerd-", "crio-", "lxc.payload."}
+
+// cgroupKind classifies a cgroup by its name: a container, a systemd slice,
+// scope or service, or "" for anything else.
+func cgroupKind(path string) string {
+	name := filepath.Base(path)
+	for _, prefix := range containerCgroupPrefixes {
+		if strings.HasPrefix(name, prefix) {
+			return "container"
+		}
+	}
+	// The cgroupfs driver puts containers under a directory named after
+	// the runtime.
+	if parent := filepath.Base(filepath.Dir(path)); parent == "docker" || parent == "libpod_parent" {
+		return "container"
+	}
+	for _, kind := range []string{"slice", "scope", "service"} {
+		if strings.HasSuffix(name, "."+kind) {
+			return kind
+		}
+	}
+	return ""
+}
+
+// cgroupHierarchy returns where the hierarchy systemd tracks units in is
+// mounted: cgroupRoot on a unified host, its "unified" or "systemd"
+// subdirectory on a hybrid or legacy one, where cgroupRoot itself only
+// holds the per-controller mounts.
+func cgroupHierarchy() string {
+	for _, root := range []string{cgroupRoot, filepath.Join(cgroupRoot, "unified"), filepath.Join(cgroupRoot, "systemd")} {
+		if _, err := os.Stat(filepath.Join(root, "cgroup.procs")); err == nil {
+			return root
+		}
+	}
+	return cgroupRoot
+}
+
+// cgroupDir returns the directory of the cgroup at path.
+func cgroupDir(path string) string {
+	return filepath.Join(cgroupHierarchy(), path)
+}
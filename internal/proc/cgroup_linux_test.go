@@ -0,0 +1,233 @@
+//go:build linux
+
+package proc
+
+import (
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func writeCgroupFile(t *testing.T, dir, name, content string) {
+	t.Helper()
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestUnifiedCgroupPath(t *testing.T) {
+	t.Parallel()
+
+	hybrid := "4:memory:/system.slice/nginx.service\n0::/system.slice/nginx.service\n"
+	if got := unifiedCgroupPath(hybrid); got != "/system.slice/nginx.service" {
+		t.Errorf("unifiedCgroupPath(hybrid) = %q", got)
+	}
+	if got := unifiedCgroupPath("4:memory:/foo\n1:name=systemd:/foo\n"); got != "" {
+		t.Errorf("expected no unified path on a v1-only host, got %q", got)
+	}
+}
+
+func TestParsePressure(t *testing.T) {
+	t.Parallel()
+
+	p := parsePressure("some avg10=1.50 avg60=0.75 avg300=0.10 total=123\nfull avg10=0.20 avg60=0.00 avg300=0.00 total=4\n")
+	if p == nil {
+		t.Fatal("expected pressure")
+	}
+	if p.Some10 != 1.5 || p.Some60 != 0.75 || p.Some300 != 0.1 || p.Full10 != 0.2 {
+		t.Errorf("unexpected pressure: %+v", *p)
+	}
+	if parsePressure("") != nil {
+		t.Error("expected nil for empty PSI file")
+	}
+}
+
+func TestParseCPUMax(t *testing.T) {
+	t.Parallel()
+
+	if q, p, ok := parseCPUMax("150000 100000\n"); !ok || q != 150000 || p != 100000 {
+		t.Errorf("parseCPUMax = (%d, %d, %v)", q, p, ok)
+	}
+	if _, _, ok := parseCPUMax("max 100000\n"); ok {
+		t.Error("expected ok=false for an unlimited quota")
+	}
+}
+
+func TestReadCgroupStatsInheritsTightestLimit(t *testing.T) {
+	root := t.TempDir()
+	orig := cgroupRoot
+	cgroupRoot = root
+	t.Cleanup(func() { cgroupRoot = orig })
+
+	slice := filepath.Join(root, "system.slice")
+	svc := filepath.Join(slice, "app.service")
+
+	// The slice caps memory and CPU; the service itself says "max".
+	writeCgroupFile(t, slice, "memory.max", "1073741824\n")
+	writeCgroupFile(t, slice, "memory.current", "1050000000\n")
+	writeCgroupFile(t, slice, "cpu.max", "50000 100000\n")
+	writeCgroupFile(t, svc, "memory.max", "max\n")
+	writeCgroupFile(t, svc, "cpu.max", "max 100000\n")
+	writeCgroupFile(t, svc, "pids.max", "100\n")
+	writeCgroupFile(t, svc, "memory.current", "1000000000\n")
+	writeCgroupFile(t, svc, "pids.current", "12\n")
+	writeCgroupFile(t, svc, "memory.events", "low 0\nhigh 0\nmax 7\noom 3\noom_kill 2\n")
+	writeCgroupFile(t, svc, "cpu.stat", "usage_usec 100\nnr_periods 200\nnr_throttled 50\nthrottled_usec 3200000\n")
+	writeCgroupFile(t, svc, "memory.pressure", "some avg10=4.00 avg60=2.00 avg300=1.00 total=1\nfull avg10=1.00 avg60=0.50 avg300=0.10 total=1\n")
+
+	stats := readCgroupStats("/system.slice/app.service")
+	if stats == nil {
+		t.Fatal("expected stats")
+	}
+	if stats.MemoryMax != 1073741824 || stats.MemoryCurrent != 1000000000 {
+		t.Errorf("memory = %d of %d", stats.MemoryCurrent, stats.MemoryMax)
+	}
+	if stats.MemoryMaxPath != "/system.slice" || stats.MemoryMaxCurrent != 1050000000 {
+		t.Errorf("memory limit from %q using %d, want /system.slice using 1050000000", stats.MemoryMaxPath, stats.MemoryMaxCurrent)
+	}
+	if stats.CPUQuota != 50000 || stats.CPUPeriod != 100000 {
+		t.Errorf("cpu.max = %d/%d, want slice's 50000/100000", stats.CPUQuota, stats.CPUPeriod)
+	}
+	if stats.PidsMax != 100 || stats.PidsCurrent != 12 {
+		t.Errorf("pids = %d of %d", stats.PidsCurrent, stats.PidsMax)
+	}
+	if stats.OOMEvents != 3 || stats.OOMKills != 2 {
+		t.Errorf("oom = %d events, %d kills", stats.OOMEvents, stats.OOMKills)
+	}
+	if stats.NrPeriods != 200 || stats.NrThrottled != 50 || stats.ThrottledUsec != 3200000 {
+		t.Errorf("unexpected cpu.stat: %+v", stats)
+	}
+	if stats.MemoryPressure == nil || stats.MemoryPressure.Some10 != 4 {
+		t.Errorf("memory pressure = %+v", stats.MemoryPressure)
+	}
+	if stats.CPUPressure != nil {
+		t.Errorf("expected no CPU pressure without cpu.pressure, got %+v", stats.CPUPressure)
+	}
+}
+
+func TestReadCgroupStatsEmptyCgroup(t *testing.T) {
+	root := t.TempDir()
+	orig := cgroupRoot
+	cgroupRoot = root
+	t.Cleanup(func() { cgroupRoot = orig })
+
+	if stats := readCgroupStats("/"); stats != nil {
+		t.Errorf("expected nil for a cgroup exposing no files, got %+v", stats)
+	}
+	if stats := readCgroupStats("/missing.scope"); stats != nil {
+		t.Errorf("expected nil for a missing cgroup, got %+v", stats)
+	}
+}
+
+func TestFindUnitCgroupAndProcesses(t *testing.T) {
+	root := t.TempDir()
+	orig := cgroupRoot
+	cgroupRoot = root
+	t.Cleanup(func() { cgroupRoot = orig })
+
+	self := strconv.Itoa(os.Getpid())
+	svc := filepath.Join(root, "user.slice", "user-1000.slice", "user@1000.service", "app.slice", "sync.service")
+	writeCgroupFile(t, svc, "cgroup.procs", "")
+	// A delegated sub-cgroup holds the processes; a vanished PID is skipped.
+	writeCgroupFile(t, filepath.Join(svc, "workers"), "cgroup.procs", self+"\n999999999\n")
+	writeCgroupFile(t, filepath.Join(root, "system.slice", "other.service"), "cgroup.procs", "1\n")
+
+	path := FindUnitCgroup("sync.service")
+	if want := "/user.slice/user-1000.slice/user@1000.service/app.slice/sync.service"; path != want {
+		t.Fatalf("FindUnitCgroup = %q, want %q", path, want)
+	}
+	if got := FindUnitCgroup("missing.service"); got != "" {
+		t.Errorf("FindUnitCgroup(missing) = %q, want empty", got)
+	}
+
+	procs := CgroupProcesses(path)
+	if len(procs) != 1 || procs[0].PID != os.Getpid() || procs[0].PPID != os.Getppid() || procs[0].Command == "" {
+		t.Fatalf("CgroupProcesses = %+v, want only this test process", procs)
+	}
+}
+
+func TestCgroupDirFindsHybridHierarchy(t *testing.T) {
+	root := t.TempDir()
+	orig := cgroupRoot
+	cgroupRoot = root
+	t.Cleanup(func() { cgroupRoot = orig })
+
+	// Only the per-controller mounts and the named systemd hierarchy.
+	writeCgroupFile(t, filepath.Join(root, "cpu"), "cgroup.procs", "1\n")
+	writeCgroupFile(t, filepath.Join(root, "systemd"), "cgroup.procs", "1\n")
+	writeCgroupFile(t, filepath.Join(root, "systemd", "system.slice", "cron.service"), "cgroup.procs", "")
+	if got, want := cgroupDir("/system.slice/cron.service"), filepath.Join(root, "systemd", "system.slice", "cron.service"); got != want {
+		t.Errorf("cgroupDir = %q, want %q", got, want)
+	}
+	if got := FindUnitCgroup("cron.service"); got != "/system.slice/cron.service" {
+		t.Errorf("FindUnitCgroup = %q, want /system.slice/cron.service", got)
+	}
+}
+
+func TestReadCgroupTree(t *testing.T) {
+	root := t.TempDir()
+	orig := cgroupRoot
+	cgroupRoot = root
+	t.Cleanup(func() { cgroupRoot = orig })
+
+	self := strconv.Itoa(os.Getpid())
+	writeCgroupFile(t, root, "cgroup.procs", "")
+	writeCgroupFile(t, filepath.Join(root, "system.slice"), "cgroup.procs", "")
+	writeCgroupFile(t, filepath.Join(root, "system.slice", "cron.service"), "cgroup.procs", self+"\n")
+	writeCgroupFile(t, filepath.Join(root, "system.slice", "idle.service"), "cgroup.procs", "")
+	writeCgroupFile(t, filepath.Join(root, "init.scope"), "cgroup.procs", "999999999\n")
+
+	for _, arg := range []string{"/", "", root, root + "/"} {
+		tree, err := ReadCgroupTree(arg)
+		if err != nil {
+			t.Fatalf("ReadCgroupTree(%q): %v", arg, err)
+		}
+		if tree.Path != "/" || tree.Total != 1 {
+			t.Fatalf("ReadCgroupTree(%q) = path %q, total %d; want /, 1", arg, tree.Path, tree.Total)
+		}
+	}
+
+	tree, _ := ReadCgroupTree("system.slice")
+	if tree.Path != "/system.slice" || tree.Kind != "slice" || len(tree.Processes) != 0 {
+		t.Fatalf("slice node = %+v", tree)
+	}
+	if len(tree.Children) != 1 {
+		t.Fatalf("children = %+v, want only cron.service", tree.Children)
+	}
+	svc := tree.Children[0]
+	if svc.Path != "/system.slice/cron.service" || svc.Kind != "service" || svc.Total != 1 {
+		t.Fatalf("service node = %+v", svc)
+	}
+	if len(svc.Processes) != 1 || svc.Processes[0].PID != os.Getpid() || svc.Processes[0].Command == "" {
+		t.Fatalf("service processes = %+v, want only this test process", svc.Processes)
+	}
+
+	if _, err := ReadCgroupTree("/system.slice/missing.service"); err == nil || !strings.Contains(err.Error(), "not found") {
+		t.Errorf("missing cgroup error = %v, want not found", err)
+	}
+}
+
+func TestCgroupKind(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]string{
+		"/":                           "",
+		"/system.slice":               "slice",
+		"/system.slice/nginx.service": "service",
+		"/user.slice/user-1000.slice/session-3.scope": "scope",
+		"/system.slice/docker-4f1c2e.scope":           "container",
+		"/machine.slice/libpod-9ab.scope":             "container",
+		"/docker/4f1c2e9d":                            "container",
+		"/kubepods/burstable/pod12":                   "",
+	}
+	for path, want := range tests {
+		if got := cgroupKind(path); got != want {
+			t.Errorf("cgroupKind(%q) = %q, want %q", path, got, want)
+		}
+	}
+}
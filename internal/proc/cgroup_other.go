@@ -0,0 +1,31 @@
+//go:build !linux
+
+package proc
+
+import (
+	"fmt"
+	"runtime"
+
+	"github.com/pranshuparmar/witr/pkg/model"
+)
+
+// ReadCgroupStats returns nil on non-Linux platforms, which have no cgroups.
+func ReadCgroupStats(pid int) *model.CgroupStats {
+	return nil
+}
+
+// FindUnitCgroup returns "" on non-Linux platforms, which have no systemd
+// units.
+func FindUnitCgroup(unit string) string {
+	return ""
+}
+
+// CgroupProcesses returns nil on non-Linux platforms, which have no cgroups.
+func CgroupProcesses(path string) []model.Process {
+	return nil
+}
+
+// ReadCgroupTree is only supported on Linux, which has cgroups.
+func ReadCgroupTree(path string) (model.CgroupNode, error) {
+	return model.CgroupNode{Path: path}, fmt.Errorf("cgroup lookup is not supported on %s", runtime.GOOS)
+}
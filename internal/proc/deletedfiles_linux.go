@@ -0,0 +1,201 @@
+//go:build linux
+
+package proc
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/pranshuparmar/witr/pkg/model"
+	"golang.org/x/sys/unix"
+)
+
+// fileID identifies a file across processes by device and inode.
+type fileID struct{ dev, ino uint64 }
+
+// openDeletedFile is a deleted file one process holds.
+type openDeletedFile struct {
+	pid  int
+	id   fileID
+	file model.DeletedFile
+}
+
+// ScanDeletedFiles finds every process holding deleted regular files open
+// and totals the space they pin, per process and per filesystem. Holders
+// carry PIDs and files only; callers fill in the process details.
+func ScanDeletedFiles() (model.DeletedFilesAudit, error) {
+	audit := model.DeletedFilesAudit{Processes: []model.DeletedFileHolder{}, Filesystems: []model.DeletedFilesystem{}}
+	entries, err := os.ReadDir("/proc")
+	if err != nil {
+		return audit, fmt.Errorf("read /proc: %w", err)
+	}
+	self := os.Getpid()
+	var open []openDeletedFile
+	for _, entry := range entries {
+		pid, err := strconv.Atoi(entry.Name())
+		if err != nil || pid == self {
+			continue
+		}
+		files, readable := processDeletedFiles(pid)
+		audit.Scanned++
+		if !readable {
+			audit.Unreadable++
+		}
+		open = append(open, files...)
+	}
+	audit.Processes, audit.Filesystems, audit.TotalBytes = tallyDeletedFiles(open)
+	return audit, nil
+}
+
+// processDeletedFiles returns the deleted regular files pid holds, one
+// entry per file under its lowest descriptor. readable is false when its
+// descriptors could not be listed.
+func processDeletedFiles(pid int) (files []openDeletedFile, readable bool) {
+	base := "/proc/" + strconv.Itoa(pid)
+	fds, err := os.ReadDir(base + "/fd")
+	if err != nil {
+		return nil, !errors.Is(err, os.ErrPermission)
+	}
+	index := make(map[fileID]int)
+	var mounts []mountEntry
+	mountsRead := false
+	for _, entry := range fds {
+		fd, err := strconv.Atoi(entry.Name())
+		if err != nil {
+			continue
+		}
+		link, err := os.Readlink(base + "/fd/" + entry.Name())
+		if err != nil {
+			continue
+		}
+		path, deleted := strings.CutSuffix(link, " (deleted)")
+		// memfd files live on an internal mount no df reports.
+		if !deleted || !strings.HasPrefix(path, "/") || strings.HasPrefix(path, "/memfd:") {
+			continue
+		}
+		// Stat through the fd link reaches the open file itself. A link count
+		// of zero tells a deleted file from one whose name really ends in
+		// " (deleted)".
+		var st unix.Stat_t
+		if err := unix.Stat(base+"/fd/"+entry.Name(), &st); err != nil ||
+			st.Mode&unix.S_IFMT != unix.S_IFREG || st.Nlink != 0 {
+			continue
+		}
+		id := fileID{uint64(st.Dev), st.Ino}
+		if i, ok := index[id]; ok {
+			files[i].file.FD = min(files[i].file.FD, fd)
+			continue
+		}
+
+		f := model.DeletedFile{FD: fd, Path: path, Size: st.Size, Allocated: uint64(st.Blocks) * 512}
+		if !mountsRead {
+			if data, err := os.ReadFile(base + "/mountinfo"); err == nil {
+				mounts = parseMountInfo(string(data))
+			}
+			mountsRead = true
+		}
+		if m, ok := fdMount(mounts, base+"/fdinfo/"+entry.Name(), path); ok {
+			f.Mount, f.FSType = m.Point, m.FSType
+		}
+		index[id] = len(files)
+		files = append(files, openDeletedFile{pid: pid, id: id, file: f})
+	}
+	return files, true
+}
+
+// fdMount returns the mount a descriptor's file is on: the one named by
+// mnt_id in its fdinfo, or else the longest mount point containing path.
+func fdMount(mounts []mountEntry, fdinfo, path string) (mountEntry, bool) {
+	if data, err := os.ReadFile(fdinfo); err == nil {
+		for _, line := range strings.Split(string(data), "\n") {
+			if v, ok := strings.CutPrefix(line, "mnt_id:"); ok {
+				id, _ := strconv.Atoi(strings.TrimSpace(v))
+				for _, m := range mounts {
+					if m.ID == id {
+						return m, true
+					}
+				}
+			}
+		}
+	}
+	return mountFor(mounts, path)
+}
+
+// tallyDeletedFiles groups deleted files by process and by filesystem,
+// largest first, noting the files several processes share. A shared file
+// counts toward each holder's total but only once toward its filesystem's
+// and the overall total.
+func tallyDeletedFiles(open []openDeletedFile) ([]model.DeletedFileHolder, []model.DeletedFilesystem, uint64) {
+	holders := make(map[fileID][]int)
+	for _, o := range open {
+		holders[o.id] = append(holders[o.id], o.pid)
+	}
+
+	byPID := make(map[int]*model.DeletedFileHolder)
+	byDev := make(map[uint64]*model.DeletedFilesystem)
+	counted := make(map[fileID]bool)
+	var total uint64
+	for _, o := range open {
+		id, f := o.id, o.file
+		for _, pid := range holders[id] {
+			if pid != o.pid {
+				f.SharedWith = append(f.SharedWith, pid)
+			}
+		}
+		sort.Ints(f.SharedWith)
+
+		h, ok := byPID[o.pid]
+		if !ok {
+			h = &model.DeletedFileHolder{PID: o.pid}
+			byPID[o.pid] = h
+		}
+		h.Files = append(h.Files, f)
+		h.Bytes += f.Allocated
+
+		if counted[id] {
+			continue
+		}
+		counted[id] = true
+		total += f.Allocated
+		fs, ok := byDev[id.dev]
+		if !ok {
+			fs = &model.DeletedFilesystem{Mount: f.Mount, FSType: f.FSType}
+			byDev[id.dev] = fs
+		}
+		fs.Bytes += f.Allocated
+		fs.Files++
+	}
+
+	processes := make([]model.DeletedFileHolder, 0, len(byPID))
+	for _, h := range byPID {
+		sort.Slice(h.Files, func(i, j int) bool {
+			if h.Files[i].Allocated != h.Files[j].Allocated {
+				return h.Files[i].Allocated > h.Files[j].Allocated
+			}
+			return h.Files[i].FD < h.Files[j].FD
+		})
+		processes = append(processes, *h)
+	}
+	sort.Slice(processes, func(i, j int) bool {
+		if processes[i].Bytes != processes[j].Bytes {
+			return processes[i].Bytes > processes[j].Bytes
+		}
+		return processes[i].PID < processes[j].PID
+	})
+
+	filesystems := make([]model.DeletedFilesystem, 0, len(byDev))
+	for _, fs := range byDev {
+		filesystems = append(filesystems, *fs)
+	}
+	sort.Slice(filesystems, func(i, j int) bool {
+		if filesystems[i].Bytes != filesystems[j].Bytes {
+			return filesystems[i].Bytes > filesystems[j].Bytes
+		}
+		return filesystems[i].Mount < filesystems[j].Mount
+	})
+	return processes, filesystems, total
+}
@@ -0,0 +1,88 @@
+//go:build linux
+
+package proc
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strconv"
+	"testing"
+
+	"github.com/pranshuparmar/witr/pkg/model"
+)
+
+func TestTallyDeletedFiles(t *testing.T) {
+	log := fileID{dev: 2049, ino: 11}
+	cache := fileID{dev: 2049, ino: 12}
+	shm := fileID{dev: 26, ino: 5}
+	open := []openDeletedFile{
+		{pid: 900, id: log, file: model.DeletedFile{FD: 7, Path: "/var/log/app.log", Allocated: 3000, Mount: "/var", FSType: "ext4"}},
+		{pid: 900, id: shm, file: model.DeletedFile{FD: 9, Path: "/dev/shm/buf", Allocated: 500, Mount: "/dev/shm", FSType: "tmpfs"}},
+		{pid: 812, id: cache, file: model.DeletedFile{FD: 4, Path: "/var/cache/x", Allocated: 1000, Mount: "/var", FSType: "ext4"}},
+		{pid: 812, id: log, file: model.DeletedFile{FD: 3, Path: "/var/log/app.log", Allocated: 3000, Mount: "/var", FSType: "ext4"}},
+	}
+	processes, filesystems, total := tallyDeletedFiles(open)
+
+	if total != 4500 {
+		t.Errorf("total = %d, want 4500 (shared file counted once)", total)
+	}
+	wantFS := []model.DeletedFilesystem{
+		{Mount: "/var", FSType: "ext4", Bytes: 4000, Files: 2},
+		{Mount: "/dev/shm", FSType: "tmpfs", Bytes: 500, Files: 1},
+	}
+	if !reflect.DeepEqual(filesystems, wantFS) {
+		t.Errorf("filesystems = %+v, want %+v", filesystems, wantFS)
+	}
+	if len(processes) != 2 || processes[0].PID != 812 || processes[0].Bytes != 4000 || processes[1].Bytes != 3500 {
+		t.Fatalf("processes not sorted by size: %+v", processes)
+	}
+	if f := processes[0].Files[0]; f.Path != "/var/log/app.log" || !reflect.DeepEqual(f.SharedWith, []int{900}) {
+		t.Errorf("largest file of pid 812 = %+v, want app.log shared with 900", f)
+	}
+	if f := processes[0].Files[1]; f.SharedWith != nil {
+		t.Errorf("unshared file has SharedWith: %+v", f)
+	}
+}
+
+func TestProcessDeletedFilesFindsOwnFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "gone.log")
+	f, err := os.Create(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	if _, err := f.Write(make([]byte, 64<<10)); err != nil {
+		t.Fatal(err)
+	}
+	if err := f.Sync(); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Remove(path); err != nil {
+		t.Fatal(err)
+	}
+	// A second descriptor for the same file is folded into the first.
+	dup, err := os.Open("/proc/self/fd/" + strconv.Itoa(int(f.Fd())))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer dup.Close()
+
+	files, readable := processDeletedFiles(os.Getpid())
+	if !readable {
+		t.Fatal("own descriptors unreadable")
+	}
+	var found []openDeletedFile
+	for _, o := range files {
+		if o.file.Path == path {
+			found = append(found, o)
+		}
+	}
+	if len(found) != 1 {
+		t.Fatalf("want one entry for %s, got %+v", path, found)
+	}
+	got := found[0].file
+	if got.FD != int(min(f.Fd(), dup.Fd())) || got.Size != 64<<10 || got.Allocated == 0 || got.Mount == "" {
+		t.Errorf("unexpected entry: %+v", got)
+	}
+}
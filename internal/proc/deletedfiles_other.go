@@ -0,0 +1,16 @@
+//go:build !linux
+
+package proc
+
+import (
+	"fmt"
+	"runtime"
+
+	"github.com/pranshuparmar/witr/pkg/model"
+)
+
+// ScanDeletedFiles is only supported on Linux, where deleted files show up
+// in the /proc/<pid>/fd links.
+func ScanDeletedFiles() (model.DeletedFilesAudit, error) {
+	return model.DeletedFilesAudit{}, fmt.Errorf("deleted file discovery is not supported on %s", runtime.GOOS)
+}
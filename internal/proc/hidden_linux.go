@@ -0,0 +1,188 @@
+//go:build linux
+
+package proc
+
+import (
+	"os"
+	"runtime"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/pranshuparmar/witr/pkg/model"
+	"golang.org/x/sys/unix"
+)
+
+// defaultPIDMax is the kernel's pid_max on 64-bit systems, used when
+// /proc/sys/kernel/pid_max is unreadable.
+const defaultPIDMax = 4194304
+
+// ScanHiddenPIDs finds processes that exist but are missing from the /proc
+// directory listing. Every PID up to kernel.pid_max that the listing leaves
+// out is probed with kill(pid, 0) and by opening /proc/<pid> directly, and
+// the children named in each listed process's /proc/<pid>/task/*/children
+// are checked against the listing. Candidates are confirmed against a second
+// listing, so processes that started or exited during the scan don't count.
+func ScanHiddenPIDs() (model.HiddenAudit, error) {
+	listed, err := listProcPIDs()
+	if err != nil {
+		return model.HiddenAudit{}, err
+	}
+	pidMax, err := readIntFile("/proc/sys/kernel/pid_max")
+	if err != nil || pidMax <= 0 {
+		pidMax = defaultPIDMax
+	}
+
+	found := probeUnlisted(listed, pidMax)
+	for pid := range listed {
+		for _, child := range taskChildren(pid) {
+			if !listed[child] {
+				found[child] = append(found[child], "task")
+			}
+		}
+	}
+
+	relisted, err := listProcPIDs()
+	if err != nil {
+		return model.HiddenAudit{}, err
+	}
+	for pid := range found {
+		if relisted[pid] || len(probePID(pid)) == 0 {
+			delete(found, pid)
+		}
+	}
+
+	return model.HiddenAudit{
+		Hidden: resolveHidden(found, listed, readTgid),
+		Listed: len(listed),
+		PIDMax: pidMax,
+	}, nil
+}
+
+// listProcPIDs returns the PIDs in the /proc directory listing.
+func listProcPIDs() (map[int]bool, error) {
+	entries, err := os.ReadDir("/proc")
+	if err != nil {
+		return nil, err
+	}
+	pids := make(map[int]bool, len(entries))
+	for _, entry := range entries {
+		if pid, err := strconv.Atoi(entry.Name()); err == nil {
+			pids[pid] = true
+		}
+	}
+	return pids, nil
+}
+
+// probeUnlisted probes every PID below pidMax that isn't in listed, spread
+// over one worker per CPU.
+func probeUnlisted(listed map[int]bool, pidMax int) map[int][]string {
+	var (
+		mu    sync.Mutex
+		wg    sync.WaitGroup
+		found = make(map[int][]string)
+	)
+	workers := runtime.NumCPU()
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func(first int) {
+			defer wg.Done()
+			for pid := first; pid < pidMax; pid += workers {
+				if listed[pid] {
+					continue
+				}
+				if methods := probePID(pid); len(methods) > 0 {
+					mu.Lock()
+					found[pid] = methods
+					mu.Unlock()
+				}
+			}
+		}(w + 1)
+	}
+	wg.Wait()
+	return found
+}
+
+// probePID reports which direct probes find pid. kill(pid, 0) failing with
+// EPERM still proves the process exists. Both probes also find threads,
+// which resolveHidden maps to their thread group.
+func probePID(pid int) []string {
+	var methods []string
+	if err := unix.Kill(pid, 0); err == nil || err == unix.EPERM {
+		methods = append(methods, "kill")
+	}
+	if _, err := os.Lstat("/proc/" + strconv.Itoa(pid)); err == nil {
+		methods = append(methods, "stat")
+	}
+	return methods
+}
+
+// taskChildren returns the child processes of every thread of pid, from
+// /proc/<pid>/task/<tid>/children (present when the kernel has
+// CONFIG_PROC_CHILDREN).
+func taskChildren(pid int) []int {
+	taskDir := "/proc/" + strconv.Itoa(pid) + "/task"
+	tasks, err := os.ReadDir(taskDir)
+	if err != nil {
+		return nil
+	}
+	var children []int
+	for _, task := range tasks {
+		data, err := os.ReadFile(taskDir + "/" + task.Name() + "/children")
+		if err != nil {
+			continue
+		}
+		for _, field := range strings.Fields(string(data)) {
+			if child, err := strconv.Atoi(field); err == nil {
+				children = append(children, child)
+			}
+		}
+	}
+	return children
+}
+
+// readTgid returns the thread group (process) a PID or thread ID belongs to.
+func readTgid(pid int) (int, bool) {
+	data, err := os.ReadFile("/proc/" + strconv.Itoa(pid) + "/status")
+	if err != nil {
+		return 0, false
+	}
+	tgid, err := strconv.Atoi(parseStatusFields(string(data))["Tgid"])
+	return tgid, err == nil
+}
+
+// resolveHidden turns probe hits into hidden processes. A hit on a thread ID
+// stands for its thread group: it is dropped when the group leader is
+// listed, and otherwise credited to the leader. Hits whose thread group
+// can't be read are kept as they are.
+func resolveHidden(found map[int][]string, listed map[int]bool, tgidOf func(int) (int, bool)) []model.HiddenProcess {
+	byPID := make(map[int]map[string]bool)
+	for pid, methods := range found {
+		if tgid, ok := tgidOf(pid); ok {
+			pid = tgid
+		}
+		if listed[pid] {
+			continue
+		}
+		if byPID[pid] == nil {
+			byPID[pid] = make(map[string]bool)
+		}
+		for _, m := range methods {
+			byPID[pid][m] = true
+		}
+	}
+
+	hidden := []model.HiddenProcess{}
+	for pid, methods := range byPID {
+		h := model.HiddenProcess{PID: pid}
+		for _, m := range []string{"kill", "stat", "task"} {
+			if methods[m] {
+				h.FoundBy = append(h.FoundBy, m)
+			}
+		}
+		hidden = append(hidden, h)
+	}
+	sort.Slice(hidden, func(i, j int) bool { return hidden[i].PID < hidden[j].PID })
+	return hidden
+}
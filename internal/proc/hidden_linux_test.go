@@ -0,0 +1,62 @@
+//go:build linux
+
+package proc
+
+import (
+	"os"
+	"reflect"
+	"testing"
+
+	"github.com/pranshuparmar/witr/pkg/model"
+)
+
+func TestResolveHidden(t *testing.T) {
+	listed := map[int]bool{1: true, 100: true}
+	tgids := map[int]int{
+		101: 100, // thread of a listed process
+		501: 500, // thread of a hidden process
+		500: 500,
+		700: 700,
+	}
+	tgidOf := func(pid int) (int, bool) {
+		tgid, ok := tgids[pid]
+		return tgid, ok
+	}
+	found := map[int][]string{
+		101: {"kill", "stat"},
+		500: {"stat"},
+		501: {"kill"},
+		700: {"task", "kill"},
+		900: {"kill"}, // status unreadable
+	}
+
+	got := resolveHidden(found, listed, tgidOf)
+	want := []model.HiddenProcess{
+		{PID: 500, FoundBy: []string{"kill", "stat"}},
+		{PID: 700, FoundBy: []string{"kill", "task"}},
+		{PID: 900, FoundBy: []string{"kill"}},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("resolveHidden =\n%+v\nwant\n%+v", got, want)
+	}
+}
+
+func TestScanHiddenPIDsFindsNothingOnCleanSystem(t *testing.T) {
+	if testing.Short() {
+		t.Skip("probes every PID up to pid_max; skipped under -short")
+	}
+	audit, err := ScanHiddenPIDs()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if audit.Listed == 0 || audit.PIDMax <= os.Getpid() {
+		t.Errorf("implausible scan: %+v", audit)
+	}
+	// The test binary's own threads are reachable by kill(2) and
+	// /proc/<tid> but must map back to this listed process.
+	for _, h := range audit.Hidden {
+		if h.PID == os.Getpid() {
+			t.Errorf("own process reported hidden: %+v", h)
+		}
+	}
+}
@@ -0,0 +1,16 @@
+//go:build !linux
+
+package proc
+
+import (
+	"fmt"
+	"runtime"
+
+	"github.com/pranshuparmar/witr/pkg/model"
+)
+
+// ScanHiddenPIDs is only supported on Linux, where /proc can be listed and
+// probed by number independently.
+func ScanHiddenPIDs() (model.HiddenAudit, error) {
+	return model.HiddenAudit{}, fmt.Errorf("hidden-process audit is not supported on %s", runtime.GOOS)
+}
@@ -0,0 +1,229 @@
+//go:build linux
+
+package proc
+
+import (
+	"encoding/binary"
+	"fmt"
+	"net"
+	"strconv"
+
+	"github.com/pranshuparmar/witr/pkg/model"
+	"golang.org/x/sys/unix"
+)
+
+// inet_diag request attribute, filter opcodes and reply attribute
+// (linux/inet_diag.h); not exported by x/sys/unix.
+const (
+	inetDiagReqBytecode = 1
+
+	inetDiagBCJmp = 1
+	inetDiagBCSEq = 11 // Linux 4.16+
+	inetDiagBCDEq = 12
+
+	inetDiagCgroupID = 21 // Linux 5.7+
+)
+
+// State bitmasks for inet_diag_req_v2.idiag_states (1 << TCP_*). UDP sockets
+// reuse the TCP numbers: CLOSE for a bound socket, ESTABLISHED once connected.
+const (
+	tcpStatesAll    = 0xfff
+	tcpStatesListen = 1 << 10
+	udpStatesBound  = 1<<1 | 1<<7
+)
+
+// inetDiagQuery selects sockets in the kernel rather than in witr: only the
+// matching entries cross into user space.
+type inetDiagQuery struct {
+	Protocol uint8  // unix.IPPROTO_TCP or unix.IPPROTO_UDP
+	States   uint32 // bitmask of 1 << state
+	Port     int    // local port to match; 0 matches every socket
+	OrRemote bool   // also match sockets whose remote port is Port
+}
+
+// inetDiagSocket is a sock_diag reply. Remote is the remote address even for
+// unconnected sockets, where Socket.RemoteAddress stays empty.
+type inetDiagSocket struct {
+	model.Socket
+	TCPState uint8
+	Remote   string
+}
+
+// dumpInetDiag returns the IPv4 and IPv6 sockets matching q.
+func dumpInetDiag(q inetDiagQuery) ([]inetDiagSocket, error) {
+	var sockets []inetDiagSocket
+	for _, family := range []uint8{unix.AF_INET, unix.AF_INET6} {
+		proto := inetProtocolName(q.Protocol, family)
+		err := sockDiagDump(inetDiagRequest(family, q), func(payload []byte) {
+			if s, ok := parseInetDiagMsg(payload); ok {
+				s.Protocol = proto
+				sockets = append(sockets, s)
+			}
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+	return sockets, nil
+}
+
+// inetProtocolName matches the protocol labels of the /proc/net parser, which
+// names sockets after the file they were read from.
+func inetProtocolName(protocol, family uint8) string {
+	name := "TCP"
+	if protocol == unix.IPPROTO_UDP {
+		name = "UDP"
+	}
+	if family == unix.AF_INET6 {
+		name += "6"
+	}
+	return name
+}
+
+// inetDiagRequest encodes a struct inet_diag_req_v2, followed by a filter
+// program when q selects a port.
+func inetDiagRequest(family uint8, q inetDiagQuery) []byte {
+	req := make([]byte, 56)
+	req[0] = family
+	req[1] = q.Protocol
+	binary.NativeEndian.PutUint32(req[4:8], q.States)
+
+	bc := portBytecode(q.Port, q.OrRemote)
+	if bc == nil {
+		return req
+	}
+	attr := make([]byte, 4)
+	binary.NativeEndian.PutUint16(attr[0:2], uint16(4+len(bc)))
+	binary.NativeEndian.PutUint16(attr[2:4], inetDiagReqBytecode)
+	return append(append(req, attr...), bc...)
+}
+
+// portBytecode builds an inet_diag filter accepting sockets whose local port
+// (or, with orRemote, remote port) is port. Every op jumps forward by "yes"
+// or "no" bytes depending on its outcome; landing exactly on the end accepts
+// the socket and landing past it rejects. A port comparison is two ops, the
+// second carrying the port in its "no" field, and the OR joins them with an
+// unconditional jump as iproute2's ss does, which is the only layout the
+// kernel's filter audit accepts.
+func portBytecode(port int, orRemote bool) []byte {
+	if port <= 0 {
+		return nil
+	}
+	op := func(code, yes uint8, no uint16) []byte {
+		b := []byte{code, yes, 0, 0}
+		binary.NativeEndian.PutUint16(b[2:4], no)
+		return b
+	}
+	local := append(op(inetDiagBCSEq, 8, 12), op(0, 0, uint16(port))...)
+	if !orRemote {
+		return local
+	}
+	remote := append(op(inetDiagBCDEq, 8, 12), op(0, 0, uint16(port))...)
+	bc := append(local, op(inetDiagBCJmp, 4, uint16(len(remote)+4))...)
+	return append(bc, remote...)
+}
+
+// parseInetDiagMsg decodes a struct inet_diag_msg and its attributes.
+func parseInetDiagMsg(b []byte) (inetDiagSocket, bool) {
+	const msgLen = 72
+	if len(b) < msgLen {
+		return inetDiagSocket{}, false
+	}
+	var src, dst net.IP
+	switch b[0] {
+	case unix.AF_INET:
+		src, dst = net.IP(b[8:12]), net.IP(b[24:28])
+	case unix.AF_INET6:
+		src, dst = net.IP(b[8:24]), net.IP(b[24:40])
+	default:
+		return inetDiagSocket{}, false
+	}
+
+	s := inetDiagSocket{TCPState: b[1], Remote: dst.String()}
+	s.Address = src.String()
+	s.Port = int(binary.BigEndian.Uint16(b[4:6]))
+	if remotePort := int(binary.BigEndian.Uint16(b[6:8])); remotePort != 0 {
+		s.RemoteAddress = s.Remote
+		s.RemotePort = remotePort
+	}
+	s.State = stateMap[fmt.Sprintf("%02X", s.TCPState)]
+	if s.State == "" {
+		s.State = "UNKNOWN"
+	}
+	s.RecvQ = binary.NativeEndian.Uint32(b[56:60])
+	s.SendQ = binary.NativeEndian.Uint32(b[60:64])
+	s.UID = strconv.FormatUint(uint64(binary.NativeEndian.Uint32(b[64:68])), 10)
+	s.Inode = strconv.FormatUint(uint64(binary.NativeEndian.Uint32(b[68:72])), 10)
+	if id, ok := netlinkAttrs(b[msgLen:])[inetDiagCgroupID]; ok && len(id) >= 8 {
+		s.CgroupID = binary.NativeEndian.Uint64(id)
+	}
+	return s, true
+}
+
+// readSocketsDiag is readSockets over sock_diag.
+func readSocketsDiag() (map[string]model.Socket, error) {
+	sockets := make(map[string]model.Socket)
+	for _, protocol := range []uint8{unix.IPPROTO_TCP, unix.IPPROTO_UDP} {
+		entries, err := dumpInetDiag(inetDiagQuery{Protocol: protocol, States: tcpStatesAll})
+		if err != nil {
+			return nil, err
+		}
+		for _, e := range entries {
+			sockets[e.Inode] = e.Socket
+		}
+	}
+	return sockets, nil
+}
+
+// PortSocketInodes returns the inodes of the TCP and UDP sockets q selects
+// (see selectPortSockets), read over sock_diag. A single port is filtered
+// in the kernel; a range, protocol and address are filtered here. An error
+// means sock_diag is unavailable (or too old for port filters) and the
+// caller should use PortSocketInodesText instead.
+func PortSocketInodes(q model.PortQuery, listenersOnly bool) (map[string]bool, error) {
+	tcpStates := uint32(tcpStatesAll)
+	if listenersOnly {
+		tcpStates = tcpStatesListen
+	}
+	port := q.Low
+	if q.IsRange() {
+		port = 0
+	}
+	var sockets []model.Socket
+	for _, query := range []inetDiagQuery{
+		{Protocol: unix.IPPROTO_TCP, States: tcpStates, Port: port, OrRemote: !listenersOnly},
+		{Protocol: unix.IPPROTO_UDP, States: udpStatesBound, Port: port, OrRemote: !listenersOnly},
+	} {
+		if !q.MatchesProtocol(inetProtocolName(query.Protocol, unix.AF_INET)) {
+			continue
+		}
+		entries, err := dumpInetDiag(query)
+		if err != nil {
+			return nil, err
+		}
+		for _, e := range entries {
+			sockets = append(sockets, e.Socket)
+		}
+	}
+	return selectPortSockets(sockets, q, listenersOnly), nil
+}
+
+// socketStatesDiag is socketStatesText over sock_diag.
+func socketStatesDiag(port int) ([]model.SocketInfo, error) {
+	entries, err := dumpInetDiag(inetDiagQuery{Protocol: unix.IPPROTO_TCP, States: tcpStatesAll, Port: port})
+	if err != nil {
+		return nil, err
+	}
+	states := make([]model.SocketInfo, 0, len(entries))
+	for _, e := range entries {
+		info := model.SocketInfo{
+			Port:       port,
+			State:      mapTCPState(int(e.TCPState)),
+			LocalAddr:  e.Address,
+			RemoteAddr: e.Remote,
+		}
+		addStateExplanation(&info)
+		states = append(states, info)
+	}
+	return states, nil
+}
@@ -0,0 +1,270 @@
+//go:build linux
+
+package proc
+
+import (
+	"bytes"
+	"encoding/binary"
+	"net"
+	"strconv"
+	"testing"
+
+	"github.com/pranshuparmar/witr/pkg/model"
+	"golang.org/x/sys/unix"
+)
+
+func TestPortBytecode(t *testing.T) {
+	t.Parallel()
+
+	if bc := portBytecode(0, true); bc != nil {
+		t.Errorf("port 0 should not filter, got %v", bc)
+	}
+
+	le := func(v uint16) []byte {
+		b := make([]byte, 2)
+		binary.NativeEndian.PutUint16(b, v)
+		return b
+	}
+	op := func(code, yes uint8, no uint16) []byte {
+		return append([]byte{code, yes}, le(no)...)
+	}
+	local := append(op(inetDiagBCSEq, 8, 12), op(0, 0, 8080)...)
+	if got := portBytecode(8080, false); !bytes.Equal(got, local) {
+		t.Errorf("local-only bytecode = %v, want %v", got, local)
+	}
+
+	want := append([]byte{}, local...)
+	want = append(want, op(inetDiagBCJmp, 4, 12)...)
+	want = append(want, op(inetDiagBCDEq, 8, 12)...)
+	want = append(want, op(0, 0, 8080)...)
+	if got := portBytecode(8080, true); !bytes.Equal(got, want) {
+		t.Errorf("local-or-remote bytecode = %v, want %v", got, want)
+	}
+}
+
+// inetDiagMsg encodes a struct inet_diag_msg with a cgroup ID attribute.
+func inetDiagMsg(family, state uint8, src, dst net.IP, sport, dport uint16, rq, wq, uid, ino uint32, cgroup uint64) []byte {
+	b := make([]byte, 72)
+	b[0], b[1] = family, state
+	binary.BigEndian.PutUint16(b[4:6], sport)
+	binary.BigEndian.PutUint16(b[6:8], dport)
+	if family == unix.AF_INET {
+		copy(b[8:12], src.To4())
+		copy(b[24:28], dst.To4())
+	} else {
+		copy(b[8:24], src.To16())
+		copy(b[24:40], dst.To16())
+	}
+	binary.NativeEndian.PutUint32(b[56:60], rq)
+	binary.NativeEndian.PutUint32(b[60:64], wq)
+	binary.NativeEndian.PutUint32(b[64:68], uid)
+	binary.NativeEndian.PutUint32(b[68:72], ino)
+
+	attr := make([]byte, 12)
+	binary.NativeEndian.PutUint16(attr[0:2], 12)
+	binary.NativeEndian.PutUint16(attr[2:4], inetDiagCgroupID)
+	binary.NativeEndian.PutUint64(attr[4:12], cgroup)
+	return append(b, attr...)
+}
+
+func TestParseInetDiagMsg(t *testing.T) {
+	t.Parallel()
+
+	listener := inetDiagMsg(unix.AF_INET, 10, net.IPv4zero, net.IPv4zero, 443, 0, 3, 4096, 0, 1234, 42)
+	got, ok := parseInetDiagMsg(listener)
+	if !ok {
+		t.Fatal("listener message rejected")
+	}
+	want := inetDiagSocket{
+		Socket: model.Socket{
+			Inode: "1234", Port: 443, Address: "0.0.0.0", State: "LISTEN",
+			RecvQ: 3, SendQ: 4096, UID: "0", CgroupID: 42,
+		},
+		TCPState: 10,
+		Remote:   "0.0.0.0",
+	}
+	if got != want {
+		t.Errorf("listener = %+v\nwant %+v", got, want)
+	}
+
+	conn := inetDiagMsg(unix.AF_INET6, 1, net.ParseIP("::1"), net.ParseIP("fe80::1"), 51000, 5432, 0, 17, 1000, 5678, 0)
+	got, ok = parseInetDiagMsg(conn)
+	if !ok {
+		t.Fatal("connection message rejected")
+	}
+	if got.Address != "::1" || got.Port != 51000 || got.RemoteAddress != "fe80::1" || got.RemotePort != 5432 {
+		t.Errorf("connection endpoints = %+v", got.Socket)
+	}
+	if got.State != "ESTABLISHED" || got.SendQ != 17 || got.UID != "1000" || got.Inode != "5678" {
+		t.Errorf("connection details = %+v", got.Socket)
+	}
+
+	if _, ok := parseInetDiagMsg(conn[:40]); ok {
+		t.Error("short message should be rejected")
+	}
+}
+
+// openLoopbackConns opens n accepted TCP connections to a fresh loopback
+// listener and returns its port. Everything is closed when the test ends.
+func openLoopbackConns(tb testing.TB, n int) int {
+	tb.Helper()
+	ln, err := net.Listen("tcp4", "127.0.0.1:0")
+	if err != nil {
+		tb.Skipf("cannot listen on loopback: %v", err)
+	}
+	tb.Cleanup(func() { ln.Close() })
+	for i := 0; i < n; i++ {
+		c, err := net.Dial("tcp4", ln.Addr().String())
+		if err != nil {
+			tb.Fatalf("dial: %v", err)
+		}
+		s, err := ln.Accept()
+		if err != nil {
+			tb.Fatalf("accept: %v", err)
+		}
+		tb.Cleanup(func() { c.Close(); s.Close() })
+	}
+	return ln.Addr().(*net.TCPAddr).Port
+}
+
+func requireInetDiag(tb testing.TB) {
+	tb.Helper()
+	if _, err := PortSocketInodes(model.PortQuery{Low: 1, High: 1}, true); err != nil {
+		tb.Skipf("sock_diag port filters unavailable: %v", err)
+	}
+}
+
+// TestInetDiagMatchesProcNet checks the sock_diag collector reports the same
+// sockets as the /proc/net text parser it replaces.
+func TestInetDiagMatchesProcNet(t *testing.T) {
+	requireInetDiag(t)
+	port := openLoopbackConns(t, 2)
+
+	diag, err := readSocketsDiag()
+	if err != nil {
+		t.Fatalf("readSocketsDiag: %v", err)
+	}
+	text, err := readSocketsText()
+	if err != nil {
+		t.Fatalf("readSocketsText: %v", err)
+	}
+
+	ours := 0
+	for inode, want := range text {
+		if want.Port != port && want.RemotePort != port {
+			continue
+		}
+		ours++
+		got, ok := diag[inode]
+		if !ok {
+			t.Errorf("sock_diag is missing socket %s (%+v)", inode, want)
+			continue
+		}
+		got.CgroupID = 0 // only sock_diag reports it
+		if got.State == "LISTEN" {
+			got.SendQ = want.SendQ // max backlog; the text table shows 0
+		}
+		if got != want {
+			t.Errorf("socket %s:\n diag %+v\n text %+v", inode, got, want)
+		}
+	}
+	if ours != 5 {
+		t.Errorf("found %d sockets on port %d in /proc/net, want 5 (listener + 2×2 ends)", ours, port)
+	}
+
+	q := model.PortQuery{Low: port, High: port}
+	listeners, err := PortSocketInodes(q, true)
+	if err != nil || len(listeners) != 1 {
+		t.Errorf("PortSocketInodes(%d, listeners) = %v, %v; want the listener only", port, listeners, err)
+	}
+	all, err := PortSocketInodes(q, false)
+	if err != nil || len(all) != 5 {
+		t.Errorf("PortSocketInodes(%d, all) = %d inodes, %v; want 5", port, len(all), err)
+	}
+
+	diagStates, err := socketStatesDiag(port)
+	if err != nil {
+		t.Fatalf("socketStatesDiag: %v", err)
+	}
+	if textStates := socketStatesText(port); len(diagStates) != len(textStates) {
+		t.Errorf("socketStatesDiag found %d sockets, text parser %d", len(diagStates), len(textStates))
+	}
+}
+
+// Netlink vs. text parsing
+// ------------------------
+// The sock_diag benchmarks filter in the kernel and skip formatting and
+// re-parsing every row; the gap grows with the host's connection count, so
+// each runs against an extra 500 loopback connections.
+//
+//   go test ./internal/proc/ -run '^$' -bench 'Diag$|Text$' -benchmem
+//
+// Baseline (4-vCPU VM, ~1k sockets plus TIME_WAIT left by earlier runs):
+//   ReadSockets          diag ~14 ms/op    text ~27 ms/op    full table
+//   SocketStateForPort   diag ~2.2 ms/op   text ~35 ms/op    1k matching sockets
+//   PortSocketInodes     diag ~0.14 ms/op  text ~52 ms/op    one listener
+
+const benchConns = 500
+
+func BenchmarkReadSocketsDiag(b *testing.B) {
+	requireInetDiag(b)
+	openLoopbackConns(b, benchConns)
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		if _, err := readSocketsDiag(); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkReadSocketsText(b *testing.B) {
+	openLoopbackConns(b, benchConns)
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		if _, err := readSocketsText(); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkSocketStateForPortDiag(b *testing.B) {
+	requireInetDiag(b)
+	port := openLoopbackConns(b, benchConns)
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		if _, err := socketStatesDiag(port); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkSocketStateForPortText(b *testing.B) {
+	port := openLoopbackConns(b, benchConns)
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		socketStatesText(port)
+	}
+}
+
+func BenchmarkPortSocketInodesDiag(b *testing.B) {
+	requireInetDiag(b)
+	port := openLoopbackConns(b, benchConns)
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		if _, err := PortSocketInodes(model.PortQuery{Low: port, High: port}, true); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+// BenchmarkPortSocketInodesText is the text-table equivalent: the same
+// filter applied after reading every socket.
+func BenchmarkPortSocketInodesText(b *testing.B) {
+	port := openLoopbackConns(b, benchConns)
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		if inodes := PortSocketInodesText(model.PortQuery{Low: port, High: port}, true); len(inodes) != 1 {
+			b.Fatalf("found %d listeners on %s", len(inodes), strconv.Itoa(port))
+		}
+	}
+}
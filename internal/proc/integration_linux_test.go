@@ -9,6 +9,8 @@ import (
 	"path/filepath"
 	"syscall"
 	"testing"
+
+	"github.com/pranshuparmar/witr/pkg/model"
 )
 
 // These tests anchor on the test process itself (its PID, an fd it holds, a
@@ -154,7 +156,7 @@ func TestGetSocketStateForPortFindsListener(t *testing.T) {
 	defer ln.Close()
 
 	port := ln.Addr().(*net.TCPAddr).Port
-	info := GetSocketStateForPort(port)
+	info := GetSocketStateForPort(port, "")
 	if info == nil {
 		t.Fatalf("GetSocketStateForPort(%d) = nil, want our listener", port)
 	}
@@ -162,3 +164,104 @@ func TestGetSocketStateForPortFindsListener(t *testing.T) {
 		t.Errorf("socket state = %q, want LISTEN", info.State)
 	}
 }
+
+func TestResolveSocketPeersFindsLoopbackPeer(t *testing.T) {
+	ln, err := net.Listen("tcp4", "127.0.0.1:0")
+	if err != nil {
+		t.Skipf("cannot listen on loopback: %v", err)
+	}
+	defer ln.Close()
+
+	accepted := make(chan net.Conn, 1)
+	go func() {
+		c, err := ln.Accept()
+		if err == nil {
+			accepted <- c
+		}
+	}()
+	client, err := net.Dial("tcp4", ln.Addr().String())
+	if err != nil {
+		t.Fatalf("dial: %v", err)
+	}
+	defer client.Close()
+	server := <-accepted
+	defer server.Close()
+
+	// Bypass the socket cache so the just-opened connection is visible.
+	socketCacheMu.Lock()
+	socketCache = nil
+	socketCacheMu.Unlock()
+
+	clientAddr := client.LocalAddr().(*net.TCPAddr)
+	serverAddr := ln.Addr().(*net.TCPAddr)
+	sockets := ResolveSocketPeers([]model.Socket{{
+		Address: "127.0.0.1", Port: clientAddr.Port, State: "ESTABLISHED", Protocol: "TCP",
+		RemoteAddress: "127.0.0.1", RemotePort: serverAddr.Port,
+	}})
+
+	// Both ends belong to the test process, so the peer is ourselves.
+	if sockets[0].Peer == nil {
+		t.Fatal("expected the accepted connection to be found as the peer")
+	}
+	if sockets[0].Peer.PID != os.Getpid() {
+		t.Errorf("peer PID = %d, want %d", sockets[0].Peer.PID, os.Getpid())
+	}
+}
+
+func TestReadUnixSocketsFindsConnectedClient(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "witr.sock")
+	ln, err := net.Listen("unix", path)
+	if err != nil {
+		t.Skipf("cannot listen on unix socket: %v", err)
+	}
+	defer ln.Close()
+
+	accepted := make(chan net.Conn, 1)
+	go func() {
+		c, err := ln.Accept()
+		if err == nil {
+			accepted <- c
+		}
+	}()
+	client, err := net.Dial("unix", path)
+	if err != nil {
+		t.Fatalf("dial: %v", err)
+	}
+	defer client.Close()
+	server := <-accepted
+	defer server.Close()
+
+	inodes, err := FindUnixSocketInodes(path)
+	if err != nil || len(inodes) != 1 {
+		t.Fatalf("FindUnixSocketInodes(%s) = %v, %v; want one listener", path, inodes, err)
+	}
+
+	var listener, conn *model.UnixSocket
+	sockets := ReadUnixSockets(os.Getpid())
+	for i := range sockets {
+		if sockets[i].Path != path {
+			continue
+		}
+		if sockets[i].Client {
+			conn = &sockets[i]
+		} else {
+			listener = &sockets[i]
+		}
+	}
+	if listener == nil {
+		t.Fatalf("listener %s not reported in %+v", path, sockets)
+	}
+	if listener.State != "LISTEN" || listener.Type != "STREAM" {
+		t.Errorf("listener = %+v, want a STREAM LISTEN socket", *listener)
+	}
+	if _, err := dumpUnixDiag(); err != nil {
+		t.Skipf("sock_diag unavailable, peers not resolvable: %v", err)
+	}
+	// Both ends belong to the test process, so each side's peer is ourselves.
+	if len(listener.Peers) != 1 || listener.Peers[0].PID != os.Getpid() {
+		t.Errorf("listener peers = %+v, want the test process", listener.Peers)
+	}
+	if conn == nil || len(conn.Peers) != 1 || conn.Peers[0].PID != os.Getpid() {
+		t.Errorf("client connection = %+v, want one connected to the test process", conn)
+	}
+}
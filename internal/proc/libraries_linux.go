@@ -0,0 +1,236 @@
+//go:build linux
+
+package proc
+
+import (
+	"bytes"
+	"debug/elf"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"syscall"
+
+	"github.com/pranshuparmar/witr/pkg/model"
+)
+
+// maxNoteSize bounds how much of a PT_NOTE segment is read when looking for
+// the build ID; real note segments are a few hundred bytes.
+const maxNoteSize = 64 << 10
+
+// libraryMapping is the first mapping of a shared object in /proc/<pid>/maps.
+type libraryMapping struct {
+	Path    string
+	Range   string // "start-end", the entry's name under /proc/<pid>/map_files
+	Inode   uint64
+	Deleted bool
+}
+
+// ReadLibraries returns the shared objects mapped by pid in load order,
+// flagging any whose file has been deleted or replaced on disk. Build IDs are
+// read only when withBuildIDs is set, since that opens every mapped ELF.
+// Returns nil if the maps file can't be read.
+func ReadLibraries(pid int, withBuildIDs bool) []model.Library {
+	libs, _ := readLibraries(pid, withBuildIDs)
+	return libs
+}
+
+// ReadStaleCode reports the code pid runs that is no longer on disk: the
+// executable's path if it was deleted or replaced, and the libraries that
+// were. Build IDs are skipped, keeping a scan of every process cheap. ok is
+// false when reading pid's mappings is not permitted, typically for another
+// user's process without root; a process that has exited reports nothing.
+func ReadStaleCode(pid int) (exe string, libs []model.Library, ok bool) {
+	all, err := readLibraries(pid, false)
+	if errors.Is(err, fs.ErrPermission) {
+		return "", nil, false
+	}
+	for _, lib := range all {
+		if lib.Stale() {
+			libs = append(libs, lib)
+		}
+	}
+	return staleExecutable(pid), libs, true
+}
+
+// staleExecutable returns the path of pid's executable if it was deleted or
+// replaced since the process started, or "" otherwise (including for kernel
+// threads, which have none).
+func staleExecutable(pid int) string {
+	link := fmt.Sprintf("/proc/%d/exe", pid)
+	path, err := os.Readlink(link)
+	if err != nil {
+		return ""
+	}
+	if trimmed, deleted := strings.CutSuffix(path, " (deleted)"); deleted {
+		return trimmed
+	}
+	// Stat through the link reaches the running file; stat by path (inside
+	// the process's root) reaches whatever is installed there now.
+	running, err := os.Stat(link)
+	if err != nil {
+		return ""
+	}
+	installed, err := os.Stat(fmt.Sprintf("/proc/%d/root%s", pid, path))
+	if err != nil {
+		return ""
+	}
+	if fileInode(running) != fileInode(installed) {
+		return path
+	}
+	return ""
+}
+
+func fileInode(fi os.FileInfo) uint64 {
+	if st, ok := fi.Sys().(*syscall.Stat_t); ok {
+		return st.Ino
+	}
+	return 0
+}
+
+func readLibraries(pid int, withBuildIDs bool) ([]model.Library, error) {
+	data, err := os.ReadFile(fmt.Sprintf("/proc/%d/maps", pid))
+	if err != nil {
+		return nil, err
+	}
+	mappings := parseLibraryMappings(string(data))
+	if len(mappings) == 0 {
+		return nil, nil
+	}
+
+	// Paths in maps are relative to the process's root, which differs from
+	// witr's inside containers and chroots.
+	root := fmt.Sprintf("/proc/%d/root", pid)
+	libs := make([]model.Library, 0, len(mappings))
+	for _, m := range mappings {
+		lib := model.Library{Path: m.Path, Deleted: m.Deleted}
+		onDisk := root + m.Path
+		if !m.Deleted {
+			// Compare inodes only: overlayfs reports a different st_dev
+			// through stat than the device recorded in maps.
+			if fi, err := os.Stat(onDisk); err == nil && fileInode(fi) != m.Inode {
+				lib.Replaced = true
+			}
+		}
+		if withBuildIDs {
+			if lib.Stale() {
+				// The path no longer names the mapped file; map_files does,
+				// but opening it needs CAP_SYS_ADMIN or CAP_CHECKPOINT_RESTORE.
+				onDisk = fmt.Sprintf("/proc/%d/map_files/%s", pid, m.Range)
+			}
+			lib.BuildID = readBuildID(onDisk)
+		}
+		libs = append(libs, lib)
+	}
+	return libs, nil
+}
+
+// parseLibraryMappings extracts the shared objects from a maps file, keeping
+// the first mapping of each path.
+func parseLibraryMappings(content string) []libraryMapping {
+	var mappings []libraryMapping
+	seen := make(map[string]bool)
+	for _, line := range strings.Split(content, "\n") {
+		fields, path, ok := splitMapsLine(line)
+		if !ok {
+			continue
+		}
+		path, deleted := strings.CutSuffix(path, " (deleted)")
+		if !isSharedObject(path) || seen[path] {
+			continue
+		}
+		inode, err := strconv.ParseUint(fields[4], 10, 64)
+		if err != nil || inode == 0 {
+			continue
+		}
+		seen[path] = true
+		mappings = append(mappings, libraryMapping{
+			Path:    path,
+			Range:   fields[0],
+			Inode:   inode,
+			Deleted: deleted,
+		})
+	}
+	return mappings
+}
+
+// splitMapsLine splits a maps line into its five fixed fields (address,
+// perms, offset, dev, inode) and the pathname, which may contain spaces.
+func splitMapsLine(line string) (fields [5]string, path string, ok bool) {
+	rest := line
+	for i := range fields {
+		rest = strings.TrimLeft(rest, " ")
+		end := strings.IndexByte(rest, ' ')
+		if end <= 0 {
+			return fields, "", false
+		}
+		fields[i], rest = rest[:end], rest[end:]
+	}
+	path = strings.TrimLeft(rest, " ")
+	return fields, path, path != ""
+}
+
+// isSharedObject matches file-backed paths named like libfoo.so or
+// libfoo.so.1.2, including the dynamic loader (ld-linux-x86-64.so.2).
+// memfd mappings look like paths but have no file behind them.
+func isSharedObject(path string) bool {
+	if !strings.HasPrefix(path, "/") || strings.HasPrefix(path, "/memfd:") {
+		return false
+	}
+	base := filepath.Base(path)
+	return strings.HasSuffix(base, ".so") || strings.Contains(base, ".so.")
+}
+
+// readBuildID returns the hex GNU build ID from path's ELF notes, or "" if
+// the file can't be opened or carries none.
+func readBuildID(path string) string {
+	f, err := elf.Open(path)
+	if err != nil {
+		return ""
+	}
+	defer f.Close()
+	for _, prog := range f.Progs {
+		if prog.Type != elf.PT_NOTE {
+			continue
+		}
+		notes, err := io.ReadAll(io.LimitReader(prog.Open(), maxNoteSize))
+		if err != nil {
+			continue
+		}
+		if id := parseBuildIDNote(notes, f.ByteOrder); id != "" {
+			return id
+		}
+	}
+	return ""
+}
+
+// parseBuildIDNote walks a note segment for the NT_GNU_BUILD_ID entry. Each
+// note is a namesz/descsz/type header followed by the name and descriptor,
+// both padded to four bytes.
+func parseBuildIDNote(notes []byte, order binary.ByteOrder) string {
+	const ntGNUBuildID = 3
+	align := func(n uint64) uint64 { return (n + 3) &^ 3 }
+	for len(notes) >= 12 {
+		nameSize := uint64(order.Uint32(notes[0:4]))
+		descSize := uint64(order.Uint32(notes[4:8]))
+		noteType := order.Uint32(notes[8:12])
+		notes = notes[12:]
+		nameEnd := align(nameSize)
+		descEnd := nameEnd + align(descSize)
+		if descEnd > uint64(len(notes)) {
+			return ""
+		}
+		name := bytes.TrimRight(notes[:nameSize], "\x00")
+		if noteType == ntGNUBuildID && string(name) == "GNU" {
+			return hex.EncodeToString(notes[nameEnd : nameEnd+descSize])
+		}
+		notes = notes[descEnd:]
+	}
+	return ""
+}
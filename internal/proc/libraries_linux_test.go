@@ -0,0 +1,120 @@
+//go:build linux
+
+package proc
+
+import (
+	"encoding/binary"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestParseLibraryMappings(t *testing.T) {
+	t.Parallel()
+
+	content := `55d0c0a00000-55d0c0a28000 r--p 00000000 fd:01 1311 /usr/sbin/nginx
+7f1a2b000000-7f1a2b028000 r--p 00000000 fd:01 2001                       /usr/lib/x86_64-linux-gnu/libc.so.6
+7f1a2b028000-7f1a2b1bd000 r-xp 00028000 fd:01 2001                       /usr/lib/x86_64-linux-gnu/libc.so.6
+7f1a2c000000-7f1a2c090000 r--p 00000000 fd:01 2002                       /usr/lib/x86_64-linux-gnu/libssl.so.3 (deleted)
+7f1a2d000000-7f1a2d001000 r--p 00000000 fd:01 2003                       /opt/my app/lib/libplugin.so
+7f1a2e000000-7f1a2e021000 rw-p 00000000 00:00 0 
+7f1a2f000000-7f1a2f001000 rw-s 00000000 00:01 4096                       /memfd:libfake.so (deleted)
+7f1a30000000-7f1a30001000 r--p 00000000 fd:01 2004                       /usr/share/locale/locale-archive
+7ffd00000000-7ffd00021000 rw-p 00000000 00:00 0                          [stack]
+7f1a31000000-7f1a31001000 r--p 00000000 fd:01 2005                       /usr/lib64/ld-linux-x86-64.so.2
+`
+	got := parseLibraryMappings(content)
+	want := []libraryMapping{
+		{Path: "/usr/lib/x86_64-linux-gnu/libc.so.6", Range: "7f1a2b000000-7f1a2b028000", Inode: 2001},
+		{Path: "/usr/lib/x86_64-linux-gnu/libssl.so.3", Range: "7f1a2c000000-7f1a2c090000", Inode: 2002, Deleted: true},
+		{Path: "/opt/my app/lib/libplugin.so", Range: "7f1a2d000000-7f1a2d001000", Inode: 2003},
+		{Path: "/usr/lib64/ld-linux-x86-64.so.2", Range: "7f1a31000000-7f1a31001000", Inode: 2005},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("parseLibraryMappings =\n%+v\nwant\n%+v", got, want)
+	}
+}
+
+func TestParseBuildIDNote(t *testing.T) {
+	t.Parallel()
+
+	note := func(name string, typ uint32, desc []byte) []byte {
+		pad := func(b []byte) []byte { return append(b, make([]byte, (4-len(b)%4)%4)...) }
+		hdr := make([]byte, 12)
+		binary.LittleEndian.PutUint32(hdr[0:4], uint32(len(name)+1))
+		binary.LittleEndian.PutUint32(hdr[4:8], uint32(len(desc)))
+		binary.LittleEndian.PutUint32(hdr[8:12], typ)
+		b := append(hdr, pad(append([]byte(name), 0))...)
+		return append(b, pad(desc)...)
+	}
+
+	abiTag := note("GNU", 1, []byte{0, 0, 0, 0, 3, 0, 0, 0, 2, 0, 0, 0, 0, 0, 0, 0})
+	buildID := note("GNU", 3, []byte{0xde, 0xad, 0xbe, 0xef, 0x01})
+	if got := parseBuildIDNote(append(abiTag, buildID...), binary.LittleEndian); got != "deadbeef01" {
+		t.Errorf("build ID = %q, want deadbeef01", got)
+	}
+
+	if got := parseBuildIDNote(note("Go", 3, []byte{1, 2}), binary.LittleEndian); got != "" {
+		t.Errorf("non-GNU note should be ignored, got %q", got)
+	}
+	if got := parseBuildIDNote(buildID[:len(buildID)-4], binary.LittleEndian); got != "" {
+		t.Errorf("truncated note should yield no build ID, got %q", got)
+	}
+}
+
+func TestReadLibrariesSelf(t *testing.T) {
+	data, err := os.ReadFile("/proc/self/maps")
+	if err != nil {
+		t.Skipf("cannot read maps: %v", err)
+	}
+	if !strings.Contains(string(data), ".so") {
+		t.Skip("test binary is statically linked")
+	}
+
+	libs := ReadLibraries(os.Getpid(), false)
+	if len(libs) == 0 {
+		t.Fatal("expected mapped shared objects")
+	}
+	for _, lib := range libs {
+		if lib.Stale() {
+			t.Errorf("%s flagged stale in a freshly started process", lib.Path)
+		}
+		if lib.BuildID != "" {
+			t.Errorf("%s: build ID %q read without withBuildIDs", lib.Path, lib.BuildID)
+		}
+	}
+}
+
+func TestReadStaleCodeDeletedExecutable(t *testing.T) {
+	sleep, err := exec.LookPath("sleep")
+	if err != nil {
+		t.Skip("sleep not found")
+	}
+	data, err := os.ReadFile(sleep)
+	if err != nil {
+		t.Skipf("cannot read %s: %v", sleep, err)
+	}
+	copied := filepath.Join(t.TempDir(), "sleep-copy")
+	if err := os.WriteFile(copied, data, 0o755); err != nil {
+		t.Fatal(err)
+	}
+
+	cmd := exec.Command(copied, "30")
+	if err := cmd.Start(); err != nil {
+		t.Skipf("cannot run copied binary: %v", err)
+	}
+	t.Cleanup(func() { cmd.Process.Kill(); cmd.Wait() })
+
+	if exe, _, ok := ReadStaleCode(cmd.Process.Pid); !ok || exe != "" {
+		t.Errorf("before removal: exe = %q, ok = %v; want nothing stale", exe, ok)
+	}
+	if err := os.Remove(copied); err != nil {
+		t.Fatal(err)
+	}
+	if exe, _, ok := ReadStaleCode(cmd.Process.Pid); !ok || exe != copied {
+		t.Errorf("after removal: exe = %q, ok = %v; want %s", exe, ok, copied)
+	}
+}
@@ -0,0 +1,16 @@
+//go:build !linux
+
+package proc
+
+import "github.com/pranshuparmar/witr/pkg/model"
+
+// ReadLibraries returns nil on non-Linux platforms, which have no
+// /proc/<pid>/maps to list a process's mapped shared objects.
+func ReadLibraries(pid int, withBuildIDs bool) []model.Library {
+	return nil
+}
+
+// ReadStaleCode reports nothing on non-Linux platforms.
+func ReadStaleCode(pid int) (exe string, libs []model.Library, ok bool) {
+	return "", nil, false
+}
@@ -0,0 +1,97 @@
+//go:build linux
+
+package proc
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/pranshuparmar/witr/pkg/model"
+)
+
+// namespaceTypes lists the namespaces reported for a process, in display
+// order. Each is a /proc/<pid>/ns/<type> symlink of the form "type:[inode]".
+var namespaceTypes = []string{"pid", "net", "mnt", "uts", "ipc", "user", "cgroup"}
+
+// ReadNamespaces returns the namespace inodes of pid, marking the ones that
+// differ from PID 1's. Reading another process's ns links requires ptrace
+// access, so when PID 1 is unreadable (unprivileged witr) the comparison falls
+// back to witr's own namespaces; comparedTo says which ("pid 1" or "witr").
+// Returns nil if the target's links can't be read at all.
+func ReadNamespaces(pid int) (namespaces []model.Namespace, comparedTo string) {
+	target := readNamespaceInodes(strconv.Itoa(pid))
+	if len(target) == 0 {
+		return nil, ""
+	}
+	host, comparedTo := readNamespaceInodes("1"), "pid 1"
+	if len(host) == 0 {
+		host, comparedTo = readNamespaceInodes("self"), "witr"
+	}
+
+	for _, t := range namespaceTypes {
+		inode, ok := target[t]
+		if !ok {
+			continue
+		}
+		hostInode, known := host[t]
+		namespaces = append(namespaces, model.Namespace{
+			Type:     t,
+			Inode:    inode,
+			Isolated: known && hostInode != inode,
+		})
+	}
+	return namespaces, comparedTo
+}
+
+// readNamespaceInodes maps namespace type to inode for /proc/<pid>/ns.
+func readNamespaceInodes(pid string) map[string]uint64 {
+	inodes := make(map[string]uint64, len(namespaceTypes))
+	for _, t := range namespaceTypes {
+		link, err := os.Readlink(fmt.Sprintf("/proc/%s/ns/%s", pid, t))
+		if err != nil {
+			continue
+		}
+		if inode, ok := parseNamespaceLink(link); ok {
+			inodes[t] = inode
+		}
+	}
+	return inodes
+}
+
+// parseNamespaceLink extracts the inode from an ns link target such as
+// "net:[4026531840]".
+func parseNamespaceLink(link string) (uint64, bool) {
+	open := strings.Index(link, ":[")
+	if open == -1 || !strings.HasSuffix(link, "]") {
+		return 0, false
+	}
+	inode, err := strconv.ParseUint(link[open+2:len(link)-1], 10, 64)
+	if err != nil {
+		return 0, false
+	}
+	return inode, true
+}
+
+// parseNSPid reads the NSpid line of /proc/<pid>/status. It lists the PID in
+// every nested PID namespace from outermost to innermost; a single entry
+// means the process isn't in a child namespace.
+func parseNSPid(status string) int {
+	for _, line := range strings.Split(status, "\n") {
+		rest, ok := strings.CutPrefix(line, "NSpid:")
+		if !ok {
+			continue
+		}
+		fields := strings.Fields(rest)
+		if len(fields) < 2 {
+			return 0
+		}
+		inner, err := strconv.Atoi(fields[len(fields)-1])
+		if err != nil {
+			return 0
+		}
+		return inner
+	}
+	return 0
+}
@@ -0,0 +1,65 @@
+//go:build linux
+
+package proc
+
+import (
+	"os"
+	"testing"
+)
+
+func TestParseNamespaceLink(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		link   string
+		want   uint64
+		wantOK bool
+	}{
+		{"net:[4026531840]", 4026531840, true},
+		{"pid_for_children:[4026532201]", 4026532201, true},
+		{"net:4026531840", 0, false},
+		{"net:[abc]", 0, false},
+		{"", 0, false},
+	}
+	for _, tt := range tests {
+		got, ok := parseNamespaceLink(tt.link)
+		if got != tt.want || ok != tt.wantOK {
+			t.Errorf("parseNamespaceLink(%q) = (%d, %v), want (%d, %v)", tt.link, got, ok, tt.want, tt.wantOK)
+		}
+	}
+}
+
+func TestParseNSPid(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name   string
+		status string
+		want   int
+	}{
+		{"host process", "Name:\tnginx\nNSpid:\t1234\n", 0},
+		{"container init", "Name:\tnginx\nNSpid:\t1234\t1\n", 1},
+		{"nested namespaces", "NSpid:\t1234\t57\t3\n", 3},
+		{"no NSpid line (old kernel)", "Name:\tnginx\nPid:\t1234\n", 0},
+	}
+	for _, tt := range tests {
+		if got := parseNSPid(tt.status); got != tt.want {
+			t.Errorf("%s: parseNSPid = %d, want %d", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestReadNamespacesSelf(t *testing.T) {
+	ns, comparedTo := ReadNamespaces(os.Getpid())
+	if len(ns) == 0 {
+		t.Skip("namespace links not readable in this environment")
+	}
+	if comparedTo != "pid 1" && comparedTo != "witr" {
+		t.Errorf("comparedTo = %q, want \"pid 1\" or \"witr\"", comparedTo)
+	}
+	for _, n := range ns {
+		if n.Inode == 0 {
+			t.Errorf("namespace %s has zero inode", n.Type)
+		}
+	}
+}
@@ -0,0 +1,11 @@
+//go:build !linux
+
+package proc
+
+import "github.com/pranshuparmar/witr/pkg/model"
+
+// ReadNamespaces returns nil on non-Linux platforms, which have no
+// /proc/<pid>/ns to inspect.
+func ReadNamespaces(pid int) ([]model.Namespace, string) {
+	return nil, ""
+}
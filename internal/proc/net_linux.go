@@ -56,7 +56,16 @@ var stateMap = map[string]string{
 	"0B": "CLOSING",
 }
 
+// readSockets returns every TCP and UDP socket on the host keyed by inode,
+// from sock_diag when available and the /proc/net text tables otherwise.
 func readSockets() (map[string]model.Socket, error) {
+	if sockets, err := readSocketsDiag(); err == nil {
+		return sockets, nil
+	}
+	return readSocketsText()
+}
+
+func readSocketsText() (map[string]model.Socket, error) {
 	sockets := make(map[string]model.Socket)
 
 	parse := func(path, proto string, ipv6 bool) {
@@ -85,13 +94,26 @@ func readSockets() (map[string]model.Socket, error) {
 			}
 
 			addr, port := parseAddr(local, ipv6)
-			sockets[inode] = model.Socket{
+			sock := model.Socket{
 				Inode:    inode,
 				Port:     port,
 				Address:  addr,
 				State:    state,
 				Protocol: proto,
 			}
+			// Unconnected sockets report a zero remote port (0.0.0.0:0).
+			if remAddr, remPort := parseAddr(fields[2], ipv6); remPort != 0 {
+				sock.RemoteAddress = remAddr
+				sock.RemotePort = remPort
+			}
+			// tx_queue:rx_queue, in hex.
+			if tx, rx, ok := strings.Cut(fields[4], ":"); ok {
+				sendQ, _ := strconv.ParseUint(tx, 16, 32)
+				recvQ, _ := strconv.ParseUint(rx, 16, 32)
+				sock.SendQ, sock.RecvQ = uint32(sendQ), uint32(recvQ)
+			}
+			sock.UID = fields[7]
+			sockets[inode] = sock
 		}
 	}
 
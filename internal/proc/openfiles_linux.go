@@ -89,22 +89,6 @@ func fdMode(pid int, fd string) string {
 	if err != nil {
 		return ""
 	}
-	for _, line := range strings.Split(string(data), "\n") {
-		if !strings.HasPrefix(line, "flags:") {
-			continue
-		}
-		flags, err := strconv.ParseInt(strings.TrimSpace(strings.TrimPrefix(line, "flags:")), 8, 64)
-		if err != nil {
-			return ""
-		}
-		switch flags & 3 { // O_ACCMODE = 3; 0=RDONLY, 1=WRONLY, 2=RDWR
-		case 0:
-			return "R"
-		case 1:
-			return "W"
-		case 2:
-			return "RW"
-		}
-	}
-	return ""
+	_, access := parseFDInfo(string(data))
+	return access
 }
@@ -0,0 +1,146 @@
+//go:build linux
+
+package proc
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/pranshuparmar/witr/pkg/model"
+)
+
+// pathUsageReasons orders the ways a process can keep a path busy.
+var pathUsageReasons = []string{"cwd", "open", "mapped", "root"}
+
+// ScanPathUsers finds every process whose working directory, open files,
+// memory mappings or root directory lie at or beneath dir. Groups hold PIDs
+// and paths only; callers fill in the process details.
+func ScanPathUsers(dir string) (model.PathUsage, error) {
+	abs, err := filepath.Abs(dir)
+	if err != nil {
+		return model.PathUsage{}, err
+	}
+	if real, err := filepath.EvalSymlinks(abs); err == nil {
+		abs = real
+	}
+
+	usage := model.PathUsage{Path: abs, Groups: []model.PathUsageGroup{}}
+	if data, err := os.ReadFile("/proc/self/mountinfo"); err == nil {
+		if m, ok := mountFor(parseMountInfo(string(data)), abs); ok {
+			usage.Mount, usage.FSType = m.Point, m.FSType
+		}
+	}
+
+	entries, err := os.ReadDir("/proc")
+	if err != nil {
+		return usage, fmt.Errorf("read /proc: %w", err)
+	}
+	self := os.Getpid()
+	byReason := make(map[string][]model.PathUser)
+	for _, entry := range entries {
+		pid, err := strconv.Atoi(entry.Name())
+		if err != nil || pid == self {
+			continue
+		}
+		uses, readable := pathUses(pid, abs)
+		usage.Scanned++
+		if !readable {
+			usage.Unreadable++
+		}
+		for _, reason := range pathUsageReasons {
+			if paths := uses[reason]; len(paths) > 0 {
+				byReason[reason] = append(byReason[reason], model.PathUser{PID: pid, Paths: paths})
+			}
+		}
+	}
+
+	for _, reason := range pathUsageReasons {
+		if users := byReason[reason]; len(users) > 0 {
+			sort.Slice(users, func(i, j int) bool { return users[i].PID < users[j].PID })
+			usage.Groups = append(usage.Groups, model.PathUsageGroup{Reason: reason, Processes: users})
+		}
+	}
+	return usage, nil
+}
+
+// pathUses returns the paths beneath dir that pid uses, by reason. readable
+// is false when its file descriptors could not be listed.
+func pathUses(pid int, dir string) (uses map[string][]string, readable bool) {
+	base := "/proc/" + strconv.Itoa(pid)
+	uses = make(map[string][]string)
+	readable = true
+
+	if cwd, err := os.Readlink(base + "/cwd"); err == nil {
+		if cwd = strings.TrimSuffix(cwd, " (deleted)"); isBeneath(cwd, dir) {
+			uses["cwd"] = []string{cwd}
+		}
+	}
+	// A root of "/" is the normal case; a chroot or container root beneath
+	// dir pins it too.
+	if root, err := os.Readlink(base + "/root"); err == nil && root != "/" {
+		if root = strings.TrimSuffix(root, " (deleted)"); isBeneath(root, dir) {
+			uses["root"] = []string{root}
+		}
+	}
+
+	fdDir := base + "/fd"
+	fds, err := os.ReadDir(fdDir)
+	if errors.Is(err, os.ErrPermission) {
+		readable = false
+	}
+	seen := make(map[string]bool)
+	for _, fd := range fds {
+		target, err := os.Readlink(fdDir + "/" + fd.Name())
+		if err != nil {
+			continue
+		}
+		target = strings.TrimSuffix(target, " (deleted)")
+		if isBeneath(target, dir) && !seen[target] {
+			seen[target] = true
+			uses["open"] = append(uses["open"], target)
+		}
+	}
+	sort.Strings(uses["open"])
+
+	if data, err := os.ReadFile(base + "/maps"); err == nil {
+		uses["mapped"] = mappedBeneath(string(data), dir)
+	}
+	return uses, readable
+}
+
+// mappedBeneath returns the distinct file-backed mappings in a maps file
+// that lie beneath dir, sorted.
+func mappedBeneath(maps, dir string) []string {
+	seen := make(map[string]bool)
+	var paths []string
+	for _, line := range strings.Split(maps, "\n") {
+		_, path, ok := splitMapsLine(line)
+		if !ok {
+			continue
+		}
+		path = strings.TrimSuffix(path, " (deleted)")
+		if isBeneath(path, dir) && !seen[path] {
+			seen[path] = true
+			paths = append(paths, path)
+		}
+	}
+	sort.Strings(paths)
+	return paths
+}
+
+// isBeneath reports whether path is dir or lies inside it. Pseudo-paths
+// such as "socket:[123]" or "[heap]" never match.
+func isBeneath(path, dir string) bool {
+	if !strings.HasPrefix(path, "/") {
+		return false
+	}
+	if dir == "/" {
+		return true
+	}
+	return path == dir || strings.HasPrefix(path, dir+"/")
+}
@@ -0,0 +1,74 @@
+//go:build linux
+
+package proc
+
+import (
+	"os/exec"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestIsBeneath(t *testing.T) {
+	tests := []struct {
+		path, dir string
+		want      bool
+	}{
+		{"/mnt/data", "/mnt/data", true},
+		{"/mnt/data/a/b", "/mnt/data", true},
+		{"/mnt/database", "/mnt/data", false},
+		{"/etc/passwd", "/", true},
+		{"socket:[1234]", "/", false},
+		{"[heap]", "/", false},
+	}
+	for _, tt := range tests {
+		if got := isBeneath(tt.path, tt.dir); got != tt.want {
+			t.Errorf("isBeneath(%q, %q) = %v, want %v", tt.path, tt.dir, got, tt.want)
+		}
+	}
+}
+
+func TestMappedBeneath(t *testing.T) {
+	maps := `55d0c0a00000-55d0c0a02000 r--p 00000000 08:01 1310 /mnt/app/bin/server
+55d0c0a02000-55d0c0a08000 r-xp 00002000 08:01 1310 /mnt/app/bin/server
+7f1e2c000000-7f1e2c021000 rw-p 00000000 00:00 0 
+7f1e2d000000-7f1e2d200000 r--p 00000000 08:01 2201 /mnt/app/lib/libplugin v2.so (deleted)
+7f1e2e000000-7f1e2e200000 r-xp 00000000 08:01 9 /usr/lib/x86_64-linux-gnu/libc.so.6
+7ffd5e000000-7ffd5e021000 rw-p 00000000 00:00 0 [stack]
+`
+	got := mappedBeneath(maps, "/mnt/app")
+	want := []string{"/mnt/app/bin/server", "/mnt/app/lib/libplugin v2.so"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("mappedBeneath = %q, want %q", got, want)
+	}
+}
+
+func TestScanPathUsersFindsWorkingDirectory(t *testing.T) {
+	dir := t.TempDir()
+	cmd := exec.Command("sleep", "10")
+	cmd.Dir = dir
+	if err := cmd.Start(); err != nil {
+		t.Skipf("cannot start sleep: %v", err)
+	}
+	defer func() {
+		_ = cmd.Process.Kill()
+		_ = cmd.Wait()
+	}()
+	time.Sleep(50 * time.Millisecond)
+
+	usage, err := ScanPathUsers(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, g := range usage.Groups {
+		if g.Reason != "cwd" {
+			continue
+		}
+		for _, p := range g.Processes {
+			if p.PID == cmd.Process.Pid {
+				return
+			}
+		}
+	}
+	t.Errorf("sleep (pid %d) with cwd %s not found: %+v", cmd.Process.Pid, dir, usage)
+}
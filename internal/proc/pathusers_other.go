@@ -0,0 +1,16 @@
+//go:build !linux
+
+package proc
+
+import (
+	"fmt"
+	"runtime"
+
+	"github.com/pranshuparmar/witr/pkg/model"
+)
+
+// ScanPathUsers is only supported on Linux; elsewhere a directory given to
+// --file is matched like any other file.
+func ScanPathUsers(dir string) (model.PathUsage, error) {
+	return model.PathUsage{}, fmt.Errorf("directory targets are not supported on %s", runtime.GOOS)
+}
@@ -0,0 +1,132 @@
+//go:build linux
+
+package proc
+
+import (
+	"net"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/pranshuparmar/witr/pkg/model"
+)
+
+// ResolveSocketPeers fills in Peer for every established TCP socket whose
+// remote end is on this host (loopback or one of its interface addresses).
+// The peer socket is the table entry with the reversed 4-tuple; its owner is
+// found by scanning /proc/*/fd, so processes witr can't inspect stay unnamed.
+// The input slice is returned with peers set in place.
+func ResolveSocketPeers(sockets []model.Socket) []model.Socket {
+	var hostAddrs map[string]bool
+	var local []int
+	for i, s := range sockets {
+		if s.State != "ESTABLISHED" || !strings.HasPrefix(s.Protocol, "TCP") {
+			continue
+		}
+		if hostAddrs == nil {
+			hostAddrs = hostAddresses()
+		}
+		if ip := net.ParseIP(s.RemoteAddress); (ip != nil && ip.IsLoopback()) || hostAddrs[s.RemoteAddress] {
+			local = append(local, i)
+		}
+	}
+	if len(local) == 0 {
+		return sockets
+	}
+
+	table, err := readSocketsCached()
+	if err != nil {
+		return sockets
+	}
+	byTuple := make(map[string]string, len(table))
+	for inode, s := range table {
+		if s.State == "ESTABLISHED" && s.RemotePort != 0 {
+			byTuple[socketTuple(s.Address, s.Port, s.RemoteAddress, s.RemotePort)] = inode
+		}
+	}
+
+	peerInodes := make(map[string]bool)
+	peerOf := make(map[int]string)
+	for _, i := range local {
+		s := sockets[i]
+		if inode, ok := byTuple[socketTuple(s.RemoteAddress, s.RemotePort, s.Address, s.Port)]; ok {
+			peerInodes[inode] = true
+			peerOf[i] = inode
+		}
+	}
+	if len(peerInodes) == 0 {
+		return sockets
+	}
+
+	owners := socketOwners(peerInodes)
+	names := make(map[int]string)
+	for i, inode := range peerOf {
+		pid, ok := owners[inode]
+		if !ok {
+			continue
+		}
+		sockets[i].Peer = &model.SocketPeer{PID: pid, Command: lockProcessName(pid, names)}
+	}
+	return sockets
+}
+
+func socketTuple(localAddr string, localPort int, remoteAddr string, remotePort int) string {
+	return localAddr + "|" + strconv.Itoa(localPort) + "|" + remoteAddr + "|" + strconv.Itoa(remotePort)
+}
+
+// socketOwners maps each socket inode to the first PID holding an fd on it,
+// stopping the /proc walk as soon as every inode has been found.
+func socketOwners(inodes map[string]bool) map[string]int {
+	owners := make(map[string]int, len(inodes))
+	entries, err := os.ReadDir("/proc")
+	if err != nil {
+		return owners
+	}
+	for _, entry := range entries {
+		pid, err := strconv.Atoi(entry.Name())
+		if err != nil {
+			continue
+		}
+		fdDir := filepath.Join("/proc", entry.Name(), "fd")
+		fds, err := os.ReadDir(fdDir)
+		if err != nil {
+			continue
+		}
+		for _, fd := range fds {
+			link, err := os.Readlink(filepath.Join(fdDir, fd.Name()))
+			if err != nil {
+				continue
+			}
+			rest, ok := strings.CutPrefix(link, "socket:[")
+			if !ok {
+				continue
+			}
+			inode := strings.TrimSuffix(rest, "]")
+			if _, done := owners[inode]; inodes[inode] && !done {
+				owners[inode] = pid
+			}
+		}
+		if len(owners) == len(inodes) {
+			break
+		}
+	}
+	return owners
+}
+
+// hostAddresses returns the interface addresses of this host in the same
+// string form readSockets produces, so a remote address found here means the
+// other end of the connection is a local process.
+func hostAddresses() map[string]bool {
+	addrs := make(map[string]bool)
+	ifaceAddrs, err := net.InterfaceAddrs()
+	if err != nil {
+		return addrs
+	}
+	for _, a := range ifaceAddrs {
+		if ipnet, ok := a.(*net.IPNet); ok {
+			addrs[ipnet.IP.String()] = true
+		}
+	}
+	return addrs
+}
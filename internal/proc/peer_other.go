@@ -0,0 +1,11 @@
+//go:build !linux
+
+package proc
+
+import "github.com/pranshuparmar/witr/pkg/model"
+
+// ResolveSocketPeers returns sockets unchanged on non-Linux platforms, whose
+// socket listings don't carry the remote end needed to match peers.
+func ResolveSocketPeers(sockets []model.Socket) []model.Socket {
+	return sockets
+}
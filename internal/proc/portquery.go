@@ -0,0 +1,142 @@
+package proc
+
+import (
+	"net"
+	"sort"
+	"strings"
+
+	"github.com/pranshuparmar/witr/pkg/model"
+)
+
+// selectBound returns the indexes of the n sockets whose local end q
+// matches, given each one's protocol, address and port. A socket bound to
+// the wildcard address is left out when another one with the same protocol
+// and port is bound to the queried address itself: the kernel delivers to
+// the specific bind.
+func selectBound(q model.PortQuery, n int, at func(i int) (protocol, address string, port int)) []int {
+	type key struct {
+		udp  bool
+		port int
+	}
+	var matched []int
+	wildcard := make(map[int]bool)
+	specific := make(map[key]bool)
+	for i := 0; i < n; i++ {
+		protocol, address, port := at(i)
+		if !q.MatchesProtocol(protocol) || !q.MatchesPort(port) {
+			continue
+		}
+		ok, exact := q.MatchesAddress(address)
+		if !ok {
+			continue
+		}
+		matched = append(matched, i)
+		if exact {
+			specific[key{isUDP(protocol), port}] = true
+		} else {
+			wildcard[i] = true
+		}
+	}
+	selected := matched[:0]
+	for _, i := range matched {
+		protocol, _, port := at(i)
+		if wildcard[i] && specific[key{isUDP(protocol), port}] {
+			continue
+		}
+		selected = append(selected, i)
+	}
+	return selected
+}
+
+// PortListener returns the socket among a process's own sockets that q
+// selected it by: a TCP listener or a bound UDP socket on a port, protocol
+// and address q matches. ok is false when none of them does, e.g. when the
+// process was found through a connection to the port rather than a bind.
+func PortListener(sockets []model.Socket, q model.PortQuery) (model.Socket, bool) {
+	var listening []model.Socket
+	for _, s := range sockets {
+		if s.State == "LISTEN" || isUDP(s.Protocol) {
+			listening = append(listening, s)
+		}
+	}
+	selected := selectBound(q, len(listening), func(i int) (string, string, int) {
+		return listening[i].Protocol, listening[i].Address, listening[i].Port
+	})
+	if len(selected) == 0 {
+		return model.Socket{}, false
+	}
+	return listening[selected[0]], true
+}
+
+// socketStatesOn keeps the socket states whose local end is address. A
+// wildcard or empty address keeps them all, since a wildcard listener
+// accepts connections on every local address.
+func socketStatesOn(states []model.SocketInfo, address string) []model.SocketInfo {
+	want := net.ParseIP(strings.Trim(address, "[]"))
+	if want == nil || want.IsUnspecified() {
+		return states
+	}
+	var kept []model.SocketInfo
+	for _, s := range states {
+		host := s.LocalAddr
+		if h, _, err := net.SplitHostPort(host); err == nil {
+			host = h // Windows netstat keeps the port: "127.0.0.1:6379"
+		}
+		if ip := net.ParseIP(strings.Trim(host, "[]")); ip != nil && ip.Equal(want) {
+			kept = append(kept, s)
+		}
+	}
+	return kept
+}
+
+// IsUDP reports whether a socket protocol label ("UDP", "udp6", ...) is UDP.
+func IsUDP(protocol string) bool {
+	return isUDP(protocol)
+}
+
+func isUDP(protocol string) bool {
+	return strings.HasPrefix(strings.ToUpper(protocol), "UDP")
+}
+
+// ListPortListeners returns the TCP listeners and bound UDP sockets q
+// matches, one entry per process, protocol, address and port, sorted by
+// port, then protocol, address and PID.
+func ListPortListeners(q model.PortQuery) ([]model.OpenPort, error) {
+	ports, err := ListOpenPorts()
+	if err != nil {
+		return nil, err
+	}
+	var listening []model.OpenPort
+	seen := make(map[model.OpenPort]bool)
+	for _, p := range ports {
+		if p.State != "LISTEN" && !isUDP(p.Protocol) {
+			continue
+		}
+		p.State = "" // a UDP socket reports CLOSE, OPEN or LISTEN by platform
+		if !seen[p] {
+			seen[p] = true
+			listening = append(listening, p)
+		}
+	}
+
+	var result []model.OpenPort
+	for _, i := range selectBound(q, len(listening), func(i int) (string, string, int) {
+		return listening[i].Protocol, listening[i].Address, listening[i].Port
+	}) {
+		result = append(result, listening[i])
+	}
+	sort.Slice(result, func(i, j int) bool {
+		a, b := result[i], result[j]
+		if a.Port != b.Port {
+			return a.Port < b.Port
+		}
+		if a.Protocol != b.Protocol {
+			return a.Protocol < b.Protocol
+		}
+		if a.Address != b.Address {
+			return a.Address < b.Address
+		}
+		return a.PID < b.PID
+	})
+	return result, nil
+}
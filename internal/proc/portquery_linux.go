@@ -0,0 +1,55 @@
+//go:build linux
+
+package proc
+
+import "github.com/pranshuparmar/witr/pkg/model"
+
+// PortSocketInodesText is PortSocketInodes over the /proc/net text tables,
+// for kernels without sock_diag port filters.
+func PortSocketInodesText(q model.PortQuery, listenersOnly bool) map[string]bool {
+	table, _ := readSocketsText()
+	sockets := make([]model.Socket, 0, len(table))
+	for _, s := range table {
+		sockets = append(sockets, s)
+	}
+	return selectPortSockets(sockets, q, listenersOnly)
+}
+
+// selectPortSockets returns the inodes of the sockets q selects. With
+// listenersOnly these are TCP listeners and bound or connected UDP sockets
+// whose local end matches q; otherwise TCP sockets in any state qualify,
+// and sockets whose remote end matches q as well, so a process with an
+// outbound connection to the port is found too.
+func selectPortSockets(sockets []model.Socket, q model.PortQuery, listenersOnly bool) map[string]bool {
+	var candidates []model.Socket
+	for _, s := range sockets {
+		if isUDP(s.Protocol) {
+			// UDP is connectionless: CLOSE means bound and ready to receive.
+			if s.State != "CLOSE" && s.State != "ESTABLISHED" {
+				continue
+			}
+		} else if listenersOnly && s.State != "LISTEN" {
+			continue
+		}
+		candidates = append(candidates, s)
+	}
+
+	inodes := make(map[string]bool)
+	for _, i := range selectBound(q, len(candidates), func(i int) (string, string, int) {
+		return candidates[i].Protocol, candidates[i].Address, candidates[i].Port
+	}) {
+		inodes[candidates[i].Inode] = true
+	}
+	if listenersOnly {
+		return inodes
+	}
+	for _, s := range candidates {
+		if s.RemotePort == 0 || !q.MatchesProtocol(s.Protocol) || !q.MatchesPort(s.RemotePort) {
+			continue
+		}
+		if ok, _ := q.MatchesAddress(s.RemoteAddress); ok {
+			inodes[s.Inode] = true
+		}
+	}
+	return inodes
+}
@@ -0,0 +1,86 @@
+//go:build linux
+
+package proc
+
+import (
+	"net"
+	"reflect"
+	"testing"
+
+	"github.com/pranshuparmar/witr/pkg/model"
+)
+
+func TestSelectPortSockets(t *testing.T) {
+	sockets := []model.Socket{
+		{Inode: "1", Protocol: "TCP", Address: "0.0.0.0", Port: 5432, State: "LISTEN"},
+		{Inode: "2", Protocol: "TCP", Address: "127.0.0.1", Port: 5432, State: "ESTABLISHED", RemoteAddress: "127.0.0.1", RemotePort: 40000},
+		{Inode: "3", Protocol: "TCP", Address: "127.0.0.1", Port: 40000, State: "ESTABLISHED", RemoteAddress: "127.0.0.1", RemotePort: 5432},
+		{Inode: "4", Protocol: "UDP", Address: "127.0.0.53", Port: 53, State: "CLOSE"},
+		{Inode: "5", Protocol: "UDP6", Address: "::", Port: 53, State: "LISTEN"}, // not a UDP state
+		{Inode: "6", Protocol: "TCP", Address: "10.0.0.2", Port: 41000, State: "TIME_WAIT", RemoteAddress: "10.0.0.9", RemotePort: 5432},
+	}
+	inodes := func(ids ...string) map[string]bool {
+		m := make(map[string]bool)
+		for _, id := range ids {
+			m[id] = true
+		}
+		return m
+	}
+
+	tests := []struct {
+		name          string
+		q             model.PortQuery
+		listenersOnly bool
+		want          map[string]bool
+	}{
+		{"listeners", model.PortQuery{Low: 5432, High: 5432}, true, inodes("1")},
+		{"either end", model.PortQuery{Low: 5432, High: 5432}, false, inodes("1", "2", "3", "6")},
+		{"remote address", model.PortQuery{Address: "10.0.0.9", Low: 5432, High: 5432}, false, inodes("1", "6")},
+		{"udp only", model.PortQuery{Protocol: "udp", Low: 1, High: 65535}, true, inodes("4")},
+		{"tcp excludes udp", model.PortQuery{Protocol: "tcp", Low: 53, High: 53}, true, inodes()},
+	}
+	for _, tt := range tests {
+		if got := selectPortSockets(sockets, tt.q, tt.listenersOnly); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("%s: got %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestPortSocketInodesFiltersAddress(t *testing.T) {
+	ln, err := net.Listen("tcp4", "127.0.0.1:0")
+	if err != nil {
+		t.Skipf("cannot listen on loopback: %v", err)
+	}
+	defer ln.Close()
+	port := ln.Addr().(*net.TCPAddr).Port
+
+	for _, lookup := range []struct {
+		name string
+		fn   func(model.PortQuery) map[string]bool
+	}{
+		{"text", func(q model.PortQuery) map[string]bool { return PortSocketInodesText(q, true) }},
+		{"diag", func(q model.PortQuery) map[string]bool {
+			inodes, err := PortSocketInodes(q, true)
+			if err != nil {
+				t.Skipf("sock_diag unavailable: %v", err)
+			}
+			return inodes
+		}},
+	} {
+		if got := lookup.fn(model.PortQuery{Protocol: "tcp", Address: "127.0.0.1", Low: port, High: port}); len(got) != 1 {
+			t.Errorf("%s: tcp/127.0.0.1:%d found %d sockets, want 1", lookup.name, port, len(got))
+		}
+		if got := lookup.fn(model.PortQuery{Address: "127.0.0.2", Low: port, High: port}); len(got) != 0 {
+			t.Errorf("%s: 127.0.0.2:%d matched a socket bound to 127.0.0.1", lookup.name, port)
+		}
+		if got := lookup.fn(model.PortQuery{Protocol: "udp", Low: port, High: port}); len(got) != 0 {
+			t.Errorf("%s: udp/%d matched a TCP listener", lookup.name, port)
+		}
+		ranged := lookup.fn(model.PortQuery{Low: port - 1, High: port + 1})
+		for inode := range lookup.fn(model.PortQuery{Low: port, High: port}) {
+			if !ranged[inode] {
+				t.Errorf("%s: range around %d missed listener %s", lookup.name, port, inode)
+			}
+		}
+	}
+}
@@ -0,0 +1,102 @@
+package proc
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/pranshuparmar/witr/pkg/model"
+)
+
+func TestSelectBound(t *testing.T) {
+	sockets := []model.OpenPort{
+		{Protocol: "TCP", Address: "0.0.0.0", Port: 6379},    // 0
+		{Protocol: "TCP", Address: "127.0.0.1", Port: 6379},  // 1
+		{Protocol: "TCP6", Address: "::", Port: 6379},        // 2
+		{Protocol: "UDP", Address: "0.0.0.0", Port: 6379},    // 3
+		{Protocol: "TCP", Address: "0.0.0.0", Port: 8080},    // 4
+		{Protocol: "TCP", Address: "10.0.0.5", Port: 8081},   // 5
+		{Protocol: "TCPv6", Address: "::1", Port: 8082},      // 6
+		{Protocol: "UDP", Address: "127.0.0.1", Port: 53},    // 7
+		{Protocol: "TCP", Address: "*", Port: 9000},          // 8
+		{Protocol: "TCP6", Address: "fe80::1%eth0", Port: 9}, // 9
+	}
+	at := func(i int) (string, string, int) {
+		return sockets[i].Protocol, sockets[i].Address, sockets[i].Port
+	}
+
+	tests := []struct {
+		q    model.PortQuery
+		want []int
+	}{
+		// The specific bind wins over both wildcards on the same protocol
+		// and port; UDP is judged on its own.
+		{model.PortQuery{Address: "127.0.0.1", Low: 6379, High: 6379}, []int{1, 3}},
+		{model.PortQuery{Protocol: "tcp", Address: "192.168.1.9", Low: 6379, High: 6379}, []int{0, 2}},
+		{model.PortQuery{Protocol: "udp", Low: 1, High: 65535}, []int{3, 7}},
+		{model.PortQuery{Low: 8080, High: 8082}, []int{4, 5, 6}},
+		{model.PortQuery{Address: "::1", Low: 8080, High: 8082}, []int{6}},
+		{model.PortQuery{Address: "127.0.0.1", Low: 9000, High: 9000}, []int{8}},
+		{model.PortQuery{Address: "fe80::1", Low: 9, High: 9}, []int{9}},
+	}
+	for _, tt := range tests {
+		if got := selectBound(tt.q, len(sockets), at); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("selectBound(%s) = %v, want %v", tt.q, got, tt.want)
+		}
+	}
+}
+
+func TestPortListener(t *testing.T) {
+	sockets := []model.Socket{
+		{Protocol: "TCP", Address: "127.0.0.1", Port: 8050, State: "ESTABLISHED", RemoteAddress: "127.0.0.1", RemotePort: 40000},
+		{Protocol: "TCP", Address: "0.0.0.0", Port: 8050, State: "LISTEN"},
+		{Protocol: "TCP", Address: "127.0.0.1", Port: 6379, State: "LISTEN"},
+		{Protocol: "UDP", Address: "127.0.0.53", Port: 53, State: "CLOSE"},
+		{Protocol: "TCP", Address: "127.0.0.53", Port: 53, State: "LISTEN"},
+	}
+	tests := []struct {
+		q    model.PortQuery
+		want model.Socket
+		ok   bool
+	}{
+		// A range reports the port actually listened on, not its start.
+		{model.PortQuery{Low: 8000, High: 8100}, sockets[1], true},
+		{model.PortQuery{Protocol: "udp", Low: 53, High: 53}, sockets[3], true},
+		{model.PortQuery{Protocol: "tcp", Low: 53, High: 53}, sockets[4], true},
+		{model.PortQuery{Address: "127.0.0.1", Low: 6379, High: 6379}, sockets[2], true},
+		{model.PortQuery{Address: "10.0.0.1", Low: 6379, High: 6379}, model.Socket{}, false},
+	}
+	for _, tt := range tests {
+		got, ok := PortListener(sockets, tt.q)
+		if ok != tt.ok || got != tt.want {
+			t.Errorf("PortListener(%s) = %+v, %v; want %+v, %v", tt.q, got, ok, tt.want, tt.ok)
+		}
+	}
+}
+
+func TestSocketStatesOn(t *testing.T) {
+	states := []model.SocketInfo{
+		{Port: 6379, State: "LISTEN", LocalAddr: "127.0.0.1"},
+		{Port: 6379, State: "TIME_WAIT", LocalAddr: "10.0.0.5"},
+		{Port: 6379, State: "CLOSE_WAIT", LocalAddr: "127.0.0.1:6379"}, // Windows netstat
+		{Port: 6379, State: "LISTEN", LocalAddr: "[::1]:6379"},
+	}
+	tests := []struct {
+		address string
+		want    []string
+	}{
+		{"", []string{"LISTEN", "TIME_WAIT", "CLOSE_WAIT", "LISTEN"}},
+		{"0.0.0.0", []string{"LISTEN", "TIME_WAIT", "CLOSE_WAIT", "LISTEN"}},
+		{"127.0.0.1", []string{"LISTEN", "CLOSE_WAIT"}},
+		{"::1", []string{"LISTEN"}},
+		{"192.168.1.9", nil},
+	}
+	for _, tt := range tests {
+		var got []string
+		for _, s := range socketStatesOn(states, tt.address) {
+			got = append(got, s.State)
+		}
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("socketStatesOn(%q) = %v, want %v", tt.address, got, tt.want)
+		}
+	}
+}
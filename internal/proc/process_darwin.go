@@ -214,6 +214,12 @@ func getCwdAndBinaryPath(pid int) (cwd string, binPath string) {
 	return cwd, binPath
 }
 
+// ReadEnvironment returns pid's environment as KEY=VALUE entries, as far
+// as ps can show it.
+func ReadEnvironment(pid int) []string {
+	return getEnvironment(pid)
+}
+
 func getEnvironment(pid int) []string {
 	var env []string
 
@@ -227,6 +227,12 @@ func parseLstart(lstartStr string) time.Time {
 	return time.Time{}
 }
 
+// ReadEnvironment returns pid's environment as KEY=VALUE entries, as far
+// as procstat can show it.
+func ReadEnvironment(pid int) []string {
+	return getEnvironment(pid)
+}
+
 func getEnvironment(pid int) []string {
 	var env []string
 
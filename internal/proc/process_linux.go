@@ -31,15 +31,7 @@ func ReadProcess(pid int) (model.Process, error) {
 	}
 
 	// Read environment variables
-	env := []string{}
-	envBytes, errEnv := os.ReadFile(fmt.Sprintf("/proc/%d/environ", pid))
-	if errEnv == nil {
-		for _, e := range strings.Split(string(envBytes), "\x00") {
-			if e != "" {
-				env = append(env, e)
-			}
-		}
-	}
+	env := ReadEnvironment(pid)
 	// Health status
 	health := "healthy"
 
@@ -213,6 +205,12 @@ func ReadProcess(pid int) (model.Process, error) {
 
 	user := readUser(pid)
 
+	// status carries both the effective capabilities and NSpid.
+	var status string
+	if data, err := os.ReadFile(fmt.Sprintf("/proc/%d/status", pid)); err == nil {
+		status = string(data)
+	}
+
 	sockets, _ := readSocketsCached()
 	inodes := socketsForPID(pid)
 
@@ -262,6 +260,8 @@ func ReadProcess(pid int) (model.Process, error) {
 		displayName = comm
 	}
 
+	exe, exeDeleted := readExe(pid)
+
 	if comm == "docker-proxy" && container == "" {
 		container = resolveDockerProxyContainer(cmdline)
 	}
@@ -271,6 +271,7 @@ func ReadProcess(pid int) (model.Process, error) {
 		PPID:             ppid,
 		Command:          displayName,
 		Cmdline:          cmdline,
+		Exe:              exe,
 		StartedAt:        startedAt,
 		User:             user,
 		CPUPercent:       cpuPercent,
@@ -287,11 +288,24 @@ func ReadProcess(pid int) (model.Process, error) {
 		Health:           health,
 		Forked:           forked,
 		Env:              env,
-		ExeDeleted:       isBinaryDeleted(pid),
-		Capabilities:     ReadCapabilities(pid),
+		NSPid:            parseNSPid(status),
+		ExeDeleted:       exeDeleted,
+		Capabilities:     parseCapabilities(status),
+		Status:           parseStatusFields(status),
 	}, nil
 }
 
+// parseStatusFields splits /proc/<pid>/status into its "Key:\tvalue" pairs.
+func parseStatusFields(content string) map[string]string {
+	fields := make(map[string]string)
+	for _, line := range strings.Split(content, "\n") {
+		if key, value, ok := strings.Cut(line, ":"); ok {
+			fields[key] = strings.TrimSpace(value)
+		}
+	}
+	return fields
+}
+
 var (
 	totalMemOnce  sync.Once
 	totalMemBytes uint64
@@ -320,12 +334,33 @@ func totalMemoryBytes() uint64 {
 	return totalMemBytes
 }
 
-func isBinaryDeleted(pid int) bool {
+// ReadEnvironment returns pid's environment as KEY=VALUE entries, or an
+// empty list when /proc/<pid>/environ is not readable (another user's
+// process when not running as root).
+func ReadEnvironment(pid int) []string {
+	env := []string{}
+	envBytes, err := os.ReadFile(fmt.Sprintf("/proc/%d/environ", pid))
+	if err != nil {
+		return env
+	}
+	for _, e := range strings.Split(string(envBytes), "\x00") {
+		if e != "" {
+			env = append(env, e)
+		}
+	}
+	return env
+}
+
+// readExe returns the path of pid's executable and whether it was deleted
+// after the process started. The path is empty for kernel threads and for
+// processes whose exe link is not readable.
+func readExe(pid int) (path string, deleted bool) {
 	exePath, err := os.Readlink(fmt.Sprintf("/proc/%d/exe", pid))
 	if err != nil {
-		return false
+		return "", false
 	}
-	return strings.HasSuffix(exePath, " (deleted)")
+	path, deleted = strings.CutSuffix(exePath, " (deleted)")
+	return path, deleted
 }
 
 // The kernel emits the state immediately after the command, so fields[0] always carries it.
@@ -10,6 +10,17 @@ import (
 	"github.com/pranshuparmar/witr/pkg/model"
 )
 
+// ReadEnvironment returns pid's environment as KEY=VALUE entries, read
+// from its PEB, or an empty list when the process cannot be opened for
+// reading.
+func ReadEnvironment(pid int) []string {
+	info, err := GetProcessDetailedInfo(pid)
+	if err != nil || info.Env == nil {
+		return []string{}
+	}
+	return info.Env
+}
+
 func ReadProcess(pid int) (model.Process, error) {
 	// PID 0 is the System Idle Process on Windows (and negative PIDs are never
 	// valid), so reject them rather than returning the idle pseudo-process —
@@ -0,0 +1,119 @@
+//go:build linux
+
+package proc
+
+import (
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/pranshuparmar/witr/pkg/model"
+)
+
+// ScanRemoteConnections finds the processes with a TCP or UDP socket
+// connected to the endpoint q describes. A socket shared by several
+// processes (after a fork) is listed under each of them.
+func ScanRemoteConnections(q model.RemoteQuery) (model.RemoteConnections, error) {
+	result := model.RemoteConnections{Query: q.String()}
+	sockets, err := readSockets()
+	if err != nil {
+		return result, err
+	}
+
+	matched := selectRemoteSockets(sockets, q)
+	if _, ok := matched["0"]; ok {
+		// TIME_WAIT and other orphaned sockets have no inode and no owner.
+		result.RecentlyClosed = true
+		delete(matched, "0")
+	}
+	if len(matched) == 0 {
+		return result, nil
+	}
+
+	held := make(map[string]bool)
+	byPID := socketHolders(matched)
+	for pid, inodes := range byPID {
+		c := model.RemoteConnector{PID: pid}
+		for _, inode := range inodes {
+			c.Sockets = append(c.Sockets, matched[inode])
+			held[inode] = true
+		}
+		sortRemoteSockets(c.Sockets)
+		result.Processes = append(result.Processes, c)
+	}
+	sort.Slice(result.Processes, func(i, j int) bool {
+		return result.Processes[i].PID < result.Processes[j].PID
+	})
+	result.Unowned = len(matched) - len(held)
+	return result, nil
+}
+
+// selectRemoteSockets returns the connected sockets whose remote end q
+// matches, keyed by inode.
+func selectRemoteSockets(sockets map[string]model.Socket, q model.RemoteQuery) map[string]model.Socket {
+	matched := make(map[string]model.Socket)
+	for inode, s := range sockets {
+		if s.RemotePort == 0 || s.State == "LISTEN" {
+			continue
+		}
+		if q.Matches(s.RemoteAddress, s.RemotePort) {
+			matched[inode] = s
+		}
+	}
+	return matched
+}
+
+// socketHolders maps every PID holding an fd on one of inodes to the inodes
+// it holds.
+func socketHolders(inodes map[string]model.Socket) map[int][]string {
+	holders := make(map[int][]string)
+	entries, err := os.ReadDir("/proc")
+	if err != nil {
+		return holders
+	}
+	for _, entry := range entries {
+		pid, err := strconv.Atoi(entry.Name())
+		if err != nil {
+			continue
+		}
+		fdDir := filepath.Join("/proc", entry.Name(), "fd")
+		fds, err := os.ReadDir(fdDir)
+		if err != nil {
+			continue
+		}
+		seen := make(map[string]bool)
+		for _, fd := range fds {
+			link, err := os.Readlink(filepath.Join(fdDir, fd.Name()))
+			if err != nil {
+				continue
+			}
+			rest, ok := strings.CutPrefix(link, "socket:[")
+			if !ok {
+				continue
+			}
+			inode := strings.TrimSuffix(rest, "]")
+			if _, want := inodes[inode]; want && !seen[inode] {
+				seen[inode] = true
+				holders[pid] = append(holders[pid], inode)
+			}
+		}
+	}
+	return holders
+}
+
+// sortRemoteSockets orders a process's connections by remote endpoint, then
+// local port.
+func sortRemoteSockets(sockets []model.Socket) {
+	sort.SliceStable(sockets, func(i, j int) bool {
+		a, b := sockets[i], sockets[j]
+		if a.RemoteAddress != b.RemoteAddress {
+			return a.RemoteAddress < b.RemoteAddress
+		}
+		if a.RemotePort != b.RemotePort {
+			return a.RemotePort < b.RemotePort
+		}
+		return a.Port < b.Port
+	})
+}
@@ -0,0 +1,80 @@
+//go:build linux
+
+package proc
+
+import (
+	"net"
+	"net/netip"
+	"os"
+	"testing"
+
+	"github.com/pranshuparmar/witr/pkg/model"
+)
+
+func TestSelectRemoteSockets(t *testing.T) {
+	sockets := map[string]model.Socket{
+		"1": {Inode: "1", Protocol: "TCP", Address: "0.0.0.0", Port: 5432, State: "LISTEN"},
+		"2": {Inode: "2", Protocol: "TCP", Address: "10.0.0.2", Port: 41000, State: "ESTABLISHED", RemoteAddress: "10.0.4.12", RemotePort: 5432},
+		"3": {Inode: "3", Protocol: "TCP6", Address: "::ffff:10.0.0.2", Port: 41001, State: "SYN_SENT", RemoteAddress: "::ffff:10.0.4.12", RemotePort: 5432},
+		"4": {Inode: "4", Protocol: "UDP", Address: "10.0.0.2", Port: 50000, State: "ESTABLISHED", RemoteAddress: "10.0.4.12", RemotePort: 53},
+		"5": {Inode: "5", Protocol: "TCP", Address: "10.0.0.2", Port: 41002, State: "ESTABLISHED", RemoteAddress: "10.0.4.13", RemotePort: 5432},
+		"0": {Inode: "0", Protocol: "TCP", Address: "10.0.0.2", Port: 40999, State: "TIME_WAIT", RemoteAddress: "10.0.4.12", RemotePort: 5432},
+	}
+	host := netip.MustParsePrefix("10.0.4.12/32")
+
+	tests := []struct {
+		name string
+		q    model.RemoteQuery
+		want []string
+	}{
+		{"host and port", model.RemoteQuery{Prefixes: []netip.Prefix{host}, Port: 5432}, []string{"0", "2", "3"}},
+		{"any port", model.RemoteQuery{Prefixes: []netip.Prefix{host}}, []string{"0", "2", "3", "4"}},
+		{"subnet", model.RemoteQuery{Prefixes: []netip.Prefix{netip.MustParsePrefix("10.0.4.0/24")}, Port: 5432}, []string{"0", "2", "3", "5"}},
+		{"nothing", model.RemoteQuery{Prefixes: []netip.Prefix{netip.MustParsePrefix("192.0.2.1/32")}}, nil},
+	}
+	for _, tt := range tests {
+		got := selectRemoteSockets(sockets, tt.q)
+		if len(got) != len(tt.want) {
+			t.Errorf("%s: selected %v, want inodes %v", tt.name, got, tt.want)
+			continue
+		}
+		for _, inode := range tt.want {
+			if _, ok := got[inode]; !ok {
+				t.Errorf("%s: missing inode %s", tt.name, inode)
+			}
+		}
+	}
+}
+
+func TestScanRemoteConnectionsFindsOwnConnection(t *testing.T) {
+	ln, err := net.Listen("tcp4", "127.0.0.1:0")
+	if err != nil {
+		t.Skipf("cannot listen on loopback: %v", err)
+	}
+	defer ln.Close()
+	conn, err := net.Dial("tcp4", ln.Addr().String())
+	if err != nil {
+		t.Fatalf("dial: %v", err)
+	}
+	defer conn.Close()
+	port := ln.Addr().(*net.TCPAddr).Port
+	local := conn.LocalAddr().(*net.TCPAddr).Port
+
+	q := model.RemoteQuery{Host: "127.0.0.1", Prefixes: []netip.Prefix{netip.MustParsePrefix("127.0.0.1/32")}, Port: port}
+	conns, err := ScanRemoteConnections(q)
+	if err != nil {
+		t.Fatalf("ScanRemoteConnections: %v", err)
+	}
+	for _, c := range conns.Processes {
+		if c.PID != os.Getpid() {
+			continue
+		}
+		for _, s := range c.Sockets {
+			if s.Port == local && s.RemotePort == port {
+				return
+			}
+		}
+		t.Fatalf("own process found without the connection from port %d: %+v", local, c.Sockets)
+	}
+	t.Fatalf("own process not among connectors to %s: %+v", q, conns)
+}
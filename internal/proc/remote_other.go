@@ -0,0 +1,16 @@
+//go:build !linux
+
+package proc
+
+import (
+	"fmt"
+	"runtime"
+
+	"github.com/pranshuparmar/witr/pkg/model"
+)
+
+// ScanRemoteConnections is only supported on Linux, where every socket's
+// remote end and owning descriptors can be read without extra tools.
+func ScanRemoteConnections(q model.RemoteQuery) (model.RemoteConnections, error) {
+	return model.RemoteConnections{Query: q.String()}, fmt.Errorf("remote endpoint lookup is not supported on %s", runtime.GOOS)
+}
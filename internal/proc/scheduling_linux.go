@@ -0,0 +1,253 @@
+//go:build linux
+
+package proc
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/pranshuparmar/witr/pkg/model"
+	"golang.org/x/sys/unix"
+)
+
+var schedPolicies = map[int]string{
+	0: "SCHED_OTHER",
+	1: "SCHED_FIFO",
+	2: "SCHED_RR",
+	3: "SCHED_BATCH",
+	5: "SCHED_IDLE",
+	6: "SCHED_DEADLINE",
+}
+
+// I/O priority encoding (linux/ioprio.h): the class in the top bits, the
+// level in the low ones.
+const (
+	ioprioWhoProcess = 1
+	ioprioClassShift = 13
+	ioprioLevelMask  = 0xff
+)
+
+var ioprioClasses = map[int]string{
+	1: "realtime",
+	2: "best-effort",
+	3: "idle",
+}
+
+// ReadScheduling returns p's scheduling policy and priorities, CPU and
+// NUMA placement, I/O priority and OOM score, or nil if its stat file is
+// unreadable. Fields whose source is unreadable are left empty.
+func ReadScheduling(p model.Process) *model.SchedulingInfo {
+	pid := p.PID
+	data, err := os.ReadFile(fmt.Sprintf("/proc/%d/stat", pid))
+	if err != nil {
+		return nil
+	}
+	s, ok := parseSchedStat(string(data))
+	if !ok {
+		return nil
+	}
+
+	s.CPUAffinity = p.Status["Cpus_allowed_list"]
+	s.MemoryNodes = p.Status["Mems_allowed_list"]
+	s.NUMAPolicy = readNUMAPolicy(pid)
+
+	if v, _, errno := unix.Syscall(unix.SYS_IOPRIO_GET, ioprioWhoProcess, uintptr(pid), 0); errno == 0 {
+		s.IOClass, s.IOPriority, s.IOPriorityFromNice = decodeIOPriority(int(v), s.Policy, s.Nice)
+	}
+
+	s.OOMScore, _ = readIntFile(fmt.Sprintf("/proc/%d/oom_score", pid))
+	s.OOMScoreAdj, _ = readIntFile(fmt.Sprintf("/proc/%d/oom_score_adj", pid))
+	return s
+}
+
+// parseSchedStat extracts the policy, real-time priority and nice value
+// from a stat line. Fields are counted from the last ')', as the command
+// name may contain spaces.
+func parseSchedStat(stat string) (*model.SchedulingInfo, bool) {
+	closeParen := strings.LastIndex(stat, ")")
+	if closeParen == -1 {
+		return nil, false
+	}
+	// fields[0] is stat field 3 (state), so field N is fields[N-3].
+	fields := strings.Fields(stat[closeParen+1:])
+	if len(fields) < 39 {
+		return nil, false
+	}
+	nice, _ := strconv.Atoi(fields[16])
+	rtPriority, _ := strconv.Atoi(fields[37])
+	policy, _ := strconv.Atoi(fields[38])
+
+	s := &model.SchedulingInfo{Policy: schedPolicies[policy], Nice: nice}
+	if s.Policy == "" {
+		s.Policy = fmt.Sprintf("policy %d", policy)
+	}
+	if policy == 1 || policy == 2 {
+		s.RTPriority = rtPriority
+	}
+	return s, true
+}
+
+// decodeIOPriority splits an ioprio_get result into class and level. With
+// no class set the kernel schedules I/O in the class matching the CPU
+// policy, at level (nice + 20) / 5.
+func decodeIOPriority(ioprio int, policy string, nice int) (class string, level int, fromNice bool) {
+	if class, ok := ioprioClasses[ioprio>>ioprioClassShift]; ok {
+		return class, ioprio & ioprioLevelMask, false
+	}
+	switch policy {
+	case "SCHED_FIFO", "SCHED_RR":
+		class = "realtime"
+	case "SCHED_IDLE":
+		return "idle", 7, true
+	default:
+		class = "best-effort"
+	}
+	return class, (nice + 20) / 5, true
+}
+
+// readNUMAPolicy returns the memory policy from the first mapping in
+// numa_maps. Mappings without a policy of their own show the task's, so
+// this is the process-wide policy unless mbind() changed that mapping. Only
+// the first line is read: the kernel walks page tables for every line it
+// formats, which is slow for large processes.
+func readNUMAPolicy(pid int) string {
+	f, err := os.Open(fmt.Sprintf("/proc/%d/numa_maps", pid))
+	if err != nil {
+		return ""
+	}
+	defer f.Close()
+	line, err := bufio.NewReader(f).ReadString('\n')
+	if err != nil && err != io.EOF {
+		return ""
+	}
+	fields := strings.Fields(line)
+	if len(fields) < 2 {
+		return ""
+	}
+	return fields[1]
+}
+
+func readIntFile(path string) (int, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return 0, err
+	}
+	return strconv.Atoi(strings.TrimSpace(string(data)))
+}
+
+// ReadLimits returns p's resource limits from /proc/<pid>/limits, with the
+// current usage of those witr can measure: open files, CPU time, pending
+// signals and the memory sizes from status. It returns nil if the limits
+// are unreadable.
+func ReadLimits(p model.Process) []model.ResourceLimit {
+	pid := p.PID
+	data, err := os.ReadFile(fmt.Sprintf("/proc/%d/limits", pid))
+	if err != nil {
+		return nil
+	}
+	limits := parseLimits(string(data))
+
+	usage := make(map[string]uint64)
+	if entries, err := os.ReadDir(fmt.Sprintf("/proc/%d/fd", pid)); err == nil {
+		usage["Max open files"] = uint64(len(entries))
+	}
+	for name, key := range map[string]string{
+		"Max address space": "VmSize",
+		"Max data size":     "VmData",
+		"Max stack size":    "VmStk",
+		"Max locked memory": "VmLck",
+		"Max resident set":  "VmRSS",
+	} {
+		if kb, ok := parseKB(p.Status[key]); ok {
+			usage[name] = kb * 1024
+		}
+	}
+	// SigQ is "queued/limit" for the real UID.
+	if queued, _, ok := strings.Cut(p.Status["SigQ"], "/"); ok {
+		if n, err := strconv.ParseUint(queued, 10, 64); err == nil {
+			usage["Max pending signals"] = n
+		}
+	}
+	if data, err := os.ReadFile(fmt.Sprintf("/proc/%d/stat", pid)); err == nil {
+		if secs, ok := cpuSeconds(string(data)); ok {
+			usage["Max cpu time"] = secs
+		}
+	}
+
+	for i := range limits {
+		if v, ok := usage[limits[i].Name]; ok {
+			limits[i].Usage = &v
+		}
+	}
+	return limits
+}
+
+// parseLimits reads the /proc/<pid>/limits table. Its columns are padded to
+// fixed widths and the names contain spaces, so rows are cut at the offsets
+// of the header's column titles.
+func parseLimits(content string) []model.ResourceLimit {
+	lines := strings.Split(content, "\n")
+	if len(lines) == 0 {
+		return nil
+	}
+	header := lines[0]
+	softAt := strings.Index(header, "Soft Limit")
+	hardAt := strings.Index(header, "Hard Limit")
+	unitsAt := strings.Index(header, "Units")
+	if softAt <= 0 || hardAt <= softAt || unitsAt <= hardAt {
+		return nil
+	}
+	column := func(line string, from, to int) string {
+		if from >= len(line) {
+			return ""
+		}
+		if to > len(line) || to < 0 {
+			to = len(line)
+		}
+		return strings.TrimSpace(line[from:to])
+	}
+
+	var limits []model.ResourceLimit
+	for _, line := range lines[1:] {
+		name := column(line, 0, softAt)
+		if name == "" {
+			continue
+		}
+		limits = append(limits, model.ResourceLimit{
+			Name: name,
+			Soft: column(line, softAt, hardAt),
+			Hard: column(line, hardAt, unitsAt),
+			Unit: column(line, unitsAt, -1),
+		})
+	}
+	return limits
+}
+
+// parseKB parses a status value such as "123456 kB".
+func parseKB(value string) (uint64, bool) {
+	n, err := strconv.ParseUint(strings.TrimSpace(strings.TrimSuffix(value, "kB")), 10, 64)
+	return n, err == nil
+}
+
+// cpuSeconds returns the user plus system CPU time from a stat line, in
+// whole seconds as RLIMIT_CPU counts it.
+func cpuSeconds(stat string) (uint64, bool) {
+	closeParen := strings.LastIndex(stat, ")")
+	if closeParen == -1 {
+		return 0, false
+	}
+	fields := strings.Fields(stat[closeParen+1:])
+	if len(fields) < 13 {
+		return 0, false
+	}
+	utime, err1 := strconv.ParseUint(fields[11], 10, 64)
+	stime, err2 := strconv.ParseUint(fields[12], 10, 64)
+	if err1 != nil || err2 != nil {
+		return 0, false
+	}
+	return (utime + stime) / uint64(ticksPerSecond()), true
+}
@@ -0,0 +1,118 @@
+//go:build linux
+
+package proc
+
+import (
+	"os"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/pranshuparmar/witr/pkg/model"
+)
+
+func TestParseLimits(t *testing.T) {
+	t.Parallel()
+
+	content := `Limit                     Soft Limit           Hard Limit           Units     
+Max cpu time              unlimited            unlimited            seconds   
+Max open files            1024                 524288               files     
+Max nice priority         0                    0                    
+`
+	want := []model.ResourceLimit{
+		{Name: "Max cpu time", Soft: "unlimited", Hard: "unlimited", Unit: "seconds"},
+		{Name: "Max open files", Soft: "1024", Hard: "524288", Unit: "files"},
+		{Name: "Max nice priority", Soft: "0", Hard: "0"},
+	}
+	if got := parseLimits(content); !reflect.DeepEqual(got, want) {
+		t.Errorf("parseLimits =\n%+v\nwant\n%+v", got, want)
+	}
+	if got := parseLimits("garbage\n"); got != nil {
+		t.Errorf("parseLimits without a header = %+v, want nil", got)
+	}
+}
+
+func TestParseSchedStat(t *testing.T) {
+	t.Parallel()
+
+	// fields[N-3] is stat field N: nice is field 19, rt_priority 40 and
+	// policy 41.
+	fields := make([]string, 42)
+	for i := range fields {
+		fields[i] = "0"
+	}
+	fields[0] = "S"
+	fields[19-3], fields[40-3], fields[41-3] = "-5", "50", "1"
+	stat := "812 (rt worker) " + strings.Join(fields, " ")
+	s, ok := parseSchedStat(stat)
+	if !ok {
+		t.Fatal("parseSchedStat rejected a valid line")
+	}
+	want := &model.SchedulingInfo{Policy: "SCHED_FIFO", RTPriority: 50, Nice: -5}
+	if !reflect.DeepEqual(s, want) {
+		t.Errorf("parseSchedStat = %+v, want %+v", s, want)
+	}
+	if _, ok := parseSchedStat("812 (short) S 1"); ok {
+		t.Error("short stat line should be rejected")
+	}
+}
+
+func TestDecodeIOPriority(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		ioprio   int
+		policy   string
+		nice     int
+		class    string
+		level    int
+		fromNice bool
+	}{
+		{2<<13 | 7, "SCHED_OTHER", 0, "best-effort", 7, false},
+		{1<<13 | 0, "SCHED_OTHER", 0, "realtime", 0, false},
+		{3 << 13, "SCHED_OTHER", 0, "idle", 0, false},
+		{0, "SCHED_OTHER", 0, "best-effort", 4, true},
+		{0, "SCHED_OTHER", -20, "best-effort", 0, true},
+		{0, "SCHED_OTHER", 19, "best-effort", 7, true},
+		{0, "SCHED_RR", 0, "realtime", 4, true},
+		{0, "SCHED_IDLE", 0, "idle", 7, true},
+	}
+	for _, tt := range tests {
+		class, level, fromNice := decodeIOPriority(tt.ioprio, tt.policy, tt.nice)
+		if class != tt.class || level != tt.level || fromNice != tt.fromNice {
+			t.Errorf("decodeIOPriority(%#x, %s, %d) = %s %d %v; want %s %d %v",
+				tt.ioprio, tt.policy, tt.nice, class, level, fromNice, tt.class, tt.level, tt.fromNice)
+		}
+	}
+}
+
+func TestReadSchedulingAndLimitsSelf(t *testing.T) {
+	t.Parallel()
+
+	self, err := ReadProcess(os.Getpid())
+	if err != nil {
+		t.Fatalf("ReadProcess(self): %v", err)
+	}
+	s := ReadScheduling(self)
+	if s == nil {
+		t.Fatal("ReadScheduling(self) = nil")
+	}
+	if s.Policy != "SCHED_OTHER" && s.Policy != "SCHED_BATCH" && s.Policy != "SCHED_IDLE" {
+		t.Errorf("unexpected policy for the test process: %+v", s)
+	}
+	if s.CPUAffinity == "" || s.IOClass == "" {
+		t.Errorf("missing affinity or I/O class: %+v", s)
+	}
+
+	limits := ReadLimits(self)
+	for _, l := range limits {
+		if l.Name != "Max open files" {
+			continue
+		}
+		if l.Usage == nil || *l.Usage == 0 {
+			t.Errorf("open files usage not measured: %+v", l)
+		}
+		return
+	}
+	t.Errorf("ReadLimits(self) has no open files row: %+v", limits)
+}
@@ -0,0 +1,16 @@
+//go:build !linux
+
+package proc
+
+import "github.com/pranshuparmar/witr/pkg/model"
+
+// ReadScheduling returns nil on non-Linux platforms.
+func ReadScheduling(p model.Process) *model.SchedulingInfo {
+	return nil
+}
+
+// ReadLimits returns nil on non-Linux platforms, which have no
+// /proc/<pid>/limits.
+func ReadLimits(p model.Process) []model.ResourceLimit {
+	return nil
+}
@@ -0,0 +1,146 @@
+//go:build linux
+
+package proc
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/pranshuparmar/witr/pkg/model"
+)
+
+// ReadSecurityContext returns the credentials, seccomp/no_new_privs state,
+// LSM label and bounding/ambient capabilities of p, or nil if its status
+// couldn't be read by ReadProcess.
+func ReadSecurityContext(p model.Process) *model.SecurityContext {
+	if len(p.Status) == 0 {
+		return nil
+	}
+	pid := p.PID
+	sc := parseSecurityStatus(p.Status)
+	resolveCredentialNames(sc)
+	sc.LSM, sc.LSMLabel = readLSMLabel(pid)
+	// Stat follows the exe link to the binary itself; it fails for other
+	// users' processes without privilege, leaving both bits unset.
+	if fi, err := os.Stat(fmt.Sprintf("/proc/%d/exe", pid)); err == nil {
+		sc.SetUIDExe = fi.Mode()&os.ModeSetuid != 0
+		sc.SetGIDExe = fi.Mode()&os.ModeSetgid != 0
+	}
+	return sc
+}
+
+// parseSecurityStatus extracts the security-relevant fields of
+// /proc/<pid>/status. Names are left unresolved.
+func parseSecurityStatus(status map[string]string) *model.SecurityContext {
+	sc := &model.SecurityContext{SeccompMode: "disabled"}
+	for key, value := range status {
+		switch key {
+		case "Uid":
+			ids := parseIDList(value)
+			if len(ids) == 4 {
+				sc.RealUID, sc.EffectiveUID, sc.SavedUID, sc.FSUID = ids[0], ids[1], ids[2], ids[3]
+			}
+		case "Gid":
+			ids := parseIDList(value)
+			if len(ids) == 4 {
+				sc.RealGID, sc.EffectiveGID, sc.SavedGID, sc.FSGID = ids[0], ids[1], ids[2], ids[3]
+			}
+		case "Groups":
+			sc.Groups = parseIDList(value)
+		case "NoNewPrivs":
+			sc.NoNewPrivs = value == "1"
+		case "Seccomp":
+			switch value {
+			case "1":
+				sc.SeccompMode = "strict"
+			case "2":
+				sc.SeccompMode = "filter"
+			}
+		case "Seccomp_filters":
+			sc.SeccompFilters, _ = strconv.Atoi(value)
+		case "CapBnd":
+			sc.CapBounding = decodeCapabilities(value)
+			sc.CapBoundingDropped = missingCapabilities(sc.CapBounding)
+		case "CapAmb":
+			sc.CapAmbient = decodeCapabilities(value)
+		}
+	}
+	return sc
+}
+
+// missingCapabilities returns the known capabilities absent from have, in
+// bit order.
+func missingCapabilities(have []string) []string {
+	present := make(map[string]bool, len(have))
+	for _, c := range have {
+		present[c] = true
+	}
+	var missing []string
+	for bit := 0; bit < 64; bit++ {
+		if name, ok := capNames[bit]; ok && !present[name] {
+			missing = append(missing, name)
+		}
+	}
+	return missing
+}
+
+func parseIDList(value string) []model.Credential {
+	var ids []model.Credential
+	for _, f := range strings.Fields(value) {
+		if id, err := strconv.Atoi(f); err == nil {
+			ids = append(ids, model.Credential{ID: id})
+		}
+	}
+	return ids
+}
+
+func resolveCredentialNames(sc *model.SecurityContext) {
+	for _, c := range []*model.Credential{&sc.RealUID, &sc.EffectiveUID, &sc.SavedUID, &sc.FSUID} {
+		if name := userName(c.ID); name != strconv.Itoa(c.ID) {
+			c.Name = name
+		}
+	}
+	for _, c := range []*model.Credential{&sc.RealGID, &sc.EffectiveGID, &sc.SavedGID, &sc.FSGID} {
+		c.Name = groupName(c.ID)
+	}
+	for i := range sc.Groups {
+		sc.Groups[i].Name = groupName(sc.Groups[i].ID)
+	}
+}
+
+// readLSMLabel returns the active LSM and the process's label under it.
+// Kernels with LSM stacking expose a per-module attr directory; older ones
+// only have the shared attr/current, whose owner is inferred from which LSM
+// filesystem is mounted.
+func readLSMLabel(pid int) (string, string) {
+	if label, ok := readAttr(fmt.Sprintf("/proc/%d/attr/apparmor/current", pid)); ok {
+		return "apparmor", label
+	}
+	label, ok := readAttr(fmt.Sprintf("/proc/%d/attr/current", pid))
+	if !ok {
+		return "", ""
+	}
+	switch {
+	case fileExists("/sys/fs/selinux/enforce"):
+		return "selinux", label
+	case fileExists("/sys/kernel/security/apparmor"), fileExists("/sys/module/apparmor"):
+		return "apparmor", label
+	}
+	return "", ""
+}
+
+func readAttr(path string) (string, bool) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return "", false
+	}
+	label := strings.TrimSpace(strings.TrimRight(string(data), "\x00"))
+	return label, label != ""
+}
+
+func fileExists(path string) bool {
+	_, err := os.Stat(path)
+	return err == nil
+}
@@ -0,0 +1,65 @@
+//go:build linux
+
+package proc
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseSecurityStatus(t *testing.T) {
+	t.Parallel()
+
+	status := "Name:\tpasswd\n" +
+		"Uid:\t1000\t0\t0\t0\n" +
+		"Gid:\t1000\t1000\t1000\t1000\n" +
+		"Groups:\t4 27 1000 \n" +
+		"CapBnd:\t0000000000000c00\n" +
+		"CapAmb:\t0000000000000400\n" +
+		"NoNewPrivs:\t1\n" +
+		"Seccomp:\t2\n" +
+		"Seccomp_filters:\t3\n"
+
+	sc := parseSecurityStatus(parseStatusFields(status))
+	if sc.RealUID.ID != 1000 || sc.EffectiveUID.ID != 0 || sc.SavedUID.ID != 0 || sc.FSUID.ID != 0 {
+		t.Errorf("unexpected UIDs: %+v %+v %+v %+v", sc.RealUID, sc.EffectiveUID, sc.SavedUID, sc.FSUID)
+	}
+	if sc.RealGID.ID != 1000 || sc.EffectiveGID.ID != 1000 {
+		t.Errorf("unexpected GIDs: %+v %+v", sc.RealGID, sc.EffectiveGID)
+	}
+	var groups []int
+	for _, g := range sc.Groups {
+		groups = append(groups, g.ID)
+	}
+	if !reflect.DeepEqual(groups, []int{4, 27, 1000}) {
+		t.Errorf("Groups = %v", groups)
+	}
+	if !sc.NoNewPrivs {
+		t.Error("expected NoNewPrivs")
+	}
+	if sc.SeccompMode != "filter" || sc.SeccompFilters != 3 {
+		t.Errorf("seccomp = %q (%d filters)", sc.SeccompMode, sc.SeccompFilters)
+	}
+	if !reflect.DeepEqual(sc.CapBounding, []string{"CAP_NET_BIND_SERVICE", "CAP_NET_BROADCAST"}) {
+		t.Errorf("CapBounding = %v", sc.CapBounding)
+	}
+	if len(sc.CapBoundingDropped) != len(capNames)-2 {
+		t.Errorf("expected %d dropped capabilities, got %d", len(capNames)-2, len(sc.CapBoundingDropped))
+	}
+	if !reflect.DeepEqual(sc.CapAmbient, []string{"CAP_NET_BIND_SERVICE"}) {
+		t.Errorf("CapAmbient = %v", sc.CapAmbient)
+	}
+}
+
+func TestParseSecurityStatusDefaults(t *testing.T) {
+	t.Parallel()
+
+	// Kernels without seccomp report no Seccomp line at all.
+	sc := parseSecurityStatus(parseStatusFields("Uid:\t0\t0\t0\t0\nGid:\t0\t0\t0\t0\n"))
+	if sc.SeccompMode != "disabled" || sc.NoNewPrivs {
+		t.Errorf("unexpected defaults: %+v", sc)
+	}
+	if len(sc.Groups) != 0 {
+		t.Errorf("expected no groups, got %v", sc.Groups)
+	}
+}
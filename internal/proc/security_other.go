@@ -0,0 +1,11 @@
+//go:build !linux
+
+package proc
+
+import "github.com/pranshuparmar/witr/pkg/model"
+
+// ReadSecurityContext returns nil on non-Linux platforms; the credential,
+// seccomp and LSM details it reports come from Linux's /proc.
+func ReadSecurityContext(p model.Process) *model.SecurityContext {
+	return nil
+}
@@ -0,0 +1,110 @@
+//go:build linux
+
+package proc
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+
+	"golang.org/x/sys/unix"
+)
+
+// sockDiagTimeout bounds each receive on the sock_diag socket so a kernel
+// that never answers can't hang an analysis.
+var sockDiagTimeout = unix.Timeval{Sec: 2}
+
+// sockDiagDump sends a SOCK_DIAG_BY_FAMILY dump request over a
+// NETLINK_SOCK_DIAG socket and calls fn with the payload of every reply. req
+// is the family-specific request (unix_diag_req, inet_diag_req_v2) encoded in
+// host byte order.
+func sockDiagDump(req []byte, fn func(payload []byte)) error {
+	fd, err := unix.Socket(unix.AF_NETLINK, unix.SOCK_DGRAM|unix.SOCK_CLOEXEC, unix.NETLINK_SOCK_DIAG)
+	if err != nil {
+		return err
+	}
+	defer unix.Close(fd)
+	_ = unix.SetsockoptTimeval(fd, unix.SOL_SOCKET, unix.SO_RCVTIMEO, &sockDiagTimeout)
+
+	msg := make([]byte, unix.NLMSG_HDRLEN, unix.NLMSG_HDRLEN+len(req))
+	binary.NativeEndian.PutUint32(msg[0:4], uint32(unix.NLMSG_HDRLEN+len(req)))
+	binary.NativeEndian.PutUint16(msg[4:6], unix.SOCK_DIAG_BY_FAMILY)
+	binary.NativeEndian.PutUint16(msg[6:8], unix.NLM_F_REQUEST|unix.NLM_F_DUMP)
+	binary.NativeEndian.PutUint32(msg[8:12], 1) // sequence number
+	msg = append(msg, req...)
+	if err := unix.Sendto(fd, msg, 0, &unix.SockaddrNetlink{Family: unix.AF_NETLINK}); err != nil {
+		return err
+	}
+
+	buf := make([]byte, 64*1024)
+	for {
+		n, _, err := unix.Recvfrom(fd, buf, 0)
+		if err == unix.EINTR {
+			continue
+		}
+		if err != nil {
+			return err
+		}
+		done, err := parseNetlinkReplies(buf[:n], fn)
+		if err != nil || done {
+			return err
+		}
+	}
+}
+
+// parseNetlinkReplies walks the netlink messages in one datagram, handing
+// data payloads to fn. done reports that NLMSG_DONE or an error ended the
+// dump.
+func parseNetlinkReplies(b []byte, fn func(payload []byte)) (done bool, err error) {
+	for len(b) >= unix.NLMSG_HDRLEN {
+		length := int(binary.NativeEndian.Uint32(b[0:4]))
+		msgType := binary.NativeEndian.Uint16(b[4:6])
+		if length < unix.NLMSG_HDRLEN || length > len(b) {
+			return true, errors.New("sock_diag: truncated netlink message")
+		}
+		payload := b[unix.NLMSG_HDRLEN:length]
+		switch msgType {
+		case unix.NLMSG_DONE:
+			return true, nil
+		case unix.NLMSG_ERROR:
+			if len(payload) >= 4 {
+				if errno := int32(binary.NativeEndian.Uint32(payload[0:4])); errno != 0 {
+					return true, fmt.Errorf("sock_diag: %w", unix.Errno(-errno))
+				}
+			}
+			return true, nil
+		default:
+			fn(payload)
+		}
+		next := netlinkAlign(length)
+		if next >= len(b) {
+			break
+		}
+		b = b[next:]
+	}
+	return false, nil
+}
+
+// netlinkAttrs splits a run of netlink attributes (struct rtattr / nlattr:
+// 16-bit length, 16-bit type, 4-byte aligned) into a type → value map.
+func netlinkAttrs(b []byte) map[uint16][]byte {
+	attrs := make(map[uint16][]byte)
+	for len(b) >= 4 {
+		length := int(binary.NativeEndian.Uint16(b[0:2]))
+		attrType := binary.NativeEndian.Uint16(b[2:4])
+		if length < 4 || length > len(b) {
+			break
+		}
+		attrs[attrType] = b[4:length]
+		next := netlinkAlign(length)
+		if next >= len(b) {
+			break
+		}
+		b = b[next:]
+	}
+	return attrs
+}
+
+func netlinkAlign(n int) int {
+	return (n + unix.NLMSG_ALIGNTO - 1) &^ (unix.NLMSG_ALIGNTO - 1)
+}
@@ -60,11 +60,16 @@ func GetSocketStates(port int) ([]model.SocketInfo, error) {
 	return sockets, nil
 }
 
-// GetSocketStateForPort returns the most relevant socket state for a port
+// GetSocketStateForPort returns the most relevant socket state for a port on
+// address, or on any address when it is empty or a wildcard.
 // Prioritizes non-LISTEN states that explain why a port might be unavailable
-func GetSocketStateForPort(port int) *model.SocketInfo {
+func GetSocketStateForPort(port int, address string) *model.SocketInfo {
 	states, err := GetSocketStates(port)
-	if err != nil || len(states) == 0 {
+	if err != nil {
+		return nil
+	}
+	states = socketStatesOn(states, address)
+	if len(states) == 0 {
 		return nil
 	}
 
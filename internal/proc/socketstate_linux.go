@@ -12,9 +12,20 @@ import (
 	"github.com/pranshuparmar/witr/pkg/model"
 )
 
-// GetSocketStateForPort returns the socket state for a port
-// Linux implementation using /proc/net/tcp and /proc/net/tcp6
-func GetSocketStateForPort(port int) *model.SocketInfo {
+// GetSocketStateForPort returns the TCP socket state for a port on address,
+// or on any address when it is empty or a wildcard.
+// Linux implementation using sock_diag, or /proc/net/tcp and /proc/net/tcp6
+// when netlink is unavailable
+func GetSocketStateForPort(port int, address string) *model.SocketInfo {
+	states, err := socketStatesDiag(port)
+	if err != nil {
+		states = socketStatesText(port)
+	}
+	return pickSocketState(socketStatesOn(states, address))
+}
+
+// socketStatesText collects the TCP sockets on port from /proc/net/tcp{,6}.
+func socketStatesText(port int) []model.SocketInfo {
 	// Check both IPv4 and IPv6
 	files := []string{"/proc/net/tcp", "/proc/net/tcp6"}
 
@@ -65,7 +76,12 @@ func GetSocketStateForPort(port int) *model.SocketInfo {
 			}
 		}()
 	}
+	return states
+}
 
+// pickSocketState chooses the state to report when several sockets share the
+// port.
+func pickSocketState(states []model.SocketInfo) *model.SocketInfo {
 	if len(states) == 0 {
 		return nil
 	}
@@ -10,7 +10,9 @@ import (
 	"github.com/pranshuparmar/witr/pkg/model"
 )
 
-func GetSocketStateForPort(port int) *model.SocketInfo {
+// GetSocketStateForPort returns the most relevant socket state for a port on
+// address, or on any address when it is empty or a wildcard.
+func GetSocketStateForPort(port int, address string) *model.SocketInfo {
 	// netstat -ano
 	out, err := exec.Command("netstat", "-ano").Output()
 	if err != nil {
@@ -50,6 +52,7 @@ func GetSocketStateForPort(port int) *model.SocketInfo {
 		}
 	}
 
+	states = socketStatesOn(states, address)
 	if len(states) == 0 {
 		return nil
 	}
@@ -0,0 +1,80 @@
+//go:build linux && (amd64 || arm64)
+
+package proc
+
+import "golang.org/x/sys/unix"
+
+// syscallNames decodes the number in /proc/<pid>/syscall. It covers the
+// calls a process is likely to be found sleeping in, plus the common file
+// and process calls; anything else is shown by number.
+var syscallNames = map[uint64]string{
+	unix.SYS_READ:            "read",
+	unix.SYS_WRITE:           "write",
+	unix.SYS_READV:           "readv",
+	unix.SYS_WRITEV:          "writev",
+	unix.SYS_PREAD64:         "pread64",
+	unix.SYS_PWRITE64:        "pwrite64",
+	unix.SYS_PREADV:          "preadv",
+	unix.SYS_PWRITEV:         "pwritev",
+	unix.SYS_OPENAT:          "openat",
+	unix.SYS_CLOSE:           "close",
+	unix.SYS_IOCTL:           "ioctl",
+	unix.SYS_FCNTL:           "fcntl",
+	unix.SYS_FLOCK:           "flock",
+	unix.SYS_FSYNC:           "fsync",
+	unix.SYS_FDATASYNC:       "fdatasync",
+	unix.SYS_SYNC:            "sync",
+	unix.SYS_SYNCFS:          "syncfs",
+	unix.SYS_MSYNC:           "msync",
+	unix.SYS_PPOLL:           "ppoll",
+	unix.SYS_PSELECT6:        "pselect6",
+	unix.SYS_EPOLL_PWAIT:     "epoll_pwait",
+	unix.SYS_EPOLL_PWAIT2:    "epoll_pwait2",
+	unix.SYS_FUTEX:           "futex",
+	unix.SYS_NANOSLEEP:       "nanosleep",
+	unix.SYS_CLOCK_NANOSLEEP: "clock_nanosleep",
+	unix.SYS_WAIT4:           "wait4",
+	unix.SYS_WAITID:          "waitid",
+	unix.SYS_ACCEPT:          "accept",
+	unix.SYS_ACCEPT4:         "accept4",
+	unix.SYS_CONNECT:         "connect",
+	unix.SYS_RECVFROM:        "recvfrom",
+	unix.SYS_RECVMSG:         "recvmsg",
+	unix.SYS_RECVMMSG:        "recvmmsg",
+	unix.SYS_SENDTO:          "sendto",
+	unix.SYS_SENDMSG:         "sendmsg",
+	unix.SYS_SENDMMSG:        "sendmmsg",
+	unix.SYS_RT_SIGSUSPEND:   "rt_sigsuspend",
+	unix.SYS_RT_SIGTIMEDWAIT: "rt_sigtimedwait",
+	unix.SYS_IO_GETEVENTS:    "io_getevents",
+	unix.SYS_IO_PGETEVENTS:   "io_pgetevents",
+	unix.SYS_IO_URING_ENTER:  "io_uring_enter",
+	unix.SYS_SPLICE:          "splice",
+	unix.SYS_TEE:             "tee",
+	unix.SYS_SENDFILE:        "sendfile",
+	unix.SYS_NEWFSTATAT:      "newfstatat",
+	unix.SYS_FSTAT:           "fstat",
+	unix.SYS_STATX:           "statx",
+	unix.SYS_GETDENTS64:      "getdents64",
+	unix.SYS_UNLINKAT:        "unlinkat",
+	unix.SYS_RENAMEAT2:       "renameat2",
+	unix.SYS_MKDIRAT:         "mkdirat",
+	unix.SYS_LSEEK:           "lseek",
+	unix.SYS_FALLOCATE:       "fallocate",
+	unix.SYS_FTRUNCATE:       "ftruncate",
+	unix.SYS_TRUNCATE:        "truncate",
+	unix.SYS_MOUNT:           "mount",
+	unix.SYS_UMOUNT2:         "umount2",
+	unix.SYS_SEMOP:           "semop",
+	unix.SYS_SEMTIMEDOP:      "semtimedop",
+	unix.SYS_MSGRCV:          "msgrcv",
+	unix.SYS_MQ_TIMEDRECEIVE: "mq_timedreceive",
+	unix.SYS_MQ_TIMEDSEND:    "mq_timedsend",
+	unix.SYS_EXECVE:          "execve",
+	unix.SYS_FACCESSAT:       "faccessat",
+	unix.SYS_READLINKAT:      "readlinkat",
+	unix.SYS_MMAP:            "mmap",
+	unix.SYS_MUNMAP:          "munmap",
+	unix.SYS_EXIT_GROUP:      "exit_group",
+	unix.SYS_CLONE:           "clone",
+}
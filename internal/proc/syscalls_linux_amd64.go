@@ -0,0 +1,29 @@
+//go:build linux
+
+package proc
+
+import "golang.org/x/sys/unix"
+
+// The legacy x86-64 calls that the generic syscall table on arm64 dropped in
+// favour of their *at, ppoll and epoll_pwait forms.
+func init() {
+	for nr, name := range map[uint64]string{
+		unix.SYS_OPEN:       "open",
+		unix.SYS_CREAT:      "creat",
+		unix.SYS_STAT:       "stat",
+		unix.SYS_LSTAT:      "lstat",
+		unix.SYS_POLL:       "poll",
+		unix.SYS_SELECT:     "select",
+		unix.SYS_EPOLL_WAIT: "epoll_wait",
+		unix.SYS_PAUSE:      "pause",
+		unix.SYS_ACCESS:     "access",
+		unix.SYS_UNLINK:     "unlink",
+		unix.SYS_RENAME:     "rename",
+		unix.SYS_MKDIR:      "mkdir",
+		unix.SYS_RMDIR:      "rmdir",
+		unix.SYS_READLINK:   "readlink",
+		unix.SYS_GETDENTS:   "getdents",
+	} {
+		syscallNames[nr] = name
+	}
+}
@@ -0,0 +1,7 @@
+//go:build linux && !amd64 && !arm64
+
+package proc
+
+// syscallNames is only populated for amd64 and arm64; elsewhere blocking
+// system calls are shown by number.
+var syscallNames map[uint64]string
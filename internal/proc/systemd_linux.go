@@ -5,37 +5,58 @@ package proc
 import (
 	"bytes"
 	"fmt"
+	"net"
 	"os/exec"
+	"strconv"
 	"strings"
+
+	"github.com/pranshuparmar/witr/pkg/model"
 )
 
-// ResolveSystemdService attempts to find the systemd service name associated with a port.
+// ResolveSystemdService attempts to find the systemd service name associated with a listener.
 // It uses `systemctl list-sockets` to find the socket unit and then maps it to the service unit.
-func ResolveSystemdService(port int) (string, error) {
+func ResolveSystemdService(listener model.Socket) (string, error) {
 	// check if systemctl is available
 	if _, err := exec.LookPath("systemctl"); err != nil {
 		return "", fmt.Errorf("systemctl not found")
 	}
 
-	cmd := exec.Command("systemctl", "list-sockets", "--no-legend", "--full")
+	cmd := exec.Command("systemctl", "list-sockets", "--no-legend", "--full", "--show-types")
 	var out bytes.Buffer
 	cmd.Stdout = &out
 	if err := cmd.Run(); err != nil {
 		return "", err
 	}
 
-	portStr := fmt.Sprintf(":%d", port)
-	lines := strings.Split(out.String(), "\n")
+	if unit := matchSystemdSocket(out.String(), listener); unit != "" {
+		return unit, nil
+	}
+	return "", fmt.Errorf("no systemd service found for port %d", listener.Port)
+}
 
-	for _, line := range lines {
+// matchSystemdSocket returns the unit activated by the socket in
+// `systemctl list-sockets --show-types` output ("LISTEN TYPE UNIT
+// ACTIVATES") that listens on the listener's port with its socket type and,
+// when both are specific, its address.
+func matchSystemdSocket(out string, listener model.Socket) string {
+	wantType := "Stream"
+	if isUDP(listener.Protocol) {
+		wantType = "Datagram"
+	}
+	for _, line := range strings.Split(out, "\n") {
 		fields := strings.Fields(line)
-		if len(fields) < 3 {
+		if len(fields) < 4 || fields[1] != wantType {
 			continue
 		}
-		if strings.HasSuffix(fields[0], portStr) {
-			return fields[2], nil
+		host, port, err := net.SplitHostPort(fields[0])
+		if err != nil || port != strconv.Itoa(listener.Port) {
+			continue
+		}
+		listen, bound := net.ParseIP(host), net.ParseIP(listener.Address)
+		if listen != nil && bound != nil && !listen.IsUnspecified() && !bound.IsUnspecified() && !listen.Equal(bound) {
+			continue
 		}
+		return fields[3]
 	}
-
-	return "", fmt.Errorf("no systemd service found for port %d", port)
+	return ""
 }
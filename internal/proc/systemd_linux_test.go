@@ -0,0 +1,34 @@
+//go:build linux
+
+package proc
+
+import (
+	"testing"
+
+	"github.com/pranshuparmar/witr/pkg/model"
+)
+
+func TestMatchSystemdSocket(t *testing.T) {
+	out := "/run/dbus/system_bus_socket Stream    dbus.socket           dbus.service\n" +
+		"127.0.0.53:53                 Datagram  resolved.socket       systemd-resolved.service\n" +
+		"[::]:22                       Stream    ssh.socket            ssh.service\n" +
+		"127.0.0.1:631                 Stream    cups.socket           cups.service\n" +
+		"10.0.0.5:631                  Stream    cups-lan.socket       cups-lan.service\n"
+
+	tests := []struct {
+		name     string
+		listener model.Socket
+		want     string
+	}{
+		{"stream on wildcard", model.Socket{Protocol: "TCP6", Address: "::", Port: 22}, "ssh.service"},
+		{"datagram", model.Socket{Protocol: "UDP", Address: "127.0.0.53", Port: 53}, "systemd-resolved.service"},
+		{"tcp does not match a datagram socket", model.Socket{Protocol: "TCP", Address: "127.0.0.53", Port: 53}, ""},
+		{"address picks the socket", model.Socket{Protocol: "TCP", Address: "10.0.0.5", Port: 631}, "cups-lan.service"},
+		{"unknown port", model.Socket{Protocol: "TCP", Address: "0.0.0.0", Port: 8080}, ""},
+	}
+	for _, tt := range tests {
+		if got := matchSystemdSocket(out, tt.listener); got != tt.want {
+			t.Errorf("%s: matchSystemdSocket = %q, want %q", tt.name, got, tt.want)
+		}
+	}
+}
@@ -2,8 +2,12 @@
 
 package proc
 
-import "fmt"
+import (
+	"fmt"
 
-func ResolveSystemdService(port int) (string, error) {
+	"github.com/pranshuparmar/witr/pkg/model"
+)
+
+func ResolveSystemdService(listener model.Socket) (string, error) {
 	return "", fmt.Errorf("systemd is only supported on Linux")
 }
@@ -0,0 +1,128 @@
+//go:build linux
+
+package proc
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pranshuparmar/witr/pkg/model"
+)
+
+// SampleThreads lists pid's threads with their CPU usage over interval: the
+// task stats are read twice and each thread's CPU% is the CPU time it used in
+// between. Threads are returned busiest first, so a spinning worker tops the
+// list even in a process with hundreds of idle ones.
+func SampleThreads(pid int, interval time.Duration) ([]model.Thread, error) {
+	before, err := readThreads(pid)
+	if err != nil {
+		return nil, err
+	}
+	start := time.Now()
+	time.Sleep(interval)
+	after, err := readThreads(pid)
+	if err != nil {
+		return nil, err
+	}
+	elapsed := time.Since(start).Seconds()
+
+	prev := make(map[int]float64, len(before))
+	for _, t := range before {
+		prev[t.TID] = t.CPUSeconds
+	}
+	for i := range after {
+		// A thread started mid-sample used all of its CPU time in the window.
+		used := after[i].CPUSeconds - prev[after[i].TID]
+		if elapsed > 0 && used > 0 {
+			after[i].CPUPercent = used / elapsed * 100
+		}
+	}
+	sortThreads(after)
+	return after, nil
+}
+
+// sortThreads orders threads by recent CPU usage, then lifetime CPU time,
+// then TID.
+func sortThreads(threads []model.Thread) {
+	sort.SliceStable(threads, func(i, j int) bool {
+		a, b := threads[i], threads[j]
+		if a.CPUPercent != b.CPUPercent {
+			return a.CPUPercent > b.CPUPercent
+		}
+		if a.CPUSeconds != b.CPUSeconds {
+			return a.CPUSeconds > b.CPUSeconds
+		}
+		return a.TID < b.TID
+	})
+}
+
+// readThreads reads every /proc/<pid>/task entry once. Threads that exit
+// while being read are skipped.
+func readThreads(pid int) ([]model.Thread, error) {
+	taskDir := fmt.Sprintf("/proc/%d/task", pid)
+	entries, err := os.ReadDir(taskDir)
+	if err != nil {
+		return nil, err
+	}
+	threads := make([]model.Thread, 0, len(entries))
+	for _, e := range entries {
+		tid, err := strconv.Atoi(e.Name())
+		if err != nil {
+			continue
+		}
+		stat, err := os.ReadFile(fmt.Sprintf("%s/%d/stat", taskDir, tid))
+		if err != nil {
+			continue
+		}
+		t, ok := parseThreadStat(tid, string(stat))
+		if !ok {
+			continue
+		}
+		if wchan, err := os.ReadFile(fmt.Sprintf("%s/%d/wchan", taskDir, tid)); err == nil {
+			t.WaitChannel = parseWaitChannel(string(wchan))
+		}
+		threads = append(threads, t)
+	}
+	return threads, nil
+}
+
+// parseThreadStat extracts the thread view's fields from a task's stat line.
+// The name sits in parentheses and may itself contain spaces or parentheses,
+// so fields are counted from the last ')'.
+func parseThreadStat(tid int, stat string) (model.Thread, bool) {
+	open := strings.Index(stat, "(")
+	closeParen := strings.LastIndex(stat, ")")
+	if open == -1 || closeParen < open || closeParen+2 >= len(stat) {
+		return model.Thread{}, false
+	}
+	// fields[0] is stat field 3 (state), so field N is fields[N-3].
+	fields := strings.Fields(stat[closeParen+2:])
+	if len(fields) < 37 {
+		return model.Thread{}, false
+	}
+	utime, _ := strconv.ParseFloat(fields[11], 64)
+	stime, _ := strconv.ParseFloat(fields[12], 64)
+	processor, _ := strconv.Atoi(fields[36])
+	return model.Thread{
+		TID:        tid,
+		Name:       stat[open+1 : closeParen],
+		State:      fields[0],
+		CPUSeconds: (utime + stime) / float64(ticksPerSecond()),
+		Processor:  processor,
+	}, true
+}
+
+// parseWaitChannel normalises a wchan file: the kernel writes "0" for a
+// running thread, and hides the symbol the same way when kallsyms are
+// restricted.
+func parseWaitChannel(wchan string) string {
+	wchan = strings.TrimSpace(wchan)
+	if wchan == "0" {
+		return ""
+	}
+	return wchan
+}
@@ -0,0 +1,99 @@
+//go:build linux
+
+package proc
+
+import (
+	"os"
+	"reflect"
+	"runtime"
+	"testing"
+	"time"
+
+	"github.com/pranshuparmar/witr/pkg/model"
+	"golang.org/x/sys/unix"
+)
+
+func TestParseThreadStat(t *testing.T) {
+	t.Parallel()
+
+	// 52 fields, as on a 5.x kernel; the name contains spaces and a ')'.
+	stat := "4242 (C2 Compiler) T) R 4200 4200 4200 0 -1 4194368 0 0 0 0 1234 66 0 0 20 0 30 0 5000 0 0 18446744073709551615 0 0 0 0 0 0 0 4096 0 0 0 0 -1 3 0 0 0 0 0 0 0 0 0 0 0 0 0"
+	got, ok := parseThreadStat(4242, stat)
+	if !ok {
+		t.Fatal("stat rejected")
+	}
+	want := model.Thread{TID: 4242, Name: "C2 Compiler) T", State: "R", CPUSeconds: 13, Processor: 3}
+	if got != want {
+		t.Errorf("parseThreadStat = %+v, want %+v", got, want)
+	}
+
+	if _, ok := parseThreadStat(1, "1 (init) S 0 1"); ok {
+		t.Error("truncated stat should be rejected")
+	}
+}
+
+func TestParseWaitChannel(t *testing.T) {
+	t.Parallel()
+
+	for in, want := range map[string]string{
+		"0":                   "",
+		"futex_wait_queue\n":  "futex_wait_queue",
+		"do_epoll_wait":       "do_epoll_wait",
+		"hrtimer_nanosleep\n": "hrtimer_nanosleep",
+	} {
+		if got := parseWaitChannel(in); got != want {
+			t.Errorf("parseWaitChannel(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
+
+func TestSortThreads(t *testing.T) {
+	t.Parallel()
+
+	threads := []model.Thread{
+		{TID: 10, CPUSeconds: 5},
+		{TID: 11, CPUPercent: 90, CPUSeconds: 1},
+		{TID: 12, CPUSeconds: 9},
+		{TID: 9, CPUSeconds: 5},
+	}
+	sortThreads(threads)
+	var got []int
+	for _, th := range threads {
+		got = append(got, th.TID)
+	}
+	if want := []int{11, 12, 9, 10}; !reflect.DeepEqual(got, want) {
+		t.Errorf("order = %v, want %v", got, want)
+	}
+}
+
+// TestSampleThreadsFindsSpinningThread spins one OS thread and checks the
+// sample ranks it first.
+func TestSampleThreadsFindsSpinningThread(t *testing.T) {
+	stop := make(chan struct{})
+	tid := make(chan int)
+	go func() {
+		runtime.LockOSThread()
+		defer runtime.UnlockOSThread()
+		tid <- unix.Gettid()
+		for {
+			select {
+			case <-stop:
+				return
+			default:
+			}
+		}
+	}()
+	spinner := <-tid
+	defer close(stop)
+
+	threads, err := SampleThreads(os.Getpid(), 300*time.Millisecond)
+	if err != nil {
+		t.Fatalf("SampleThreads: %v", err)
+	}
+	if len(threads) < 2 {
+		t.Fatalf("expected several threads, got %d", len(threads))
+	}
+	if threads[0].TID != spinner || threads[0].CPUPercent < 20 {
+		t.Errorf("spinning thread %d should rank first with high CPU; top = %+v", spinner, threads[0])
+	}
+}
@@ -0,0 +1,16 @@
+//go:build !linux
+
+package proc
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/pranshuparmar/witr/pkg/model"
+)
+
+// SampleThreads is Linux-only: other platforms expose no per-thread stats
+// through a filesystem witr reads.
+func SampleThreads(pid int, interval time.Duration) ([]model.Thread, error) {
+	return nil, fmt.Errorf("thread listing is not supported on this platform")
+}
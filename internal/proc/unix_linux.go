@@ -0,0 +1,344 @@
+//go:build linux
+
+package proc
+
+import (
+	"bufio"
+	"encoding/binary"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/pranshuparmar/witr/pkg/model"
+	"golang.org/x/sys/unix"
+)
+
+// unix_diag attribute types and udiag_show flags (linux/unix_diag.h); not
+// exported by x/sys/unix.
+const (
+	unixDiagName  = 0
+	unixDiagPeer  = 2
+	unixDiagIcons = 3
+
+	udiagShowName  = 0x01
+	udiagShowPeer  = 0x04
+	udiagShowIcons = 0x08
+)
+
+// unixSocketEntry is one row of the host's unix socket table. Peer is the
+// inode of the connected socket on the other end and Pending, for a
+// listener, the clients still waiting in its accept queue; both are only
+// known when the table came from sock_diag.
+type unixSocketEntry struct {
+	Inode   string
+	Path    string
+	Type    string
+	State   string
+	Peer    string
+	Pending []string
+}
+
+var unixTypeNames = map[uint8]string{
+	unix.SOCK_STREAM:    "STREAM",
+	unix.SOCK_DGRAM:     "DGRAM",
+	unix.SOCK_SEQPACKET: "SEQPACKET",
+}
+
+// sock_diag reports unix socket states using the TCP state numbers.
+var unixDiagStates = map[uint8]string{
+	1:  "ESTABLISHED",
+	2:  "CONNECTING",
+	7:  "UNCONNECTED",
+	10: "LISTEN",
+}
+
+// /proc/net/unix reports the socket's SS_* state, plus __SO_ACCEPTCON in
+// Flags for listeners.
+var procUnixStates = map[string]string{
+	"01": "UNCONNECTED",
+	"02": "CONNECTING",
+	"03": "ESTABLISHED",
+	"04": "DISCONNECTING",
+}
+
+const soAcceptCon = 0x10000
+
+// ReadUnixSockets returns the unix sockets pid serves (listening stream or
+// seqpacket sockets and bound datagram sockets) with the processes connected
+// to them, and the connections pid made to other processes' named sockets.
+// Peers come from sock_diag's UNIX_DIAG_PEER; when netlink is unavailable the
+// /proc/net/unix fallback still lists served sockets, without peers.
+func ReadUnixSockets(pid int) []model.UnixSocket {
+	inodes := socketsForPID(pid)
+	if len(inodes) == 0 {
+		return nil
+	}
+	table, err := readUnixSockets()
+	if err != nil {
+		return nil
+	}
+	sockets := unixSocketsFor(inodes, table, socketOwners)
+	names := make(map[int]string)
+	for i := range sockets {
+		for j := range sockets[i].Peers {
+			p := &sockets[i].Peers[j]
+			p.Command = lockProcessName(p.PID, names)
+		}
+	}
+	return sockets
+}
+
+// FindUnixSocketInodes returns the inodes of the sockets serving path: a
+// listening socket, or a bound datagram socket. Abstract sockets are named
+// with a leading "@", as in /proc/net/unix.
+func FindUnixSocketInodes(path string) (map[string]bool, error) {
+	table, err := readUnixSockets()
+	if err != nil {
+		return nil, err
+	}
+	match := unixPathMatcher(path)
+	inodes := make(map[string]bool)
+	for inode, e := range table {
+		if isServedUnixSocket(e) && match(e.Path) {
+			inodes[inode] = true
+		}
+	}
+	if len(inodes) == 0 {
+		return nil, fmt.Errorf("no process is serving unix socket %s", path)
+	}
+	return inodes, nil
+}
+
+// unixPathMatcher returns a predicate matching table paths against the path
+// the user asked for. The socket table records the path as passed to bind(),
+// so /var/run/docker.sock and /run/docker.sock must both match when one is a
+// symlink to the other's directory.
+func unixPathMatcher(path string) func(string) bool {
+	if strings.HasPrefix(path, "@") {
+		return func(p string) bool { return p == path }
+	}
+	abs, err := filepath.Abs(path)
+	if err != nil {
+		abs = path
+	}
+	resolved, err := filepath.EvalSymlinks(abs)
+	if err != nil {
+		resolved = abs
+	}
+	return func(p string) bool {
+		if p == "" || strings.HasPrefix(p, "@") {
+			return false
+		}
+		if p == abs || p == resolved {
+			return true
+		}
+		if filepath.Base(p) != filepath.Base(resolved) {
+			return false
+		}
+		r, err := filepath.EvalSymlinks(p)
+		return err == nil && r == resolved
+	}
+}
+
+// isServedUnixSocket reports whether e is a socket others connect to. A bound
+// datagram socket counts unless it is itself connected somewhere; its state
+// is no guide, as the kernel marks it ESTABLISHED once a client connects.
+func isServedUnixSocket(e unixSocketEntry) bool {
+	return e.State == "LISTEN" || (e.Type == "DGRAM" && e.Path != "" && e.Peer == "")
+}
+
+// unixSocketsFor picks the served and client sockets among inodes (the
+// process's own) and resolves the peers' owners with owners. Peers carry
+// only a PID; the caller names them.
+func unixSocketsFor(inodes []string, table map[string]unixSocketEntry, owners func(map[string]bool) map[string]int) []model.UnixSocket {
+	// A stream server's accepted sockets inherit the listener's path, so the
+	// clients of a listener are the peers of the established sockets bound
+	// to its path. Datagram clients connect to the bound socket directly.
+	var acceptedByPath, dgramClients map[string][]string
+	index := func() {
+		if acceptedByPath != nil {
+			return
+		}
+		acceptedByPath = make(map[string][]string)
+		dgramClients = make(map[string][]string)
+		for inode, e := range table {
+			switch {
+			case e.Peer == "":
+			case e.Type == "DGRAM":
+				dgramClients[e.Peer] = append(dgramClients[e.Peer], inode)
+			case e.Path != "" && e.State == "ESTABLISHED":
+				acceptedByPath[e.Path] = append(acceptedByPath[e.Path], e.Peer)
+			}
+		}
+	}
+
+	var sockets []model.UnixSocket
+	peerInodes := make(map[int][]string)
+	wanted := make(map[string]bool)
+	for _, inode := range inodes {
+		e, ok := table[inode]
+		if !ok {
+			continue
+		}
+		s := model.UnixSocket{Inode: inode, Path: e.Path, Type: e.Type, State: e.State}
+		var peers []string
+		switch {
+		case e.State == "LISTEN":
+			index()
+			peers = append(append([]string(nil), acceptedByPath[e.Path]...), e.Pending...)
+		case isServedUnixSocket(e):
+			index()
+			s.State = "BOUND"
+			peers = dgramClients[inode]
+		case e.State == "ESTABLISHED" && e.Path == "" && e.Peer != "":
+			server, ok := table[e.Peer]
+			if !ok || server.Path == "" {
+				continue // socketpair or unnamed peer
+			}
+			s.Path = server.Path
+			s.Client = true
+			peers = []string{e.Peer}
+		default:
+			continue
+		}
+		for _, p := range peers {
+			wanted[p] = true
+		}
+		peerInodes[len(sockets)] = peers
+		sockets = append(sockets, s)
+	}
+	if len(sockets) == 0 {
+		return nil
+	}
+
+	var owner map[string]int
+	if len(wanted) > 0 {
+		owner = owners(wanted)
+	}
+	for i, peers := range peerInodes {
+		seen := make(map[int]bool)
+		for _, inode := range peers {
+			pid, ok := owner[inode]
+			if !ok || seen[pid] {
+				continue
+			}
+			seen[pid] = true
+			sockets[i].Peers = append(sockets[i].Peers, model.SocketPeer{PID: pid})
+		}
+		sort.Slice(sockets[i].Peers, func(a, b int) bool { return sockets[i].Peers[a].PID < sockets[i].Peers[b].PID })
+	}
+
+	sort.SliceStable(sockets, func(i, j int) bool {
+		if sockets[i].Client != sockets[j].Client {
+			return !sockets[i].Client
+		}
+		return sockets[i].Path < sockets[j].Path
+	})
+	return sockets
+}
+
+// readUnixSockets returns the host's unix socket table keyed by inode, from
+// sock_diag when possible and /proc/net/unix otherwise.
+func readUnixSockets() (map[string]unixSocketEntry, error) {
+	if table, err := dumpUnixDiag(); err == nil {
+		return table, nil
+	}
+	data, err := os.ReadFile("/proc/net/unix")
+	if err != nil {
+		return nil, err
+	}
+	return parseProcNetUnix(string(data)), nil
+}
+
+func dumpUnixDiag() (map[string]unixSocketEntry, error) {
+	// struct unix_diag_req
+	req := make([]byte, 24)
+	req[0] = unix.AF_UNIX
+	binary.NativeEndian.PutUint32(req[4:8], 0xffffffff) // udiag_states: all
+	binary.NativeEndian.PutUint32(req[12:16], udiagShowName|udiagShowPeer|udiagShowIcons)
+
+	table := make(map[string]unixSocketEntry)
+	err := sockDiagDump(req, func(payload []byte) {
+		if e, ok := parseUnixDiagMsg(payload); ok {
+			table[e.Inode] = e
+		}
+	})
+	if err != nil {
+		return nil, err
+	}
+	return table, nil
+}
+
+// parseUnixDiagMsg decodes a struct unix_diag_msg and its attributes.
+func parseUnixDiagMsg(b []byte) (unixSocketEntry, bool) {
+	const msgLen = 16
+	if len(b) < msgLen || b[0] != unix.AF_UNIX {
+		return unixSocketEntry{}, false
+	}
+	e := unixSocketEntry{
+		Inode: strconv.FormatUint(uint64(binary.NativeEndian.Uint32(b[4:8])), 10),
+		Type:  unixTypeNames[b[1]],
+		State: unixDiagStates[b[2]],
+	}
+	attrs := netlinkAttrs(b[msgLen:])
+	if name, ok := attrs[unixDiagName]; ok {
+		e.Path = unixSocketName(name)
+	}
+	if peer, ok := attrs[unixDiagPeer]; ok && len(peer) >= 4 {
+		if ino := binary.NativeEndian.Uint32(peer[0:4]); ino != 0 {
+			e.Peer = strconv.FormatUint(uint64(ino), 10)
+		}
+	}
+	// Icons are the client sockets of connections not yet accepted.
+	icons := attrs[unixDiagIcons]
+	for len(icons) >= 4 {
+		if ino := binary.NativeEndian.Uint32(icons[0:4]); ino != 0 {
+			e.Pending = append(e.Pending, strconv.FormatUint(uint64(ino), 10))
+		}
+		icons = icons[4:]
+	}
+	return e, true
+}
+
+// unixSocketName renders a sun_path the way /proc/net/unix does: abstract
+// names (leading NUL) get an "@" prefix and embedded NULs become "@".
+func unixSocketName(b []byte) string {
+	if len(b) > 0 && b[0] == 0 {
+		return "@" + strings.ReplaceAll(string(b[1:]), "\x00", "@")
+	}
+	return strings.TrimRight(string(b), "\x00")
+}
+
+// parseProcNetUnix parses /proc/net/unix:
+//
+//	Num       RefCount Protocol Flags    Type St Inode Path
+//	0000000000000000: 00000002 00000000 00010000 0001 01 23456 /run/foo.sock
+func parseProcNetUnix(content string) map[string]unixSocketEntry {
+	table := make(map[string]unixSocketEntry)
+	scanner := bufio.NewScanner(strings.NewReader(content))
+	scanner.Scan() // skip header
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) < 7 {
+			continue
+		}
+		flags, _ := strconv.ParseUint(fields[3], 16, 32)
+		typ, _ := strconv.ParseUint(fields[4], 16, 8)
+		e := unixSocketEntry{
+			Inode: fields[6],
+			Type:  unixTypeNames[uint8(typ)],
+			State: procUnixStates[fields[5]],
+		}
+		if flags&soAcceptCon != 0 {
+			e.State = "LISTEN"
+		}
+		if len(fields) > 7 {
+			e.Path = strings.Join(fields[7:], " ")
+		}
+		table[e.Inode] = e
+	}
+	return table
+}
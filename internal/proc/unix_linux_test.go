@@ -0,0 +1,213 @@
+//go:build linux
+
+package proc
+
+import (
+	"encoding/binary"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/pranshuparmar/witr/pkg/model"
+	"golang.org/x/sys/unix"
+)
+
+func TestParseProcNetUnix(t *testing.T) {
+	t.Parallel()
+
+	content := `Num       RefCount Protocol Flags    Type St Inode Path
+0000000000000000: 00000002 00000000 00010000 0001 01 23456 /run/docker.sock
+0000000000000000: 00000003 00000000 00000000 0001 03 23457 /run/docker.sock
+0000000000000000: 00000003 00000000 00000000 0001 03 23458
+0000000000000000: 00000002 00000000 00000000 0002 01 23459 @journal notify
+`
+	got := parseProcNetUnix(content)
+	want := map[string]unixSocketEntry{
+		"23456": {Inode: "23456", Path: "/run/docker.sock", Type: "STREAM", State: "LISTEN"},
+		"23457": {Inode: "23457", Path: "/run/docker.sock", Type: "STREAM", State: "ESTABLISHED"},
+		"23458": {Inode: "23458", Type: "STREAM", State: "ESTABLISHED"},
+		"23459": {Inode: "23459", Path: "@journal notify", Type: "DGRAM", State: "UNCONNECTED"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("parseProcNetUnix =\n%+v\nwant\n%+v", got, want)
+	}
+}
+
+// unixDiagMsg encodes a unix_diag_msg followed by its attributes.
+func unixDiagMsg(typ, state uint8, ino uint32, attrs map[uint16][]byte) []byte {
+	b := make([]byte, 16)
+	b[0], b[1], b[2] = unix.AF_UNIX, typ, state
+	binary.NativeEndian.PutUint32(b[4:8], ino)
+	for _, attrType := range []uint16{unixDiagName, unixDiagPeer, unixDiagIcons} {
+		value, ok := attrs[attrType]
+		if !ok {
+			continue
+		}
+		hdr := make([]byte, 4)
+		binary.NativeEndian.PutUint16(hdr[0:2], uint16(4+len(value)))
+		binary.NativeEndian.PutUint16(hdr[2:4], attrType)
+		b = append(b, hdr...)
+		b = append(b, value...)
+		b = append(b, make([]byte, netlinkAlign(len(value))-len(value))...)
+	}
+	return b
+}
+
+func u32s(vs ...uint32) []byte {
+	b := make([]byte, 4*len(vs))
+	for i, v := range vs {
+		binary.NativeEndian.PutUint32(b[4*i:], v)
+	}
+	return b
+}
+
+func TestParseUnixDiagMsg(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		msg  []byte
+		want unixSocketEntry
+	}{
+		{
+			name: "listener with pending clients",
+			msg: unixDiagMsg(unix.SOCK_STREAM, 10, 100, map[uint16][]byte{
+				unixDiagName:  []byte("/run/foo.sock\x00"),
+				unixDiagIcons: u32s(201, 0, 202),
+			}),
+			want: unixSocketEntry{Inode: "100", Path: "/run/foo.sock", Type: "STREAM", State: "LISTEN", Pending: []string{"201", "202"}},
+		},
+		{
+			name: "abstract name",
+			msg: unixDiagMsg(unix.SOCK_DGRAM, 7, 101, map[uint16][]byte{
+				unixDiagName: []byte("\x00bus\x00x"),
+			}),
+			want: unixSocketEntry{Inode: "101", Path: "@bus@x", Type: "DGRAM", State: "UNCONNECTED"},
+		},
+		{
+			name: "connected client",
+			msg: unixDiagMsg(unix.SOCK_SEQPACKET, 1, 102, map[uint16][]byte{
+				unixDiagPeer: u32s(103),
+			}),
+			want: unixSocketEntry{Inode: "102", Type: "SEQPACKET", State: "ESTABLISHED", Peer: "103"},
+		},
+	}
+	for _, tt := range tests {
+		got, ok := parseUnixDiagMsg(tt.msg)
+		if !ok || !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("%s: parseUnixDiagMsg = %+v, %v; want %+v", tt.name, got, ok, tt.want)
+		}
+	}
+
+	if _, ok := parseUnixDiagMsg([]byte{unix.AF_UNIX, 1, 1}); ok {
+		t.Error("short message should be rejected")
+	}
+}
+
+func TestParseNetlinkRepliesStopsOnDoneAndError(t *testing.T) {
+	t.Parallel()
+
+	msg := func(typ uint16, payload []byte) []byte {
+		b := make([]byte, unix.NLMSG_HDRLEN)
+		binary.NativeEndian.PutUint32(b[0:4], uint32(unix.NLMSG_HDRLEN+len(payload)))
+		binary.NativeEndian.PutUint16(b[4:6], typ)
+		return append(b, payload...)
+	}
+
+	var payloads [][]byte
+	collect := func(p []byte) { payloads = append(payloads, p) }
+
+	data := append(msg(unix.SOCK_DIAG_BY_FAMILY, []byte{1, 2, 3, 4}), msg(unix.NLMSG_DONE, u32s(0))...)
+	done, err := parseNetlinkReplies(data, collect)
+	if !done || err != nil || len(payloads) != 1 {
+		t.Errorf("got done=%v err=%v payloads=%d, want done, no error, 1 payload", done, err, len(payloads))
+	}
+
+	errno := int32(-int32(unix.EPERM))
+	done, err = parseNetlinkReplies(msg(unix.NLMSG_ERROR, u32s(uint32(errno))), collect)
+	if !done || err == nil {
+		t.Errorf("NLMSG_ERROR: got done=%v err=%v, want done with an error", done, err)
+	}
+}
+
+func TestUnixSocketsFor(t *testing.T) {
+	t.Parallel()
+
+	// Server (inodes 10, 11) serves /run/app.sock and a datagram socket; it
+	// has accepted one client (12 ↔ 20) and another waits in the queue (21).
+	// Client process holds 20, 21, 22 and 23.
+	table := map[string]unixSocketEntry{
+		"10": {Inode: "10", Path: "/run/app.sock", Type: "STREAM", State: "LISTEN", Pending: []string{"21"}},
+		"11": {Inode: "11", Path: "/run/app.dgram", Type: "DGRAM", State: "ESTABLISHED"},
+		"12": {Inode: "12", Path: "/run/app.sock", Type: "STREAM", State: "ESTABLISHED", Peer: "20"},
+		"13": {Inode: "13", Type: "STREAM", State: "ESTABLISHED", Peer: "14"},
+		"14": {Inode: "14", Type: "STREAM", State: "ESTABLISHED", Peer: "13"},
+		"20": {Inode: "20", Type: "STREAM", State: "ESTABLISHED", Peer: "12"},
+		"21": {Inode: "21", Type: "STREAM", State: "ESTABLISHED"},
+		"22": {Inode: "22", Type: "DGRAM", State: "ESTABLISHED", Peer: "11"},
+		"23": {Inode: "23", Type: "STREAM", State: "ESTABLISHED", Peer: "13"},
+	}
+	owners := func(inodes map[string]bool) map[string]int {
+		all := map[string]int{"10": 1000, "11": 1000, "12": 1000, "13": 1000, "20": 2000, "21": 3000, "22": 2000}
+		got := make(map[string]int)
+		for inode := range inodes {
+			if pid, ok := all[inode]; ok {
+				got[inode] = pid
+			}
+		}
+		return got
+	}
+
+	server := unixSocketsFor([]string{"10", "11", "12", "13"}, table, owners)
+	wantServer := []model.UnixSocket{
+		{Inode: "11", Path: "/run/app.dgram", Type: "DGRAM", State: "BOUND", Peers: []model.SocketPeer{{PID: 2000}}},
+		{Inode: "10", Path: "/run/app.sock", Type: "STREAM", State: "LISTEN", Peers: []model.SocketPeer{{PID: 2000}, {PID: 3000}}},
+	}
+	if !reflect.DeepEqual(server, wantServer) {
+		t.Errorf("server sockets =\n%+v\nwant\n%+v", server, wantServer)
+	}
+
+	client := unixSocketsFor([]string{"20", "21", "22", "23"}, table, owners)
+	wantClient := []model.UnixSocket{
+		{Inode: "22", Path: "/run/app.dgram", Type: "DGRAM", State: "ESTABLISHED", Client: true, Peers: []model.SocketPeer{{PID: 1000}}},
+		{Inode: "20", Path: "/run/app.sock", Type: "STREAM", State: "ESTABLISHED", Client: true, Peers: []model.SocketPeer{{PID: 1000}}},
+	}
+	if !reflect.DeepEqual(client, wantClient) {
+		t.Errorf("client sockets =\n%+v\nwant\n%+v", client, wantClient)
+	}
+}
+
+func TestUnixPathMatcherFollowsSymlinks(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	runDir := filepath.Join(dir, "run")
+	if err := os.Mkdir(runDir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(runDir, "app.sock"), nil, 0o600); err != nil {
+		t.Fatal(err)
+	}
+	link := filepath.Join(dir, "var-run")
+	if err := os.Symlink(runDir, link); err != nil {
+		t.Skipf("symlink: %v", err)
+	}
+
+	match := unixPathMatcher(filepath.Join(runDir, "app.sock"))
+	for _, p := range []string{filepath.Join(runDir, "app.sock"), filepath.Join(link, "app.sock")} {
+		if !match(p) {
+			t.Errorf("expected %s to match", p)
+		}
+	}
+	for _, p := range []string{"", "@app.sock", filepath.Join(runDir, "other.sock")} {
+		if match(p) {
+			t.Errorf("expected %q not to match", p)
+		}
+	}
+
+	abstract := unixPathMatcher("@bus")
+	if !abstract("@bus") || abstract("/bus") {
+		t.Error("abstract names must match exactly")
+	}
+}
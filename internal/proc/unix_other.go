@@ -0,0 +1,11 @@
+//go:build !linux
+
+package proc
+
+import "github.com/pranshuparmar/witr/pkg/model"
+
+// ReadUnixSockets returns nil on non-Linux platforms, which have neither
+// /proc/net/unix nor sock_diag to map unix sockets to their peers.
+func ReadUnixSockets(pid int) []model.UnixSocket {
+	return nil
+}
@@ -13,6 +13,9 @@ import (
 var (
 	userCache     map[int]string
 	userCacheOnce sync.Once
+
+	groupCache     map[int]string
+	groupCacheOnce sync.Once
 )
 
 func loadUserCache() map[int]string {
@@ -48,8 +51,11 @@ func readUser(pid int) string {
 		return "unknown"
 	}
 
-	uid := int(stat.Uid)
+	return userName(int(stat.Uid))
+}
 
+// userName resolves a UID to its /etc/passwd name, or the numeric ID.
+func userName(uid int) string {
 	userCacheOnce.Do(func() {
 		userCache = loadUserCache()
 	})
@@ -59,3 +65,31 @@ func readUser(pid int) string {
 	}
 	return strconv.Itoa(uid)
 }
+
+// loadGroupCache maps GIDs to names from /etc/group.
+func loadGroupCache() map[int]string {
+	cache := map[int]string{0: "root"}
+
+	data, err := os.ReadFile("/etc/group")
+	if err != nil {
+		return cache
+	}
+
+	for line := range strings.Lines(string(data)) {
+		fields := strings.Split(line, ":")
+		if len(fields) > 2 {
+			if gid, err := strconv.Atoi(fields[2]); err == nil {
+				cache[gid] = fields[0]
+			}
+		}
+	}
+	return cache
+}
+
+// groupName resolves a GID to its /etc/group name, or "" when unknown.
+func groupName(gid int) string {
+	groupCacheOnce.Do(func() {
+		groupCache = loadGroupCache()
+	})
+	return groupCache[gid]
+}
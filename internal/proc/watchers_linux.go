@@ -0,0 +1,274 @@
+//go:build linux
+
+package proc
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/pranshuparmar/witr/pkg/model"
+	"golang.org/x/sys/unix"
+)
+
+// watchEvent names one bit of an inotify or fanotify event mask.
+type watchEvent struct {
+	bit  uint64
+	name string
+}
+
+// Event bits shared by inotify and fanotify (linux/fsnotify_backend.h).
+var fsnotifyEvents = []watchEvent{
+	{0x1, "access"},
+	{0x2, "modify"},
+	{0x4, "attrib"},
+	{0x8, "close_write"},
+	{0x10, "close_nowrite"},
+	{0x20, "open"},
+	{0x40, "moved_from"},
+	{0x80, "moved_to"},
+	{0x100, "create"},
+	{0x200, "delete"},
+	{0x400, "delete_self"},
+	{0x800, "move_self"},
+}
+
+// Bits only one of the APIs defines.
+var (
+	inotifyEvents = []watchEvent{
+		{0x2000, "unmount"},
+		{0x1000000, "onlydir"},
+		{0x2000000, "dont_follow"},
+		{0x4000000, "excl_unlink"},
+		{0x80000000, "oneshot"},
+	}
+	fanotifyEvents = []watchEvent{
+		{0x1000, "open_exec"},
+		{0x8000, "fs_error"},
+		{0x10000, "open_perm"},
+		{0x20000, "access_perm"},
+		{0x40000, "open_exec_perm"},
+		{0x100000, "pre_access"},
+		{0x8000000, "event_on_child"},
+		{0x10000000, "rename"},
+		{0x40000000, "ondir"},
+	}
+)
+
+// watchMark is one watch line from an inotify or fanotify fdinfo file.
+type watchMark struct {
+	API   string
+	WD    int
+	Inode uint64
+	Dev   uint64 // kernel-internal dev_t: major << 20 | minor
+	MntID int
+	Mask  uint64
+}
+
+// watchTarget identifies the path's inode, its parent directory's inode and
+// its mount, which watches are matched against.
+type watchTarget struct {
+	inode, parentInode uint64
+	major, minor       uint32
+	parentMajor        uint32
+	parentMinor        uint32
+	mountID            int
+}
+
+// ScanWatchers finds every process holding an inotify watch or fanotify
+// mark that reports events for path: on the path itself, on its parent
+// directory, or for fanotify on its whole mount or filesystem. Watchers
+// hold PIDs and watches only; callers fill in the process details.
+func ScanWatchers(path string) (model.FileWatchers, error) {
+	abs, err := filepath.Abs(path)
+	if err != nil {
+		return model.FileWatchers{}, err
+	}
+	target, err := statWatchTarget(abs)
+	if err != nil {
+		return model.FileWatchers{}, err
+	}
+
+	result := model.FileWatchers{Path: abs, Watchers: []model.FileWatcher{}}
+	entries, err := os.ReadDir("/proc")
+	if err != nil {
+		return result, fmt.Errorf("read /proc: %w", err)
+	}
+	for _, entry := range entries {
+		pid, err := strconv.Atoi(entry.Name())
+		if err != nil {
+			continue
+		}
+		result.Scanned++
+		watches, readable := processWatches(pid, target)
+		if !readable {
+			result.Unreadable++
+		}
+		if len(watches) > 0 {
+			result.Watchers = append(result.Watchers, model.FileWatcher{PID: pid, Watches: watches})
+		}
+	}
+	sort.Slice(result.Watchers, func(i, j int) bool { return result.Watchers[i].PID < result.Watchers[j].PID })
+	return result, nil
+}
+
+func statWatchTarget(abs string) (watchTarget, error) {
+	var t watchTarget
+	var st, parent unix.Stat_t
+	if err := unix.Stat(abs, &st); err != nil {
+		return t, &os.PathError{Op: "stat", Path: abs, Err: err}
+	}
+	t.inode, t.major, t.minor = st.Ino, unix.Major(uint64(st.Dev)), unix.Minor(uint64(st.Dev))
+	if dir := filepath.Dir(abs); dir != abs {
+		if err := unix.Stat(dir, &parent); err == nil {
+			t.parentInode = parent.Ino
+			t.parentMajor, t.parentMinor = unix.Major(uint64(parent.Dev)), unix.Minor(uint64(parent.Dev))
+		}
+	}
+	if data, err := os.ReadFile("/proc/self/mountinfo"); err == nil {
+		real := abs
+		if r, err := filepath.EvalSymlinks(abs); err == nil {
+			real = r
+		}
+		if m, ok := mountFor(parseMountInfo(string(data)), real); ok {
+			t.mountID = m.ID
+		}
+	}
+	return t, nil
+}
+
+// processWatches returns pid's watches that cover target. readable is false
+// when its descriptors could not be listed.
+func processWatches(pid int, target watchTarget) (watches []model.Watch, readable bool) {
+	base := "/proc/" + strconv.Itoa(pid)
+	fds, err := os.ReadDir(base + "/fd")
+	if err != nil {
+		return nil, !errors.Is(err, os.ErrPermission)
+	}
+	for _, entry := range fds {
+		link, err := os.Readlink(base + "/fd/" + entry.Name())
+		if err != nil || (link != "anon_inode:inotify" && link != "anon_inode:[fanotify]") {
+			continue
+		}
+		fd, err := strconv.Atoi(entry.Name())
+		if err != nil {
+			continue
+		}
+		data, err := os.ReadFile(base + "/fdinfo/" + entry.Name())
+		if err != nil {
+			continue
+		}
+		for _, m := range parseWatchMarks(string(data)) {
+			scope := target.scope(m)
+			if scope == "" {
+				continue
+			}
+			watches = append(watches, model.Watch{
+				API:    m.API,
+				FD:     fd,
+				WD:     m.WD,
+				Scope:  scope,
+				Mask:   m.Mask,
+				Events: watchEvents(m.API, m.Mask),
+			})
+		}
+	}
+	return watches, true
+}
+
+// scope reports what part of the target a mark covers, or "" if none.
+func (t watchTarget) scope(m watchMark) string {
+	major, minor := uint32(m.Dev>>20), uint32(m.Dev&(1<<20-1))
+	switch {
+	case m.Inode != 0 && m.Inode == t.inode && major == t.major && minor == t.minor:
+		return "path"
+	case m.Inode != 0 && t.parentInode != 0 && m.Inode == t.parentInode && major == t.parentMajor && minor == t.parentMinor:
+		return "parent"
+	case m.API == "fanotify" && m.MntID != 0 && m.MntID == t.mountID:
+		return "mount"
+	case m.API == "fanotify" && m.Inode == 0 && m.MntID == 0 && m.Dev != 0 && major == t.major && minor == t.minor:
+		return "filesystem"
+	}
+	return ""
+}
+
+// parseWatchMarks reads the watch lines of an inotify or fanotify fdinfo
+// file, e.g.
+//
+//	inotify wd:3 ino:2a0f sdev:800001 mask:fce ignored_mask:0 ...
+//	fanotify ino:2a0f sdev:800001 mflags:0 mask:3b ignored_mask:0 ...
+//	fanotify mnt_id:1d mflags:0 mask:3b ignored_mask:0
+//
+// Every mark field is printed in hex, the watch descriptor and mount ID
+// included. The fanotify group header ("fanotify flags:... event-flags:...")
+// has no mask and is skipped.
+func parseWatchMarks(content string) []watchMark {
+	var marks []watchMark
+	for _, line := range strings.Split(content, "\n") {
+		api, rest, ok := strings.Cut(line, " ")
+		if !ok || (api != "inotify" && api != "fanotify") {
+			continue
+		}
+		m := watchMark{API: api}
+		hasMask := false
+		for _, field := range strings.Fields(rest) {
+			key, value, ok := strings.Cut(field, ":")
+			if !ok {
+				continue
+			}
+			switch key {
+			case "wd":
+				wd, _ := strconv.ParseInt(value, 16, 0)
+				m.WD = int(wd)
+			case "ino":
+				m.Inode, _ = strconv.ParseUint(value, 16, 64)
+			case "sdev":
+				m.Dev, _ = strconv.ParseUint(value, 16, 64)
+			case "mnt_id":
+				id, _ := strconv.ParseInt(value, 16, 0)
+				m.MntID = int(id)
+			case "mask":
+				m.Mask, _ = strconv.ParseUint(value, 16, 64)
+				hasMask = true
+			}
+		}
+		if hasMask {
+			marks = append(marks, m)
+		}
+	}
+	return marks
+}
+
+// allEvents is IN_ALL_EVENTS: every event bit shared by both APIs.
+const allEvents = 0xfff
+
+// watchEvents names the bits set in an inotify or fanotify mask, with
+// "all events" standing for IN_ALL_EVENTS. Bits witr doesn't know are shown
+// in hex.
+func watchEvents(api string, mask uint64) []string {
+	extra := inotifyEvents
+	if api == "fanotify" {
+		extra = fanotifyEvents
+	}
+	var names []string
+	known := uint64(0)
+	for _, table := range [][]watchEvent{fsnotifyEvents, extra} {
+		for _, e := range table {
+			known |= e.bit
+			if mask&e.bit != 0 && (mask&allEvents != allEvents || e.bit&allEvents == 0) {
+				names = append(names, e.name)
+			}
+		}
+		if mask&allEvents == allEvents && len(names) == 0 {
+			names = append(names, "all events")
+		}
+	}
+	if rest := mask &^ known; rest != 0 {
+		names = append(names, fmt.Sprintf("0x%x", rest))
+	}
+	return names
+}
@@ -0,0 +1,112 @@
+//go:build linux
+
+package proc
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"golang.org/x/sys/unix"
+)
+
+func TestParseWatchMarks(t *testing.T) {
+	inotify := "pos:\t0\nflags:\t02004000\nmnt_id:\t15\nino:\t1057\n" +
+		"inotify wd:a ino:2a0f sdev:800001 mask:fce ignored_mask:0 fhandle-bytes:8 fhandle-type:1 f_handle:0f2a000000000000\n" +
+		"inotify wd:10 ino:2a10 sdev:800001 mask:2 ignored_mask:0 fhandle-bytes:8 fhandle-type:1 f_handle:102a000000000000\n" +
+		"inotify wd:1 ino:2 sdev:fd00000 mask:100 ignored_mask:0 fhandle-bytes:8 fhandle-type:1 f_handle:0200000000000000\n"
+	want := []watchMark{
+		{API: "inotify", WD: 10, Inode: 0x2a0f, Dev: 0x800001, Mask: 0xfce},
+		{API: "inotify", WD: 16, Inode: 0x2a10, Dev: 0x800001, Mask: 0x2},
+		{API: "inotify", WD: 1, Inode: 2, Dev: 0xfd00000, Mask: 0x100},
+	}
+	if got := parseWatchMarks(inotify); !reflect.DeepEqual(got, want) {
+		t.Errorf("inotify marks = %+v, want %+v", got, want)
+	}
+
+	fanotify := "pos:\t0\nflags:\t02\nmnt_id:\t15\n" +
+		"fanotify flags:10 event-flags:0\n" +
+		"fanotify ino:2a0f sdev:800001 mflags:0 mask:8000003 ignored_mask:0 fhandle-bytes:8 fhandle-type:1 f_handle:0f2a000000000000\n" +
+		"fanotify mnt_id:1d mflags:0 mask:10020 ignored_mask:0\n" +
+		"fanotify sdev:800001 mflags:0 mask:8 ignored_mask:0\n"
+	want = []watchMark{
+		{API: "fanotify", Inode: 0x2a0f, Dev: 0x800001, Mask: 0x8000003},
+		{API: "fanotify", MntID: 29, Mask: 0x10020},
+		{API: "fanotify", Dev: 0x800001, Mask: 0x8},
+	}
+	if got := parseWatchMarks(fanotify); !reflect.DeepEqual(got, want) {
+		t.Errorf("fanotify marks = %+v, want %+v", got, want)
+	}
+}
+
+func TestWatchScope(t *testing.T) {
+	target := watchTarget{inode: 0x2a0f, parentInode: 0x2a00, major: 8, minor: 1, parentMajor: 8, parentMinor: 1, mountID: 29}
+	tests := []struct {
+		mark watchMark
+		want string
+	}{
+		{watchMark{API: "inotify", Inode: 0x2a0f, Dev: 8<<20 | 1}, "path"},
+		{watchMark{API: "inotify", Inode: 0x2a00, Dev: 8<<20 | 1}, "parent"},
+		{watchMark{API: "inotify", Inode: 0x2a0f, Dev: 8<<20 | 2}, ""}, // same inode, other device
+		{watchMark{API: "fanotify", MntID: 29}, "mount"},
+		{watchMark{API: "fanotify", MntID: 30}, ""},
+		{watchMark{API: "fanotify", Dev: 8<<20 | 1}, "filesystem"},
+		{watchMark{API: "inotify", Dev: 8<<20 | 1}, ""},
+	}
+	for _, tt := range tests {
+		if got := target.scope(tt.mark); got != tt.want {
+			t.Errorf("scope(%+v) = %q, want %q", tt.mark, got, tt.want)
+		}
+	}
+}
+
+func TestWatchEvents(t *testing.T) {
+	tests := []struct {
+		api  string
+		mask uint64
+		want []string
+	}{
+		{"inotify", 0x2 | 0x8 | 0x100 | 0x200, []string{"modify", "close_write", "create", "delete"}},
+		{"inotify", 0xfff | 0x1000000, []string{"all events", "onlydir"}},
+		{"fanotify", 0x20 | 0x10000 | 0x8000000, []string{"open", "open_perm", "event_on_child"}},
+		{"inotify", 0x400000, []string{"0x400000"}},
+	}
+	for _, tt := range tests {
+		if got := watchEvents(tt.api, tt.mask); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("watchEvents(%s, %#x) = %q, want %q", tt.api, tt.mask, got, tt.want)
+		}
+	}
+}
+
+func TestScanWatchersFindsOwnInotifyWatch(t *testing.T) {
+	dir := t.TempDir()
+	file := filepath.Join(dir, "watched.txt")
+	if err := os.WriteFile(file, nil, 0o644); err != nil {
+		t.Fatal(err)
+	}
+	fd, err := unix.InotifyInit1(unix.IN_CLOEXEC)
+	if err != nil {
+		t.Skipf("inotify unavailable: %v", err)
+	}
+	defer unix.Close(fd)
+	if _, err := unix.InotifyAddWatch(fd, dir, unix.IN_CREATE|unix.IN_DELETE); err != nil {
+		t.Fatal(err)
+	}
+
+	result, err := ScanWatchers(file)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, w := range result.Watchers {
+		if w.PID != os.Getpid() {
+			continue
+		}
+		if len(w.Watches) != 1 || w.Watches[0].FD != fd || w.Watches[0].Scope != "parent" ||
+			!reflect.DeepEqual(w.Watches[0].Events, []string{"create", "delete"}) {
+			t.Errorf("unexpected watches: %+v", w.Watches)
+		}
+		return
+	}
+	t.Errorf("own watch on %s not found: %+v", dir, result)
+}
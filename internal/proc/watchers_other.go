@@ -0,0 +1,16 @@
+//go:build !linux
+
+package proc
+
+import (
+	"fmt"
+	"runtime"
+
+	"github.com/pranshuparmar/witr/pkg/model"
+)
+
+// ScanWatchers is only supported on Linux, where inotify and fanotify
+// watches are visible in /proc/<pid>/fdinfo.
+func ScanWatchers(path string) (model.FileWatchers, error) {
+	return model.FileWatchers{}, fmt.Errorf("watcher discovery is not supported on %s", runtime.GOOS)
+}
@@ -0,0 +1,56 @@
+package source
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/pranshuparmar/witr/pkg/model"
+)
+
+// blockingWarnings flags a process that stayed in uninterruptible sleep for
+// every sample, and one that stayed blocked on a network or FUSE filesystem
+// for every sample, where a server or daemon that stops answering hangs it
+// indefinitely.
+func blockingWarnings(b *model.BlockingInfo) []string {
+	if b == nil {
+		return nil
+	}
+	var w []string
+
+	if b.Stuck() {
+		msg := fmt.Sprintf("Process is stuck in uninterruptible sleep (D state) across %d samples", b.Samples)
+		if where := b.WaitChannel; where != "" {
+			msg += " in " + where
+		} else if b.Syscall != "" {
+			msg += " in " + b.Syscall
+		}
+		if b.WaitingOn != "" {
+			msg += ", waiting on " + b.WaitingOn
+		}
+		w = append(w, msg+"; it cannot be killed until the call returns")
+	}
+
+	if b.StuckOnFS() {
+		label := networkFSLabel(b.FSType)
+		if b.Mount != "" {
+			w = append(w, fmt.Sprintf("Process is blocked on %s mount %s (%s) across %d samples; a hung server or filesystem daemon keeps it waiting",
+				label, b.Mount, b.FSType, b.Samples))
+		} else {
+			w = append(w, fmt.Sprintf("Process is blocked in %s filesystem code across %d samples; a hung server or filesystem daemon keeps it waiting", label, b.Samples))
+		}
+	}
+	return w
+}
+
+// networkFSLabel names a filesystem type the way users refer to it.
+func networkFSLabel(fsType string) string {
+	switch {
+	case strings.HasPrefix(fsType, "nfs"):
+		return "NFS"
+	case strings.HasPrefix(fsType, "fuse"):
+		return "FUSE"
+	case fsType == "cifs" || strings.HasPrefix(fsType, "smb"):
+		return "SMB"
+	}
+	return fsType
+}
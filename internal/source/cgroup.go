@@ -0,0 +1,51 @@
+package source
+
+import (
+	"fmt"
+
+	"github.com/pranshuparmar/witr/pkg/model"
+)
+
+const (
+	// memoryLimitWarnPercent is how close to its cgroup memory.max a process
+	// may get before witr warns that the OOM killer is near.
+	memoryLimitWarnPercent = 90
+	// throttleWarnPercent is the share of CFS periods spent throttled above
+	// which the cgroup counts as CPU-starved by its quota.
+	throttleWarnPercent = 10
+)
+
+// cgroupWarnings flags cgroup v2 limits the process is running into.
+func cgroupWarnings(cg *model.CgroupStats) []string {
+	if cg == nil {
+		return nil
+	}
+	var w []string
+
+	// An inherited limit is measured against the ancestor's usage, which
+	// includes the process's siblings, and is named as such.
+	switch {
+	case cg.MemoryMax > 0 && cg.MemoryMaxPath != "":
+		pct := float64(cg.MemoryMaxCurrent) / float64(cg.MemoryMax) * 100
+		if pct >= memoryLimitWarnPercent {
+			w = append(w, fmt.Sprintf("Parent cgroup %s is at %.0f%% of its memory limit", cg.MemoryMaxPath, pct))
+		}
+	case cg.MemoryMax > 0:
+		pct := float64(cg.MemoryCurrent) / float64(cg.MemoryMax) * 100
+		if pct >= memoryLimitWarnPercent {
+			w = append(w, fmt.Sprintf("Process cgroup is at %.0f%% of its memory limit", pct))
+		}
+	}
+
+	if cg.NrPeriods > 0 && cg.NrThrottled > 0 {
+		pct := float64(cg.NrThrottled) / float64(cg.NrPeriods) * 100
+		if pct >= throttleWarnPercent {
+			w = append(w, fmt.Sprintf("Process cgroup is CPU-throttled in %.0f%% of scheduling periods", pct))
+		}
+	}
+
+	if cg.OOMKills > 0 {
+		w = append(w, fmt.Sprintf("Process cgroup has recorded %d OOM kill(s)", cg.OOMKills))
+	}
+	return w
+}
@@ -50,21 +50,16 @@ func detectContainer(ancestry []model.Process) *model.Source {
 		}
 	}
 
-	// Snap/Flatpak sandbox detection via environment variables
+	// Snap/Flatpak sandbox detection via environment variables, enriched
+	// with the package metadata each sandbox exposes.
 	if len(ancestry) > 0 {
 		target := ancestry[len(ancestry)-1]
 		for _, e := range target.Env {
-			if strings.HasPrefix(e, "SNAP_NAME=") {
-				return &model.Source{
-					Type: model.SourceContainer,
-					Name: "snap",
-				}
+			if name, ok := strings.CutPrefix(e, "SNAP_NAME="); ok {
+				return detectSnap(target, name)
 			}
-			if strings.HasPrefix(e, "FLATPAK_ID=") {
-				return &model.Source{
-					Type: model.SourceContainer,
-					Name: "flatpak",
-				}
+			if appID, ok := strings.CutPrefix(e, "FLATPAK_ID="); ok {
+				return detectFlatpak(target, appID)
 			}
 		}
 	}
@@ -204,6 +204,9 @@ func Warnings(p []model.Process, restartCount int, srcType ...model.SourceType)
 		w = append(w, "No known supervisor or service manager detected")
 	}
 
+	// Credential elevation and unconfined root services
+	w = append(w, securityWarnings(last.Security, st)...)
+
 	// Warn if process is very old (>90 days). A zero start time means we
 	// couldn't read it (e.g. protected Windows processes), not that the process
 	// is ancient — skip the warning rather than emit a false positive.
@@ -240,11 +243,32 @@ func Warnings(p []model.Process, restartCount int, srcType ...model.SourceType)
 		}
 	}
 
-	// Warn if binary is deleted
-	if last.ExeDeleted {
+	// Warn if binary is deleted. A memfd executable is always reported as
+	// deleted; identityWarnings explains it instead.
+	if last.ExeDeleted && !exeIsMemfd(last) {
 		w = append(w, "Process is running from a deleted binary (potential library injection or pending update)")
 	}
 
+	// Warn if the process disguises what it runs: fileless or temp-dir
+	// executables, kernel-thread or daemon lookalikes, or a name that
+	// doesn't match the executable
+	w = append(w, identityWarnings(last)...)
+
+	// Warn if shared libraries were upgraded underneath the process
+	if msg := staleLibrariesWarning(last.Libraries); msg != "" {
+		w = append(w, msg)
+	}
+
+	// Warn when a soft resource limit is nearly used up
+	w = append(w, limitsWarnings(last.Limits)...)
+
+	// Warn when the process is hung in the kernel or on a remote filesystem
+	w = append(w, blockingWarnings(last.Blocking)...)
+
+	// Warn when the cgroup's limits are biting: memory near memory.max, CPU
+	// quota throttling, or OOM kills already recorded
+	w = append(w, cgroupWarnings(last.Cgroup)...)
+
 	// Include warnings based on suspicious env variables
 	w = append(w, envSuspiciousWarnings(last.Env)...)
 
@@ -0,0 +1,245 @@
+package source
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/pranshuparmar/witr/pkg/model"
+)
+
+// wellKnownDaemons are process names malware likes to borrow so it blends
+// into a process listing.
+var wellKnownDaemons = map[string]bool{
+	"sshd": true, "systemd": true, "init": true, "cron": true, "crond": true,
+	"atd": true, "rsyslogd": true, "syslogd": true, "systemd-journald": true,
+	"systemd-logind": true, "systemd-resolved": true, "systemd-networkd": true,
+	"systemd-udevd": true, "dbus-daemon": true, "dbus-broker": true,
+	"NetworkManager": true, "polkitd": true, "auditd": true, "agetty": true,
+	"chronyd": true, "ntpd": true, "containerd": true, "dockerd": true,
+	"kubelet": true, "nginx": true, "httpd": true, "apache2": true,
+	"mysqld": true, "mariadbd": true, "postgres": true, "redis-server": true,
+}
+
+// systemExePrefixes are the locations packaged software is installed to.
+var systemExePrefixes = []string{
+	"/usr/", "/bin/", "/sbin/", "/lib/", "/lib64/", "/opt/", "/snap/", "/nix/store/", "/gnu/store/",
+}
+
+// tempExeDirs are world-writable scratch directories, where droppers put
+// their payloads.
+var tempExeDirs = []string{"/tmp/", "/var/tmp/", "/dev/shm/", "/run/shm/"}
+
+// kernelThreadPrefixes are names of common kernel threads, which have no
+// executable of their own.
+var kernelThreadPrefixes = []string{"kworker/", "ksoftirqd/", "kthreadd", "kswapd", "migration/", "rcu_", "watchdog/"}
+
+// multiCallBinaries run as whatever applet name they are invoked by.
+var multiCallBinaries = map[string]bool{"busybox": true, "toybox": true}
+
+// interpreters run scripts that are started by name from PATH. A script's
+// process keeps the script's name as comm and argv[0], but its executable is
+// the interpreter. Versioned binaries such as python3.11 are matched on the
+// name without the version.
+var interpreters = map[string]bool{
+	"python": true, "node": true, "nodejs": true, "ruby": true, "perl": true,
+	"php": true, "lua": true, "bun": true, "deno": true, "java": true,
+}
+
+// defaultPath is searched for a bare command name when the process's own
+// PATH is unknown.
+const defaultPath = "/usr/local/sbin:/usr/local/bin:/usr/sbin:/usr/bin:/sbin:/bin"
+
+// identityWarnings flags a process that disguises what it is running: one
+// executing from an anonymous memory file or a temporary directory, one
+// posing as a kernel thread or a system daemon, and one whose name and
+// argv[0] both disagree with its executable while borrowing the name of a
+// system daemon or binary. Titles set with setproctitle or process.title
+// name no installed program and are left alone. It needs the executable
+// path, which is only known on Linux and Windows; Windows paths are skipped.
+func identityWarnings(p model.Process) []string {
+	if !strings.HasPrefix(p.Exe, "/") {
+		return nil
+	}
+	var w []string
+	argv0 := commandArgv0(p.Cmdline)
+
+	if exeIsMemfd(p) {
+		name := strings.TrimPrefix(p.Exe, "/memfd:")
+		w = append(w, fmt.Sprintf("Process is running from an anonymous memory file (memfd:%s) with no file on disk; fileless execution is a common malware technique", name))
+	}
+
+	label, kthread := kernelThreadName(p)
+	if kthread {
+		w = append(w, fmt.Sprintf("Process poses as a kernel thread (%q) but runs %s", label, p.Exe))
+	}
+
+	trusted := hasAnyPrefix(p.Exe, systemExePrefixes)
+	daemon := ""
+	for _, name := range []string{p.Command, filepath.Base(argv0)} {
+		if wellKnownDaemons[name] {
+			daemon = name
+			break
+		}
+	}
+	switch {
+	case daemon != "" && !trusted && !exeIsMemfd(p):
+		w = append(w, fmt.Sprintf("Process is named like the system daemon %s but runs from %s", daemon, p.Exe))
+	case hasAnyPrefix(p.Exe, tempExeDirs):
+		w = append(w, "Process is running an executable from a world-writable temporary directory: "+p.Exe)
+	}
+
+	// Symlinks a name may resolve through (sh -> dash) live in the process's
+	// own filesystem, which for a container isn't the one witr sees.
+	inContainer := p.ContainerID != "" || p.Container != ""
+	if !kthread && !inContainer && !exeIsMemfd(p) &&
+		!nameMatchesExe(p.Command, p, true) && !nameMatchesExe(argv0, p, false) &&
+		(daemon != "" || namesSystemBinary(p.Command) || namesSystemBinary(argv0)) {
+		w = append(w, fmt.Sprintf("Process name does not match its executable: runs as %q (argv[0] %q) but executes %s", p.Command, argv0, p.Exe))
+	}
+	return w
+}
+
+// commandArgv0 returns argv[0] from a space-joined command line, without
+// the "-" login shells prepend or the ":" that ends a title such as
+// "sshd: alice@pts/0".
+func commandArgv0(cmdline string) string {
+	fields := strings.Fields(cmdline)
+	if len(fields) == 0 {
+		return ""
+	}
+	return strings.TrimSuffix(strings.TrimPrefix(fields[0], "-"), ":")
+}
+
+// kernelThreadName returns the kernel-thread name a process shows, if any:
+// a bracketed argv[0] such as "[kworker/0:1]", as ps prints real kernel
+// threads, or a comm such as "kworker/0:1". Real kernel threads have no
+// executable, so the caller only asks when there is one.
+func kernelThreadName(p model.Process) (string, bool) {
+	if cmdline := strings.TrimSpace(p.Cmdline); strings.HasPrefix(cmdline, "[") {
+		if end := strings.Index(cmdline, "]"); end > 0 {
+			return cmdline[:end+1], true
+		}
+	}
+	if hasAnyPrefix(p.Command, kernelThreadPrefixes) {
+		return p.Command, true
+	}
+	return "", false
+}
+
+// nameMatchesExe reports whether a process name or argv[0] is explained by
+// its executable: the same base name, a multi-call binary, the kernel's
+// 15-character truncation of comm, a path that resolves to the executable
+// through symlinks (e.g. /bin/sh -> dash), or, when the executable is an
+// interpreter, a script of that name (gunicorn run by python3). An empty
+// name can't disagree with anything.
+func nameMatchesExe(name string, p model.Process, isComm bool) bool {
+	if name == "" || name == "/proc/self/exe" {
+		return true
+	}
+	exeBase := filepath.Base(p.Exe)
+	base := filepath.Base(name)
+	if base == exeBase || multiCallBinaries[exeBase] {
+		return true
+	}
+	if isComm && len(name) == 15 && strings.HasPrefix(exeBase, name) {
+		return true
+	}
+
+	var candidates []string
+	switch {
+	case filepath.IsAbs(name):
+		candidates = []string{name}
+	case strings.Contains(name, "/"):
+		if p.WorkingDir == "" {
+			return true // relative to a directory we don't know
+		}
+		candidates = []string{filepath.Join(p.WorkingDir, name)}
+	default:
+		path := defaultPath
+		for _, kv := range p.Env {
+			if v, ok := strings.CutPrefix(kv, "PATH="); ok {
+				path = v
+			}
+		}
+		for _, dir := range filepath.SplitList(path) {
+			if dir != "" {
+				candidates = append(candidates, filepath.Join(dir, name))
+			}
+		}
+	}
+	interpreter := isInterpreter(exeBase)
+	for _, c := range candidates {
+		resolved, err := filepath.EvalSymlinks(c)
+		if err != nil {
+			continue
+		}
+		if resolved == p.Exe || interpreter && isScript(resolved) {
+			return true
+		}
+	}
+	return false
+}
+
+// namesSystemBinary reports whether name is an installed program: an
+// absolute path under a system location, or a bare command found on the
+// default PATH.
+func namesSystemBinary(name string) bool {
+	var candidates []string
+	switch {
+	case name == "":
+		return false
+	case filepath.IsAbs(name):
+		if !hasAnyPrefix(name, systemExePrefixes) {
+			return false
+		}
+		candidates = []string{name}
+	case strings.Contains(name, "/"):
+		return false
+	default:
+		for _, dir := range filepath.SplitList(defaultPath) {
+			candidates = append(candidates, filepath.Join(dir, name))
+		}
+	}
+	for _, c := range candidates {
+		if fi, err := os.Stat(c); err == nil && fi.Mode().IsRegular() {
+			return true
+		}
+	}
+	return false
+}
+
+// isInterpreter reports whether an executable's base name is a shell or a
+// script interpreter, ignoring a version suffix ("python3.11").
+func isInterpreter(exeBase string) bool {
+	return isShell(exeBase) || interpreters[strings.TrimRight(exeBase, "0123456789.")]
+}
+
+// isScript reports whether path is a file starting with a "#!" line.
+func isScript(path string) bool {
+	f, err := os.Open(path)
+	if err != nil {
+		return false
+	}
+	defer f.Close()
+	magic := make([]byte, 2)
+	_, err = io.ReadFull(f, magic)
+	return err == nil && string(magic) == "#!"
+}
+
+func hasAnyPrefix(s string, prefixes []string) bool {
+	for _, prefix := range prefixes {
+		if strings.HasPrefix(s, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// exeIsMemfd reports whether the executable is an anonymous memory file,
+// which the kernel always reports as deleted.
+func exeIsMemfd(p model.Process) bool {
+	return strings.HasPrefix(p.Exe, "/memfd:")
+}
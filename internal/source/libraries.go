@@ -0,0 +1,38 @@
+package source
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/pranshuparmar/witr/pkg/model"
+)
+
+// maxNamedLibraries caps how many stale libraries the warning names.
+const maxNamedLibraries = 3
+
+// staleLibrariesWarning flags a process still running shared libraries that
+// have been deleted or replaced on disk, typically by a package upgrade: the
+// fix (often a security one) only takes effect after a restart.
+func staleLibrariesWarning(libs []model.Library) string {
+	var names []string
+	for _, lib := range libs {
+		if lib.Stale() {
+			names = append(names, filepath.Base(lib.Path))
+		}
+	}
+	if len(names) == 0 {
+		return ""
+	}
+
+	count := len(names)
+	noun := "library"
+	if count > 1 {
+		noun = "libraries"
+	}
+	if count > maxNamedLibraries {
+		names = append(names[:maxNamedLibraries], fmt.Sprintf("+%d more", count-maxNamedLibraries))
+	}
+	return fmt.Sprintf("Process still uses %d deleted or replaced shared %s (%s); restart it to pick up the update",
+		count, noun, strings.Join(names, ", "))
+}
@@ -0,0 +1,69 @@
+package source
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/pranshuparmar/witr/pkg/model"
+)
+
+// limitWarnPercent is how much of a soft resource limit a process may use
+// before witr warns that it is about to run into it.
+const limitWarnPercent = 80
+
+// unenforcedLimits are still listed by the kernel but no longer enforced.
+var unenforcedLimits = map[string]bool{"Max resident set": true}
+
+// limitsWarnings flags soft resource limits the process has nearly used up
+// (or already exceeds, after the limit was lowered underneath it), naming
+// the hard limit when the soft one can simply be raised.
+func limitsWarnings(limits []model.ResourceLimit) []string {
+	var w []string
+	for _, l := range limits {
+		if l.Usage == nil || unenforcedLimits[l.Name] {
+			continue
+		}
+		soft, ok := l.SoftValue()
+		if !ok || soft == 0 {
+			continue
+		}
+		pct := float64(*l.Usage) / float64(soft) * 100
+		if pct < limitWarnPercent {
+			continue
+		}
+		msg := fmt.Sprintf("Process is at %.0f%% of its soft limit on %s (%s of %s)",
+			pct, limitNoun(l.Name), formatLimit(*l.Usage, l.Unit), formatLimit(soft, l.Unit))
+		if hard, ok := l.HardValue(); !ok {
+			msg += "; the hard limit is unlimited"
+		} else if hard > soft {
+			msg += "; the hard limit allows " + formatLimit(hard, l.Unit)
+		}
+		w = append(w, msg)
+	}
+	return w
+}
+
+// limitNoun turns a limits row name ("Max open files") into the resource it
+// limits ("open files").
+func limitNoun(name string) string {
+	return strings.TrimPrefix(name, "Max ")
+}
+
+// formatLimit renders a limit or usage value, with byte counts in binary
+// units.
+func formatLimit(v uint64, unit string) string {
+	if unit != "bytes" {
+		return strconv.FormatUint(v, 10)
+	}
+	const k = 1024
+	if v < k {
+		return fmt.Sprintf("%d B", v)
+	}
+	div, exp := uint64(k), 0
+	for m := v / k; m >= k; m /= k {
+		div *= k
+		exp++
+	}
+	return fmt.Sprintf("%.1f %cB", float64(v)/float64(div), "KMGTPE"[exp])
+}
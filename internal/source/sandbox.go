@@ -0,0 +1,396 @@
+package source
+
+import (
+	"bufio"
+	"context"
+	"os"
+	"os/exec"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pranshuparmar/witr/pkg/model"
+)
+
+// sandboxCmdTimeout bounds the `snap` CLI lookups used to enrich a snap
+// source. Both are best-effort and skipped when snapd isn't installed.
+const sandboxCmdTimeout = 2 * time.Second
+
+// snapCommand runs the snap CLI; replaced in tests.
+var snapCommand = func(args ...string) ([]byte, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), sandboxCmdTimeout)
+	defer cancel()
+	return exec.CommandContext(ctx, "snap", args...).Output()
+}
+
+// snapCLIInfo is what the snap CLI reports about one installed snap.
+type snapCLIInfo struct {
+	Row     snapListRow
+	Listed  bool
+	Granted []string
+}
+
+// snapCLICache holds the snap CLI results per snap name for the rest of the
+// run: a multi-process snap, or a scan that detects the source of every
+// process, would otherwise run both commands once per process.
+var (
+	snapCLICacheMu sync.Mutex
+	snapCLICache   = map[string]snapCLIInfo{}
+)
+
+// lookupSnapCLI runs `snap list` and `snap connections` for name, once per
+// run. A failed or timed-out lookup is cached as well.
+func lookupSnapCLI(name string) snapCLIInfo {
+	snapCLICacheMu.Lock()
+	defer snapCLICacheMu.Unlock()
+	if info, ok := snapCLICache[name]; ok {
+		return info
+	}
+	var info snapCLIInfo
+	if out, err := snapCommand("list", "--unicode=never", name); err == nil {
+		info.Row, info.Listed = parseSnapList(string(out), name)
+	}
+	if out, err := snapCommand("connections", name); err == nil {
+		info.Granted = parseSnapConnections(string(out))
+	}
+	snapCLICache[name] = info
+	return info
+}
+
+// snapMeta is the subset of meta/snap.yaml that witr reports.
+type snapMeta struct {
+	Version     string
+	Confinement string
+	Plugs       []string
+}
+
+// flatpakInfo is the subset of /.flatpak-info that witr reports.
+type flatpakInfo struct {
+	App         string
+	Runtime     string
+	Branch      string
+	Permissions []string
+	Denied      []string
+}
+
+// detectSnap builds a snap source from the target's SNAP_* environment, the
+// installed snap.yaml and (when available) `snap list`/`snap connections`.
+// Every lookup is best-effort: a missing file or CLI just leaves the detail out.
+func detectSnap(target model.Process, name string) *model.Source {
+	src := &model.Source{
+		Type:    model.SourceContainer,
+		Name:    "snap",
+		Details: map[string]string{},
+	}
+	if name == "" {
+		return src
+	}
+
+	env := []model.Process{target}
+	revision := findEnvVar(env, "SNAP_REVISION")
+	version := findEnvVar(env, "SNAP_VERSION")
+
+	metaDir := "/snap/" + name + "/current"
+	if revision != "" {
+		metaDir = "/snap/" + name + "/" + revision
+	}
+	var meta snapMeta
+	if data, err := os.ReadFile(metaDir + "/meta/snap.yaml"); err == nil {
+		meta = parseSnapYAML(string(data))
+	}
+	if version == "" {
+		version = meta.Version
+	}
+	confinement := meta.Confinement
+
+	cli := lookupSnapCLI(name)
+	if row := cli.Row; cli.Listed {
+		if revision == "" {
+			revision = row.Rev
+		}
+		if version == "" {
+			version = row.Version
+		}
+		if row.Tracking != "" && row.Tracking != "-" {
+			src.Details["channel"] = row.Tracking
+		}
+		// Notes reflect how the snap was actually installed, which can
+		// differ from snap.yaml (e.g. `snap install --devmode`).
+		if c := confinementFromNotes(row.Notes); c != "" {
+			confinement = c
+		}
+	}
+
+	permissions := meta.Plugs
+	if len(cli.Granted) > 0 {
+		permissions = cli.Granted
+	}
+
+	src.Description = "snap " + name
+	if version != "" {
+		src.Description += " " + version
+	}
+	if revision != "" {
+		src.Details["revision"] = revision
+	}
+	if confinement != "" {
+		src.Details["confinement"] = confinement
+	}
+	if len(permissions) > 0 {
+		src.Details["permissions"] = strings.Join(permissions, ", ")
+	}
+	return src
+}
+
+// detectFlatpak builds a flatpak source from the sandbox's /.flatpak-info,
+// read through /proc/<pid>/root so it resolves inside the sandbox's mount
+// namespace.
+func detectFlatpak(target model.Process, appID string) *model.Source {
+	src := &model.Source{
+		Type:    model.SourceContainer,
+		Name:    "flatpak",
+		Details: map[string]string{},
+	}
+
+	info := flatpakInfo{App: appID}
+	if data, err := os.ReadFile("/proc/" + itoa(target.PID) + "/root/.flatpak-info"); err == nil {
+		info = parseFlatpakInfo(string(data))
+		if info.App == "" {
+			info.App = appID
+		}
+	}
+
+	if info.App != "" {
+		src.Description = "flatpak " + info.App
+	}
+	if info.Branch != "" {
+		src.Details["branch"] = info.Branch
+	}
+	if info.Runtime != "" {
+		src.Details["runtime"] = info.Runtime
+	}
+	if len(info.Permissions) > 0 {
+		src.Details["permissions"] = strings.Join(info.Permissions, ", ")
+	}
+	if len(info.Denied) > 0 {
+		src.Details["denied"] = strings.Join(info.Denied, ", ")
+	}
+	return src
+}
+
+// parseSnapYAML extracts version, confinement and the declared plug
+// names from a snap.yaml. It reads only the keys it needs rather than pulling
+// in a YAML parser: top-level scalars, the top-level plugs map, and the plugs
+// lists under each app.
+func parseSnapYAML(data string) snapMeta {
+	var meta snapMeta
+	plugs := map[string]bool{}
+
+	section := ""    // current top-level key
+	inAppPlugs := -1 // indent of an app's "plugs:" key, or -1
+	scanner := bufio.NewScanner(strings.NewReader(data))
+	for scanner.Scan() {
+		line := strings.TrimRight(scanner.Text(), " \t\r")
+		trimmed := strings.TrimSpace(line)
+		if trimmed == "" || strings.HasPrefix(trimmed, "#") {
+			continue
+		}
+		indent := len(line) - len(strings.TrimLeft(line, " "))
+
+		if indent == 0 {
+			inAppPlugs = -1
+			key, value, _ := strings.Cut(trimmed, ":")
+			section = key
+			value = unquoteYAML(value)
+			switch key {
+			case "version":
+				meta.Version = value
+			case "confinement":
+				meta.Confinement = value
+			case "plugs":
+				for _, p := range yamlInlineList(value) {
+					plugs[p] = true
+				}
+			}
+			continue
+		}
+
+		switch section {
+		case "plugs":
+			// Top-level plugs map: the first indented level names the plug.
+			if key, _, ok := strings.Cut(trimmed, ":"); ok && !strings.HasPrefix(trimmed, "-") && indent <= 2 {
+				plugs[key] = true
+			}
+		case "apps":
+			if inAppPlugs >= 0 && indent <= inAppPlugs {
+				inAppPlugs = -1
+			}
+			if inAppPlugs >= 0 {
+				if item, ok := strings.CutPrefix(trimmed, "-"); ok {
+					plugs[unquoteYAML(item)] = true
+				}
+				continue
+			}
+			if value, ok := strings.CutPrefix(trimmed, "plugs:"); ok {
+				if strings.TrimSpace(value) == "" {
+					inAppPlugs = indent
+				}
+				for _, p := range yamlInlineList(value) {
+					plugs[p] = true
+				}
+			}
+		}
+	}
+
+	for p := range plugs {
+		if p != "" {
+			meta.Plugs = append(meta.Plugs, p)
+		}
+	}
+	sort.Strings(meta.Plugs)
+	return meta
+}
+
+// yamlInlineList parses a flow sequence such as "[network, home]".
+func yamlInlineList(value string) []string {
+	value = strings.TrimSpace(value)
+	if !strings.HasPrefix(value, "[") || !strings.HasSuffix(value, "]") {
+		return nil
+	}
+	var items []string
+	for _, item := range strings.Split(value[1:len(value)-1], ",") {
+		if item = unquoteYAML(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+func unquoteYAML(s string) string {
+	s = strings.TrimSpace(s)
+	if len(s) >= 2 && (s[0] == '"' || s[0] == '\'') && s[len(s)-1] == s[0] {
+		return s[1 : len(s)-1]
+	}
+	return s
+}
+
+// snapListRow is one row of `snap list` output.
+type snapListRow struct {
+	Version  string
+	Rev      string
+	Tracking string
+	Notes    string
+}
+
+// parseSnapList finds the row for name in `snap list` output, whose columns
+// are: Name Version Rev Tracking Publisher Notes.
+func parseSnapList(out, name string) (snapListRow, bool) {
+	for i, line := range strings.Split(out, "\n") {
+		fields := strings.Fields(line)
+		if i == 0 || len(fields) < 4 || fields[0] != name {
+			continue
+		}
+		row := snapListRow{Version: fields[1], Rev: fields[2], Tracking: fields[3]}
+		if len(fields) >= 6 {
+			row.Notes = fields[5]
+		}
+		return row, true
+	}
+	return snapListRow{}, false
+}
+
+// confinementFromNotes maps the Notes column of `snap list` to the effective
+// confinement, or "" when the snap is strictly confined.
+func confinementFromNotes(notes string) string {
+	for _, n := range strings.Split(notes, ",") {
+		switch n {
+		case "classic", "devmode", "jailmode":
+			return n
+		}
+	}
+	return ""
+}
+
+// parseSnapConnections returns the interfaces with a connected slot from
+// `snap connections` output (columns: Interface Plug Slot Notes).
+func parseSnapConnections(out string) []string {
+	seen := map[string]bool{}
+	var granted []string
+	for i, line := range strings.Split(out, "\n") {
+		fields := strings.Fields(line)
+		if i == 0 || len(fields) < 3 || fields[2] == "-" {
+			continue
+		}
+		if !seen[fields[0]] {
+			seen[fields[0]] = true
+			granted = append(granted, fields[0])
+		}
+	}
+	sort.Strings(granted)
+	return granted
+}
+
+// parseFlatpakInfo reads the keyfile flatpak writes to /.flatpak-info inside
+// every sandbox. Permissions are the [Context] entries (shared, sockets,
+// devices, filesystems, ...) rendered as "key=value" so the kind of access
+// stays visible. An entry negated with "!" (e.g. "sockets=!x11", from
+// `flatpak override --nosocket=x11`) is listed under Denied instead.
+func parseFlatpakInfo(data string) flatpakInfo {
+	var info flatpakInfo
+	group := ""
+	for _, line := range strings.Split(data, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		if strings.HasPrefix(line, "[") && strings.HasSuffix(line, "]") {
+			group = line[1 : len(line)-1]
+			continue
+		}
+		key, value, ok := strings.Cut(line, "=")
+		if !ok {
+			continue
+		}
+		switch group {
+		case "Application":
+			switch key {
+			case "name":
+				info.App = value
+			case "runtime":
+				info.Runtime = strings.TrimPrefix(value, "runtime/")
+			}
+		case "Instance":
+			if key == "branch" {
+				info.Branch = value
+			}
+		case "Context":
+			for _, v := range strings.Split(value, ";") {
+				if denied, ok := strings.CutPrefix(v, "!"); ok {
+					if denied != "" {
+						info.Denied = append(info.Denied, key+"="+denied)
+					}
+				} else if v != "" {
+					info.Permissions = append(info.Permissions, key+"="+v)
+				}
+			}
+		}
+	}
+	return info
+}
+
+// SourceWarnings returns warnings derived from the detected source itself
+// rather than from the process: currently snaps that run outside strict
+// confinement.
+func SourceWarnings(src model.Source) []string {
+	if src.Type != model.SourceContainer || src.Name != "snap" {
+		return nil
+	}
+	switch src.Details["confinement"] {
+	case "classic":
+		return []string{"Snap uses classic confinement (no sandbox, full system access)"}
+	case "devmode":
+		return []string{"Snap runs in devmode (sandbox violations are logged, not blocked)"}
+	}
+	return nil
+}
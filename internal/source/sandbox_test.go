@@ -0,0 +1,209 @@
+package source
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/pranshuparmar/witr/pkg/model"
+)
+
+func TestParseSnapYAML(t *testing.T) {
+	data := `name: firefox
+version: "128.0.3-1"
+summary: Mozilla Firefox web browser
+grade: stable
+confinement: strict
+plugs:
+  dot-mozilla-firefox:
+    interface: personal-files
+    read: [$HOME/.mozilla/firefox]
+apps:
+  firefox:
+    command: firefox.launcher
+    plugs:
+      - network
+      - home
+      - 'audio-playback'
+  geckodriver:
+    command: geckodriver
+    plugs: [network-bind, network]
+`
+	got := parseSnapYAML(data)
+	if got.Version != "128.0.3-1" {
+		t.Errorf("Version = %q, want 128.0.3-1", got.Version)
+	}
+	if got.Confinement != "strict" {
+		t.Errorf("Confinement = %q, want strict", got.Confinement)
+	}
+	want := []string{"audio-playback", "dot-mozilla-firefox", "home", "network", "network-bind"}
+	if !reflect.DeepEqual(got.Plugs, want) {
+		t.Errorf("Plugs = %v, want %v", got.Plugs, want)
+	}
+}
+
+func TestParseSnapList(t *testing.T) {
+	out := `Name     Version    Rev    Tracking       Publisher   Notes
+code     1.92.0     165    latest/stable  vscode**    classic
+firefox  128.0.3-1  4539   latest/stable  mozilla**   -
+`
+	row, ok := parseSnapList(out, "code")
+	if !ok {
+		t.Fatal("expected a row for code")
+	}
+	if row.Rev != "165" || row.Tracking != "latest/stable" || row.Notes != "classic" {
+		t.Errorf("unexpected row: %+v", row)
+	}
+	if _, ok := parseSnapList(out, "Name"); ok {
+		t.Error("header row must not match")
+	}
+	if _, ok := parseSnapList(out, "missing"); ok {
+		t.Error("unknown snap must not match")
+	}
+}
+
+func TestConfinementFromNotes(t *testing.T) {
+	tests := []struct {
+		notes string
+		want  string
+	}{
+		{"-", ""},
+		{"classic", "classic"},
+		{"devmode", "devmode"},
+		{"disabled,devmode", "devmode"},
+		{"base", ""},
+	}
+	for _, tt := range tests {
+		if got := confinementFromNotes(tt.notes); got != tt.want {
+			t.Errorf("confinementFromNotes(%q) = %q, want %q", tt.notes, got, tt.want)
+		}
+	}
+}
+
+func TestParseSnapConnections(t *testing.T) {
+	out := `Interface       Plug                 Slot              Notes
+audio-playback  firefox:audio-playback  :audio-playback  -
+camera          firefox:camera       -                 -
+network         firefox:network      :network          -
+network         firefox:network      :network          -
+`
+	want := []string{"audio-playback", "network"}
+	if got := parseSnapConnections(out); !reflect.DeepEqual(got, want) {
+		t.Errorf("parseSnapConnections = %v, want %v", got, want)
+	}
+}
+
+func TestParseFlatpakInfo(t *testing.T) {
+	data := `[Application]
+name=org.mozilla.firefox
+runtime=runtime/org.freedesktop.Platform/x86_64/23.08
+
+[Instance]
+instance-id=1234567890
+branch=stable
+arch=x86_64
+
+[Context]
+shared=network;ipc;
+sockets=x11;wayland;pulseaudio;!fallback-x11;
+filesystems=xdg-download;!home;
+`
+	got := parseFlatpakInfo(data)
+	if got.App != "org.mozilla.firefox" {
+		t.Errorf("App = %q", got.App)
+	}
+	if got.Runtime != "org.freedesktop.Platform/x86_64/23.08" {
+		t.Errorf("Runtime = %q", got.Runtime)
+	}
+	if got.Branch != "stable" {
+		t.Errorf("Branch = %q", got.Branch)
+	}
+	want := []string{
+		"shared=network", "shared=ipc",
+		"sockets=x11", "sockets=wayland", "sockets=pulseaudio",
+		"filesystems=xdg-download",
+	}
+	if !reflect.DeepEqual(got.Permissions, want) {
+		t.Errorf("Permissions = %v, want %v", got.Permissions, want)
+	}
+	wantDenied := []string{"sockets=fallback-x11", "filesystems=home"}
+	if !reflect.DeepEqual(got.Denied, wantDenied) {
+		t.Errorf("Denied = %v, want %v", got.Denied, wantDenied)
+	}
+}
+
+func TestSourceWarningsSnapConfinement(t *testing.T) {
+	tests := []struct {
+		confinement string
+		want        string
+	}{
+		{"classic", "classic confinement"},
+		{"devmode", "devmode"},
+		{"strict", ""},
+	}
+	for _, tt := range tests {
+		src := model.Source{
+			Type:    model.SourceContainer,
+			Name:    "snap",
+			Details: map[string]string{"confinement": tt.confinement},
+		}
+		got := SourceWarnings(src)
+		if tt.want == "" {
+			if len(got) != 0 {
+				t.Errorf("confinement %q: expected no warnings, got %v", tt.confinement, got)
+			}
+			continue
+		}
+		if !contains(got, tt.want) {
+			t.Errorf("confinement %q: expected warning containing %q, got %v", tt.confinement, tt.want, got)
+		}
+	}
+
+	// Non-snap sources never warn, whatever their details say.
+	docker := model.Source{Type: model.SourceContainer, Name: "docker", Details: map[string]string{"confinement": "classic"}}
+	if got := SourceWarnings(docker); len(got) != 0 {
+		t.Errorf("expected no warnings for docker, got %v", got)
+	}
+}
+
+func TestDetectSnapUsesCLIAndEnv(t *testing.T) {
+	orig := snapCommand
+	t.Cleanup(func() {
+		snapCommand = orig
+		snapCLICache = map[string]snapCLIInfo{}
+	})
+	snapCLICache = map[string]snapCLIInfo{}
+	calls := 0
+	snapCommand = func(args ...string) ([]byte, error) {
+		calls++
+		switch args[0] {
+		case "list":
+			return []byte("Name  Version  Rev  Tracking     Publisher  Notes\nfoo   2.1      42   latest/edge  acme       devmode\n"), nil
+		case "connections":
+			return []byte("Interface  Plug         Slot      Notes\nnetwork    foo:network  :network  -\n"), nil
+		}
+		return nil, nil
+	}
+
+	target := model.Process{PID: 1234, Env: []string{"SNAP_NAME=foo", "SNAP_REVISION=42"}}
+	src := detectSnap(target, "foo")
+	if src.Description != "snap foo 2.1" {
+		t.Errorf("Description = %q", src.Description)
+	}
+	want := map[string]string{
+		"revision":    "42",
+		"channel":     "latest/edge",
+		"confinement": "devmode",
+		"permissions": "network",
+	}
+	if !reflect.DeepEqual(src.Details, want) {
+		t.Errorf("Details = %v, want %v", src.Details, want)
+	}
+
+	// A second process of the same snap reuses the CLI results.
+	if again := detectSnap(model.Process{PID: 1235}, "foo"); !reflect.DeepEqual(again.Details, want) {
+		t.Errorf("second Details = %v, want %v", again.Details, want)
+	}
+	if calls != 2 {
+		t.Errorf("snap CLI ran %d times, want 2 (list and connections once)", calls)
+	}
+}
@@ -0,0 +1,57 @@
+package source
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/pranshuparmar/witr/pkg/model"
+)
+
+// managedServiceSources are the sources under which a process is a
+// long-running system service rather than something a user started by hand.
+var managedServiceSources = map[model.SourceType]bool{
+	model.SourceSystemd:    true,
+	model.SourceBsdRc:      true,
+	model.SourceSupervisor: true,
+	model.SourceInit:       true,
+}
+
+// securityWarnings flags setuid-style credential elevation and root services
+// that run without any seccomp filter or LSM confinement.
+func securityWarnings(sc *model.SecurityContext, st model.SourceType) []string {
+	if sc == nil {
+		return nil
+	}
+	var w []string
+
+	if elevated(sc.RealUID, sc.EffectiveUID, sc.SetUIDExe) {
+		w = append(w, fmt.Sprintf("Process has an elevated effective UID (real %s, effective %s), e.g. a setuid binary",
+			sc.RealUID, sc.EffectiveUID))
+	}
+	if elevated(sc.RealGID, sc.EffectiveGID, sc.SetGIDExe) {
+		w = append(w, fmt.Sprintf("Process has an elevated effective GID (real %s, effective %s), e.g. a setgid binary",
+			sc.RealGID, sc.EffectiveGID))
+	}
+
+	if sc.EffectiveUID.ID == 0 && managedServiceSources[st] && sc.SeccompMode == "disabled" && isUnconfinedLabel(sc.LSMLabel) {
+		w = append(w, "Root service runs unconfined (no seccomp filter or LSM profile)")
+	}
+	return w
+}
+
+// elevated reports whether the effective ID grants more than the real one:
+// root acting for a non-root user, or any mismatch picked up from the
+// binary's setuid/setgid bit. A root daemon that dropped to a service user
+// (real 0, effective 33) has given privilege up, not gained it.
+func elevated(real, effective model.Credential, setIDExe bool) bool {
+	if effective.ID == real.ID {
+		return false
+	}
+	return effective.ID == 0 || setIDExe
+}
+
+// isUnconfinedLabel reports whether an LSM label means "no policy applies":
+// AppArmor's "unconfined", SELinux's unconfined_* domains, or no label.
+func isUnconfinedLabel(label string) bool {
+	return label == "" || label == "unconfined" || strings.Contains(label, ":unconfined_")
+}
@@ -2,7 +2,11 @@
 
 package source
 
-import "github.com/pranshuparmar/witr/pkg/model"
+import (
+	"fmt"
+
+	"github.com/pranshuparmar/witr/pkg/model"
+)
 
 func detectSystemd(_ []model.Process) *model.Source {
 	// FreeBSD doesn't use systemd
@@ -11,3 +15,11 @@ func detectSystemd(_ []model.Process) *model.Source {
 
 // IsSystemdRunning always returns false on FreeBSD.
 func IsSystemdRunning() bool { return false }
+
+// UnitEnvironment always reports no systemd unit on FreeBSD.
+func UnitEnvironment(unitName string) ([]string, bool) { return nil, false }
+
+// UnitStatus always fails on FreeBSD, which has no systemd.
+func UnitStatus(unitName string) (model.UnitStatus, error) {
+	return model.UnitStatus{Name: unitName}, fmt.Errorf("systemd is not running")
+}
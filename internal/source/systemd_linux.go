@@ -98,6 +98,131 @@ func enrichFromSystemd(src *model.Source, unitName string) {
 	}
 }
 
+// UnitEnvironment returns the environment a systemd service declares with
+// Environment= and EnvironmentFile=, in the order systemd applies them, so a
+// later assignment of a name overrides an earlier one. ok is false when the
+// unit's properties can't be read over D-Bus.
+func UnitEnvironment(unitName string) (env []string, ok bool) {
+	if !strings.HasSuffix(unitName, ".service") {
+		return nil, false
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), dbusTimeout)
+	defer cancel()
+
+	conn, err := sd.NewSystemConnectionContext(ctx)
+	if err != nil {
+		return nil, false
+	}
+	defer conn.Close()
+
+	svc, err := conn.GetUnitTypePropertiesContext(ctx, unitName, "Service")
+	if err != nil {
+		return nil, false
+	}
+	if declared, ok := svc["Environment"].([]string); ok {
+		env = append(env, declared...)
+	}
+	// EnvironmentFiles is a list of (path, ignore-errors) pairs; a file that
+	// is missing or unreadable contributes nothing, as for systemd.
+	for _, entry := range timerEntries(svc["EnvironmentFiles"]) {
+		if len(entry) == 0 {
+			continue
+		}
+		path, _ := entry[0].(string)
+		if data, err := os.ReadFile(path); err == nil {
+			env = append(env, parseEnvironmentFile(string(data))...)
+		}
+	}
+	return env, true
+}
+
+// unitTypeInterfaces maps a unit name suffix to the D-Bus interface holding
+// the unit type's properties, for the types that own a control group.
+var unitTypeInterfaces = map[string]string{
+	".service": "Service",
+	".scope":   "Scope",
+	".slice":   "Slice",
+	".socket":  "Socket",
+	".mount":   "Mount",
+	".swap":    "Swap",
+}
+
+// UnitStatus reads a systemd unit's state, main PID, restart count and
+// control group over D-Bus. A unit systemd doesn't know is returned with
+// LoadState "not-found" rather than an error; an error means the bus
+// couldn't be used.
+func UnitStatus(unitName string) (model.UnitStatus, error) {
+	status := model.UnitStatus{Name: unitName}
+	if !IsSystemdRunning() {
+		return status, fmt.Errorf("systemd is not running")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), dbusTimeout)
+	defer cancel()
+
+	conn, err := sd.NewSystemConnectionContext(ctx)
+	if err != nil {
+		return status, err
+	}
+	defer conn.Close()
+
+	unit, err := conn.GetUnitPropertiesContext(ctx, unitName)
+	if err != nil {
+		return status, err
+	}
+	status.Description = stringProp(unit, "Description")
+	if fp := stringProp(unit, "FragmentPath"); fp != "" {
+		status.UnitFile = fp
+	} else {
+		status.UnitFile = stringProp(unit, "SourcePath")
+	}
+	status.LoadState = stringProp(unit, "LoadState")
+	status.ActiveState = stringProp(unit, "ActiveState")
+	status.SubState = stringProp(unit, "SubState")
+	status.Since = usecToTime(uint64Prop(unit, "StateChangeTimestamp"))
+
+	dot := strings.LastIndexByte(unitName, '.')
+	if dot < 0 {
+		return status, nil
+	}
+	iface, ok := unitTypeInterfaces[unitName[dot:]]
+	if !ok {
+		return status, nil
+	}
+	if props, err := conn.GetUnitTypePropertiesContext(ctx, unitName, iface); err == nil {
+		status.ControlGroup = stringProp(props, "ControlGroup")
+		status.MainPID = int(uint32Prop(props, "MainPID"))
+		status.Restarts = int(uint32Prop(props, "NRestarts"))
+		status.Result = stringProp(props, "Result")
+	}
+	return status, nil
+}
+
+// parseEnvironmentFile reads the KEY=VALUE lines of a systemd
+// EnvironmentFile, skipping blank lines and "#" or ";" comments and
+// stripping one level of matching quotes around a value.
+func parseEnvironmentFile(content string) []string {
+	var env []string
+	for _, line := range strings.Split(content, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || line[0] == '#' || line[0] == ';' {
+			continue
+		}
+		key, value, ok := strings.Cut(line, "=")
+		key = strings.TrimSpace(key)
+		if !ok || key == "" {
+			continue
+		}
+		value = strings.TrimSpace(value)
+		if len(value) >= 2 && (value[0] == '"' || value[0] == '\'') && value[len(value)-1] == value[0] {
+			value = value[1 : len(value)-1]
+		}
+		env = append(env, key+"="+value)
+	}
+	return env
+}
+
 // timerSchedule renders a "<spec>, last: …, next: …" line for a .timer unit,
 // or "" when the timer isn't loaded.
 func timerSchedule(ctx context.Context, conn *sd.Conn, timerUnit string) string {
@@ -162,8 +287,9 @@ func monotonicSpec(v interface{}) string {
 	return ""
 }
 
-// timerEntries normalizes a TimersCalendar/TimersMonotonic D-Bus value into a
-// slice of struct fields, tolerating any decoding shape it can't read.
+// timerEntries normalizes an array-of-structs D-Bus value (TimersCalendar,
+// TimersMonotonic, EnvironmentFiles) into a slice of struct fields,
+// tolerating any decoding shape it can't read.
 func timerEntries(v interface{}) [][]interface{} {
 	entries, _ := v.([][]interface{})
 	return entries
@@ -5,6 +5,7 @@ package source
 import (
 	"math"
 	"os"
+	"strings"
 	"testing"
 	"time"
 )
@@ -104,3 +105,27 @@ func TestGetUnitNameFromCgroupSelf(t *testing.T) {
 		t.Errorf("getUnitNameFromCgroup(0) = %q, want empty", got)
 	}
 }
+
+func TestParseEnvironmentFile(t *testing.T) {
+	content := `# defaults for the daemon
+; also a comment
+DAEMON_OPTS="-n --port 8080"
+export_ok=1
+  SPACED = value with spaces  
+QUOTED='single'
+MISMATCHED="open
+=novalue
+NOEQUALS
+`
+	want := []string{
+		"DAEMON_OPTS=-n --port 8080",
+		"export_ok=1",
+		"SPACED=value with spaces",
+		"QUOTED=single",
+		`MISMATCHED="open`,
+	}
+	got := parseEnvironmentFile(content)
+	if strings.Join(got, "\n") != strings.Join(want, "\n") {
+		t.Errorf("parseEnvironmentFile =\n%q\nwant\n%q", got, want)
+	}
+}
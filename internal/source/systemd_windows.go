@@ -2,7 +2,11 @@
 
 package source
 
-import "github.com/pranshuparmar/witr/pkg/model"
+import (
+	"fmt"
+
+	"github.com/pranshuparmar/witr/pkg/model"
+)
 
 func detectSystemd(ancestry []model.Process) *model.Source {
 	return nil
@@ -10,3 +14,11 @@ func detectSystemd(ancestry []model.Process) *model.Source {
 
 // IsSystemdRunning always returns false on Windows.
 func IsSystemdRunning() bool { return false }
+
+// UnitEnvironment always reports no systemd unit on Windows.
+func UnitEnvironment(unitName string) ([]string, bool) { return nil, false }
+
+// UnitStatus always fails on Windows, which has no systemd.
+func UnitStatus(unitName string) (model.UnitStatus, error) {
+	return model.UnitStatus{Name: unitName}, fmt.Errorf("systemd is not running")
+}
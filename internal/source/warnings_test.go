@@ -1,7 +1,10 @@
 package source
 
 import (
+	"os"
+	"path/filepath"
 	"runtime"
+	"strconv"
 	"strings"
 	"testing"
 	"time"
@@ -298,3 +301,464 @@ func TestWarningsEmptyInputReturnsNil(t *testing.T) {
 		t.Errorf("Warnings(nil) = %v, want nil", got)
 	}
 }
+
+func TestWarningsCgroupLimits(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		cg   model.CgroupStats
+		want string
+	}{
+		{"near memory limit", model.CgroupStats{MemoryCurrent: 95, MemoryMax: 100}, "Process cgroup is at 95% of its memory limit"},
+		{"parent near memory limit", model.CgroupStats{MemoryCurrent: 10, MemoryMax: 100, MemoryMaxPath: "/system.slice", MemoryMaxCurrent: 92}, "Parent cgroup /system.slice is at 92% of its memory limit"},
+		{"cpu throttled", model.CgroupStats{NrPeriods: 100, NrThrottled: 40}, "CPU-throttled in 40%"},
+		{"oom kills", model.CgroupStats{OOMKills: 3}, "3 OOM kill"},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			p := baseProc()
+			p.Cgroup = &tt.cg
+			if !contains(wrap(p), tt.want) {
+				t.Errorf("expected warning containing %q, got: %v", tt.want, wrap(p))
+			}
+		})
+	}
+
+	// Comfortable headroom, occasional throttling and no OOMs stay quiet.
+	p := baseProc()
+	p.Cgroup = &model.CgroupStats{MemoryCurrent: 50, MemoryMax: 100, NrPeriods: 1000, NrThrottled: 5}
+	if got := wrap(p); contains(got, "cgroup") {
+		t.Errorf("expected no cgroup warnings, got: %v", got)
+	}
+}
+
+func TestWarningsSecurityContext(t *testing.T) {
+	t.Parallel()
+
+	root := model.Credential{ID: 0, Name: "root"}
+	alice := model.Credential{ID: 1000, Name: "alice"}
+
+	t.Run("setuid elevation", func(t *testing.T) {
+		t.Parallel()
+		p := baseProc()
+		p.Security = &model.SecurityContext{
+			RealUID: alice, EffectiveUID: root, SavedUID: root, FSUID: root,
+			SeccompMode: "disabled",
+		}
+		if got := wrap(p); !contains(got, "elevated effective UID (real 1000 (alice), effective 0 (root))") {
+			t.Errorf("expected setuid warning, got: %v", got)
+		}
+	})
+
+	t.Run("dropped privileges", func(t *testing.T) {
+		t.Parallel()
+		// A root daemon that switched its effective UID to a service user.
+		p := baseProc()
+		p.Security = &model.SecurityContext{
+			RealUID: root, EffectiveUID: model.Credential{ID: 33, Name: "www-data"},
+			SeccompMode: "disabled",
+		}
+		if got := wrap(p); contains(got, "elevated") {
+			t.Errorf("expected no elevation warning, got: %v", got)
+		}
+	})
+
+	t.Run("non-root setuid binary", func(t *testing.T) {
+		t.Parallel()
+		games := model.Credential{ID: 5, Name: "games"}
+		p := baseProc()
+		p.Security = &model.SecurityContext{
+			RealUID: alice, EffectiveUID: games, SavedUID: games, FSUID: games,
+			SeccompMode: "disabled",
+		}
+		if got := wrap(p); contains(got, "elevated") {
+			t.Errorf("expected no warning without the setuid bit, got: %v", got)
+		}
+		p.Security.SetUIDExe = true
+		if got := wrap(p); !contains(got, "elevated effective UID (real 1000 (alice), effective 5 (games))") {
+			t.Errorf("expected setuid warning, got: %v", got)
+		}
+	})
+
+	t.Run("unconfined root service", func(t *testing.T) {
+		t.Parallel()
+		p := baseProc()
+		p.Security = &model.SecurityContext{
+			RealUID: root, EffectiveUID: root, SavedUID: root, FSUID: root,
+			SeccompMode: "disabled", LSM: "apparmor", LSMLabel: "unconfined",
+		}
+		if got := wrap(p); !contains(got, "Root service runs unconfined") {
+			t.Errorf("expected unconfined warning, got: %v", got)
+		}
+
+		// A seccomp filter or an enforcing profile counts as confinement.
+		p.Security.SeccompMode = "filter"
+		if got := wrap(p); contains(got, "unconfined") {
+			t.Errorf("seccomp-filtered service should not warn, got: %v", got)
+		}
+		p.Security.SeccompMode = "disabled"
+		p.Security.LSMLabel = "/usr/sbin/nginx (enforce)"
+		if got := wrap(p); contains(got, "unconfined") {
+			t.Errorf("AppArmor-confined service should not warn, got: %v", got)
+		}
+	})
+
+	t.Run("unconfined root shell is not a service", func(t *testing.T) {
+		t.Parallel()
+		p := baseProc()
+		p.Security = &model.SecurityContext{SeccompMode: "disabled", LSMLabel: "unconfined"}
+		got := Warnings([]model.Process{p}, 0, model.SourceShell)
+		if contains(got, "unconfined") {
+			t.Errorf("expected no unconfined warning for a shell process, got: %v", got)
+		}
+	})
+}
+
+func TestWarningsStaleLibraries(t *testing.T) {
+	t.Parallel()
+
+	p := baseProc()
+	p.Libraries = []model.Library{
+		{Path: "/usr/lib/x86_64-linux-gnu/libc.so.6"},
+		{Path: "/usr/lib/x86_64-linux-gnu/libssl.so.3", Deleted: true},
+		{Path: "/usr/lib/x86_64-linux-gnu/libcrypto.so.3", Replaced: true},
+	}
+	if got := wrap(p); !contains(got, "2 deleted or replaced shared libraries (libssl.so.3, libcrypto.so.3); restart") {
+		t.Errorf("expected stale library warning, got: %v", got)
+	}
+
+	for i := 0; i < 3; i++ {
+		p.Libraries = append(p.Libraries, model.Library{Path: "/usr/lib/libx" + strconv.Itoa(i) + ".so", Deleted: true})
+	}
+	if got := wrap(p); !contains(got, "(libssl.so.3, libcrypto.so.3, libx0.so, +2 more)") {
+		t.Errorf("expected capped library list, got: %v", got)
+	}
+
+	p.Libraries = p.Libraries[:1]
+	if got := wrap(p); contains(got, "libraries") || contains(got, "library") {
+		t.Errorf("up-to-date libraries should not warn, got: %v", got)
+	}
+}
+
+func TestWarningsBlocking(t *testing.T) {
+	t.Parallel()
+
+	p := baseProc()
+	p.Blocking = &model.BlockingInfo{
+		State: "D", WaitChannel: "rpc_wait_bit_killable", Syscall: "read",
+		WaitingOn: "file /mnt/share/data.bin", Samples: 5, UninterruptibleSamples: 5, SameCallSamples: 5,
+		FSType: "nfs4", Mount: "/mnt/share",
+	}
+	got := wrap(p)
+	if !contains(got, "stuck in uninterruptible sleep (D state) across 5 samples in rpc_wait_bit_killable, waiting on file /mnt/share/data.bin") {
+		t.Errorf("expected D-state warning, got: %v", got)
+	}
+	if !contains(got, "blocked on NFS mount /mnt/share (nfs4) across 5 samples") {
+		t.Errorf("expected NFS warning, got: %v", got)
+	}
+
+	// A process that left D state between samples is just doing I/O.
+	p.Blocking = &model.BlockingInfo{State: "D", WaitChannel: "io_schedule", Samples: 2, UninterruptibleSamples: 1}
+	if got := wrap(p); contains(got, "uninterruptible") {
+		t.Errorf("transient D state should not warn, got: %v", got)
+	}
+
+	p.Blocking = &model.BlockingInfo{State: "S", WaitChannel: "fuse_simple_request", FSType: "fuse", Samples: 5, SameCallSamples: 5}
+	if got := wrap(p); !contains(got, "blocked in FUSE filesystem code across 5 samples") {
+		t.Errorf("expected FUSE warning without a mount, got: %v", got)
+	}
+
+	// A FUSE read that returned between samples, or a single unsampled
+	// reading, is not a hung daemon.
+	p.Blocking = &model.BlockingInfo{State: "S", WaitChannel: "fuse_simple_request", FSType: "fuse", Samples: 2, SameCallSamples: 1}
+	if got := wrap(p); contains(got, "FUSE") {
+		t.Errorf("a FUSE wait that moved on should not warn, got: %v", got)
+	}
+	p.Blocking = &model.BlockingInfo{State: "S", WaitChannel: "fuse_simple_request", FSType: "fuse"}
+	if got := wrap(p); contains(got, "FUSE") {
+		t.Errorf("an unsampled FUSE wait should not warn, got: %v", got)
+	}
+
+	p.Blocking = &model.BlockingInfo{State: "S", WaitChannel: "do_epoll_wait", Syscall: "epoll_wait"}
+	if got := wrap(p); contains(got, "blocked") || contains(got, "uninterruptible") {
+		t.Errorf("an idle event loop should not warn, got: %v", got)
+	}
+}
+
+func TestWarningsResourceLimits(t *testing.T) {
+	t.Parallel()
+
+	usage := func(v uint64) *uint64 { return &v }
+	p := baseProc()
+	p.Limits = []model.ResourceLimit{
+		{Name: "Max open files", Soft: "1024", Hard: "524288", Unit: "files", Usage: usage(950)},
+		{Name: "Max stack size", Soft: "8388608", Hard: "unlimited", Unit: "bytes", Usage: usage(8 << 20)},
+		{Name: "Max pending signals", Soft: "100", Hard: "100", Unit: "signals", Usage: usage(250)},
+		{Name: "Max resident set", Soft: "1024", Hard: "unlimited", Unit: "bytes", Usage: usage(1 << 30)},
+		{Name: "Max processes", Soft: "10", Hard: "10", Unit: "processes"},
+		{Name: "Max address space", Soft: "unlimited", Hard: "unlimited", Unit: "bytes", Usage: usage(1 << 40)},
+		{Name: "Max locked memory", Soft: "8388608", Hard: "8388608", Unit: "bytes", Usage: usage(0)},
+	}
+	got := wrap(p)
+	for _, want := range []string{
+		"at 93% of its soft limit on open files (950 of 1024); the hard limit allows 524288",
+		"at 100% of its soft limit on stack size (8.0 MB of 8.0 MB); the hard limit is unlimited",
+		"at 250% of its soft limit on pending signals (250 of 100)",
+	} {
+		if !contains(got, want) {
+			t.Errorf("expected %q, got: %v", want, got)
+		}
+	}
+	if contains(got, "resident set") || contains(got, "processes") || contains(got, "address space") || contains(got, "locked memory") {
+		t.Errorf("unenforced, unmeasured, unlimited or unused limits should not warn, got: %v", got)
+	}
+}
+
+func TestWarningsIdentitySpoofing(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		mutate  func(p *model.Process)
+		want    string
+		notWant []string
+	}{
+		{
+			name: "packaged daemon with a process title",
+			mutate: func(p *model.Process) {
+				p.Exe = "/usr/sbin/nginx"
+				p.Cmdline = "nginx: master process /usr/sbin/nginx"
+			},
+			notWant: []string{"does not match", "daemon", "kernel thread", "temporary"},
+		},
+		{
+			name: "comm and argv[0] disagree with the executable",
+			mutate: func(p *model.Process) {
+				p.Command = "nginx"
+				p.Cmdline = "nginx: worker process"
+				p.Exe = "/usr/local/lib/.cache/miner"
+			},
+			want: `Process name does not match its executable: runs as "nginx" (argv[0] "nginx") but executes /usr/local/lib/.cache/miner`,
+		},
+		{
+			name: "script run by its interpreter",
+			mutate: func(p *model.Process) {
+				p.Command = "backup.sh"
+				p.Cmdline = "/bin/bash /usr/local/bin/backup.sh"
+				p.Exe = "/bin/bash"
+			},
+			notWant: []string{"does not match"},
+		},
+		{
+			name: "setproctitle title on a python worker",
+			mutate: func(p *model.Process) {
+				p.Command = "gunicorn: maste"
+				p.Cmdline = "gunicorn: master [app:wsgi]"
+				p.Exe = "/usr/bin/python3.11"
+			},
+			notWant: []string{"does not match"},
+		},
+		{
+			name: "node process.title",
+			mutate: func(p *model.Process) {
+				p.Command = "orders-api"
+				p.Cmdline = "orders-api"
+				p.Exe = "/usr/bin/node"
+			},
+			notWant: []string{"does not match"},
+		},
+		{
+			name: "PM2 daemon title",
+			mutate: func(p *model.Process) {
+				p.Command = "PM2 v5.3.0: God"
+				p.Cmdline = "PM2 v5.3.0: God Daemon (/root/.pm2)"
+				p.Exe = "/usr/bin/node"
+			},
+			notWant: []string{"does not match"},
+		},
+		{
+			name: "comm truncated to 15 characters",
+			mutate: func(p *model.Process) {
+				p.Command = "systemd-timesyn"
+				p.Cmdline = ""
+				p.Exe = "/usr/lib/systemd/systemd-timesyncd"
+			},
+			notWant: []string{"does not match"},
+		},
+		{
+			name: "busybox applet",
+			mutate: func(p *model.Process) {
+				p.Command = "sh"
+				p.Cmdline = "/bin/sh -c true"
+				p.Exe = "/bin/busybox"
+			},
+			notWant: []string{"does not match"},
+		},
+		{
+			name: "memfd executable",
+			mutate: func(p *model.Process) {
+				p.Command = "sleep"
+				p.Cmdline = "sleep 30"
+				p.Exe = "/memfd:payload"
+				p.ExeDeleted = true
+			},
+			want:    "Process is running from an anonymous memory file (memfd:payload)",
+			notWant: []string{"deleted binary", "does not match"},
+		},
+		{
+			name: "executable in /dev/shm",
+			mutate: func(p *model.Process) {
+				p.Command = "x"
+				p.Cmdline = "./x"
+				p.WorkingDir = "/dev/shm"
+				p.Exe = "/dev/shm/x"
+			},
+			want: "Process is running an executable from a world-writable temporary directory: /dev/shm/x",
+		},
+		{
+			name: "argv overwritten to look like a kernel thread",
+			mutate: func(p *model.Process) {
+				p.Command = "sleep"
+				p.Cmdline = "[kworker/0:1] 30"
+				p.Exe = "/usr/bin/sleep"
+			},
+			want:    `Process poses as a kernel thread ("[kworker/0:1]") but runs /usr/bin/sleep`,
+			notWant: []string{"does not match"},
+		},
+		{
+			name: "comm renamed to a kernel thread",
+			mutate: func(p *model.Process) {
+				p.Command = "kworker/u8:2"
+				p.Cmdline = ""
+				p.Exe = "/var/lib/.k/agent"
+			},
+			want: `Process poses as a kernel thread ("kworker/u8:2") but runs /var/lib/.k/agent`,
+		},
+		{
+			name: "daemon name from an unexpected path",
+			mutate: func(p *model.Process) {
+				p.Command = "sshd"
+				p.Cmdline = "/tmp/sshd -D"
+				p.Exe = "/tmp/sshd"
+			},
+			want:    "Process is named like the system daemon sshd but runs from /tmp/sshd",
+			notWant: []string{"temporary directory"},
+		},
+		{
+			name: "daemon name borrowed with exec -a",
+			mutate: func(p *model.Process) {
+				p.Command = ".x"
+				p.Cmdline = "crond"
+				p.Exe = "/home/alice/.x"
+			},
+			want: "Process is named like the system daemon crond but runs from /home/alice/.x",
+		},
+		{
+			name: "container process skips the name check",
+			mutate: func(p *model.Process) {
+				p.Command = "sh"
+				p.Cmdline = "/bin/sh"
+				p.Exe = "/usr/bin/dash"
+				p.ContainerID = "abc123"
+			},
+			notWant: []string{"does not match"},
+		},
+		{
+			name: "windows executable path",
+			mutate: func(p *model.Process) {
+				p.Command = "sshd"
+				p.Exe = `C:\Users\Public\sshd.exe`
+			},
+			notWant: []string{"daemon", "does not match"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			p := baseProc()
+			tt.mutate(&p)
+			got := wrap(p)
+			if tt.want != "" && !contains(got, tt.want) {
+				t.Errorf("expected %q, got: %v", tt.want, got)
+			}
+			for _, nw := range tt.notWant {
+				if contains(got, nw) {
+					t.Errorf("unexpected warning containing %q: %v", nw, got)
+				}
+			}
+		})
+	}
+}
+
+func TestWarningsIdentityFollowsSymlinks(t *testing.T) {
+	t.Parallel()
+	if runtime.GOOS == "windows" {
+		t.Skip("identity checks only apply to Unix executable paths")
+	}
+
+	dir, err := filepath.EvalSymlinks(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	dash := filepath.Join(dir, "dash")
+	if err := os.WriteFile(dash, nil, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Symlink("dash", filepath.Join(dir, "sh")); err != nil {
+		t.Fatal(err)
+	}
+
+	p := baseProc()
+	p.Command = "sh"
+	p.Cmdline = "sh -c true"
+	p.Exe = dash
+	p.Env = []string{"PATH=" + dir}
+	if got := wrap(p); contains(got, "does not match") {
+		t.Errorf("sh resolving to dash through PATH should not warn, got: %v", got)
+	}
+
+	p.Env = []string{"PATH=/nonexistent"}
+	if got := wrap(p); !contains(got, "does not match") {
+		t.Errorf("sh not resolving to the executable should warn, got: %v", got)
+	}
+}
+
+func TestWarningsIdentityInterpreterScript(t *testing.T) {
+	t.Parallel()
+	if runtime.GOOS == "windows" {
+		t.Skip("identity checks only apply to Unix executable paths")
+	}
+
+	dir, err := filepath.EvalSymlinks(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	script := filepath.Join(dir, "gunicorn")
+	if err := os.WriteFile(script, []byte("#!/usr/bin/python3\nimport gunicorn\n"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+
+	// gunicorn started from PATH: comm and argv[0] name the script, the
+	// executable is the interpreter.
+	p := baseProc()
+	p.Command = "gunicorn"
+	p.Cmdline = "gunicorn app:wsgi"
+	p.Exe = "/usr/bin/python3.11"
+	p.Env = []string{"PATH=" + dir}
+	if got := wrap(p); contains(got, "does not match") {
+		t.Errorf("a PATH script run by its interpreter should not warn, got: %v", got)
+	}
+	if !nameMatchesExe("gunicorn", p, true) {
+		t.Error("expected gunicorn to match its interpreter through the PATH script")
+	}
+
+	// The same script name does not explain a non-interpreter executable.
+	p.Exe = "/opt/agent/bin/agent"
+	if nameMatchesExe("gunicorn", p, true) {
+		t.Error("a script should only match an interpreter executable")
+	}
+}
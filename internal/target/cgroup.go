@@ -0,0 +1,20 @@
+package target
+
+import (
+	"fmt"
+
+	procpkg "github.com/pranshuparmar/witr/internal/proc"
+)
+
+// ResolveCgroup returns the PIDs of the processes in a cgroup and the
+// cgroups beneath it.
+func ResolveCgroup(path string) ([]int, error) {
+	tree, err := procpkg.ReadCgroupTree(path)
+	if err != nil {
+		return nil, err
+	}
+	if tree.Total == 0 {
+		return nil, fmt.Errorf("no process in cgroup %s", tree.Path)
+	}
+	return tree.PIDs(), nil
+}
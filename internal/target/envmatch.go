@@ -0,0 +1,129 @@
+package target
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"sort"
+	"strings"
+
+	procpkg "github.com/pranshuparmar/witr/internal/proc"
+)
+
+// EnvMatcher is a parsed --env-match value: a variable name and the values
+// it may have. A nil Value matches any value.
+type EnvMatcher struct {
+	Key   string
+	Value *regexp.Regexp
+}
+
+// ParseEnvMatch parses an --env-match value: "KEY" for any process that
+// sets KEY, "KEY=VALUE" where VALUE is a glob ("NODE_ENV=dev*",
+// "AWS_PROFILE=prod"), or "KEY=/REGEX/" for a regular expression.
+func ParseEnvMatch(value string) (EnvMatcher, error) {
+	key, pattern, hasValue := strings.Cut(strings.TrimSpace(value), "=")
+	if key == "" {
+		return EnvMatcher{}, fmt.Errorf("invalid env match: missing variable name in %q", value)
+	}
+	m := EnvMatcher{Key: key}
+	if !hasValue {
+		return m, nil
+	}
+
+	var err error
+	if len(pattern) >= 2 && strings.HasPrefix(pattern, "/") && strings.HasSuffix(pattern, "/") {
+		m.Value, err = regexp.Compile(pattern[1 : len(pattern)-1])
+	} else {
+		m.Value, err = globRegexp(pattern)
+	}
+	if err != nil {
+		return m, fmt.Errorf("invalid env match: %w", err)
+	}
+	return m, nil
+}
+
+// Match returns the entry of env that m matches, if any.
+func (m EnvMatcher) Match(env []string) (string, bool) {
+	for _, entry := range env {
+		key, value, _ := strings.Cut(entry, "=")
+		if key == m.Key && (m.Value == nil || m.Value.MatchString(value)) {
+			return entry, true
+		}
+	}
+	return "", false
+}
+
+// globRegexp compiles a shell glob into an anchored regular expression.
+// Unlike path.Match, "*" also matches "/", since values are not paths.
+func globRegexp(glob string) (*regexp.Regexp, error) {
+	var b strings.Builder
+	b.WriteString("^")
+	for i := 0; i < len(glob); i++ {
+		switch c := glob[i]; c {
+		case '*':
+			b.WriteString(".*")
+		case '?':
+			b.WriteString(".")
+		case '[':
+			end := strings.IndexByte(glob[i+1:], ']')
+			if end <= 0 {
+				return nil, fmt.Errorf("unclosed [ in %q", glob)
+			}
+			class := glob[i+1 : i+1+end]
+			if rest, ok := strings.CutPrefix(class, "!"); ok {
+				class = "^" + rest
+			}
+			b.WriteString("[" + class + "]")
+			i += end + 1
+		default:
+			b.WriteString(regexp.QuoteMeta(string(c)))
+		}
+	}
+	b.WriteString("$")
+	return regexp.Compile(b.String())
+}
+
+// FindEnvMatches returns the processes whose environment matches an
+// --env-match value, each with the matching KEY=VALUE entry.
+func FindEnvMatches(value string) (map[int]string, error) {
+	m, err := ParseEnvMatch(value)
+	if err != nil {
+		return nil, err
+	}
+	procs, err := procpkg.ListProcesses()
+	if err != nil {
+		return nil, err
+	}
+
+	// witr inherits its environment, so it always matches what its shell
+	// exported. That shell, and whatever started it, are real matches.
+	self := os.Getpid()
+	matches := make(map[int]string)
+	for _, p := range procs {
+		if p.PID == self {
+			continue
+		}
+		if entry, ok := m.Match(procpkg.ReadEnvironment(p.PID)); ok {
+			matches[p.PID] = entry
+		}
+	}
+	if len(matches) == 0 {
+		return nil, fmt.Errorf("no running process with %s in its environment", value)
+	}
+	return matches, nil
+}
+
+// ResolveEnvMatch returns the PIDs of the processes whose environment
+// matches an --env-match value.
+func ResolveEnvMatch(value string) ([]int, error) {
+	matches, err := FindEnvMatches(value)
+	if err != nil {
+		return nil, err
+	}
+	pids := make([]int, 0, len(matches))
+	for pid := range matches {
+		pids = append(pids, pid)
+	}
+	sort.Ints(pids)
+	return pids, nil
+}
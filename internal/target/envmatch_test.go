@@ -0,0 +1,53 @@
+package target
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestParseEnvMatch(t *testing.T) {
+	t.Parallel()
+
+	env := []string{"PATH=/usr/bin:/bin", "NODE_ENV=development", "AWS_PROFILE=prod-eu", "EMPTY="}
+	tests := []struct {
+		query string
+		want  string // matched entry, "" for no match
+	}{
+		{"NODE_ENV", "NODE_ENV=development"},
+		{"NODE_ENV=development", "NODE_ENV=development"},
+		{"NODE_ENV=dev", ""},
+		{"NODE_ENV=dev*", "NODE_ENV=development"},
+		{"AWS_PROFILE=prod", ""},
+		{"AWS_PROFILE=prod-??", "AWS_PROFILE=prod-eu"},
+		{"AWS_PROFILE=prod-[a-f]u", "AWS_PROFILE=prod-eu"},
+		{"AWS_PROFILE=prod-[!e]u", ""},
+		{"AWS_PROFILE=/^prod/", "AWS_PROFILE=prod-eu"},
+		{"AWS_PROFILE=/staging|dev/", ""},
+		{"PATH=*/bin", "PATH=/usr/bin:/bin"},
+		{"PATH=/usr/bin.*", ""}, // a glob: the dot is literal
+		{"EMPTY=", "EMPTY="},
+		{"HOME", ""},
+		{"node_env", ""}, // names are case-sensitive
+	}
+	for _, tt := range tests {
+		m, err := ParseEnvMatch(tt.query)
+		if err != nil {
+			t.Errorf("ParseEnvMatch(%q): %v", tt.query, err)
+			continue
+		}
+		got, ok := m.Match(env)
+		if ok != (tt.want != "") || got != tt.want {
+			t.Errorf("ParseEnvMatch(%q).Match = %q, %v; want %q", tt.query, got, ok, tt.want)
+		}
+	}
+}
+
+func TestParseEnvMatchErrors(t *testing.T) {
+	t.Parallel()
+
+	for _, query := range []string{"", "=prod", "KEY=[abc", "KEY=[]", "KEY=/(/"} {
+		if _, err := ParseEnvMatch(query); err == nil || !strings.Contains(err.Error(), "invalid env match") {
+			t.Errorf("ParseEnvMatch(%q) error = %v, want invalid env match", query, err)
+		}
+	}
+}
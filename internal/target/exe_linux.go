@@ -0,0 +1,76 @@
+//go:build linux
+
+package target
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// ResolveExe returns the processes running the executable at path, matched
+// against each process's resolved /proc/<pid>/exe link so that one of
+// several interpreters sharing a command name can be picked out. Processes
+// whose executable has since been deleted or replaced still match.
+func ResolveExe(path string) ([]int, error) {
+	wanted, err := exeCandidates(path)
+	if err != nil {
+		return nil, err
+	}
+
+	entries, err := os.ReadDir("/proc")
+	if err != nil {
+		return nil, fmt.Errorf("read /proc: %w", err)
+	}
+	self := os.Getpid()
+	var pids []int
+	for _, e := range entries {
+		pid, err := strconv.Atoi(e.Name())
+		if err != nil || pid == self {
+			continue
+		}
+		link, err := os.Readlink("/proc/" + e.Name() + "/exe")
+		if err != nil {
+			continue
+		}
+		if wanted[strings.TrimSuffix(link, " (deleted)")] {
+			pids = append(pids, pid)
+		}
+	}
+	if len(pids) == 0 {
+		return nil, fmt.Errorf("no running process with executable %s", path)
+	}
+	sort.Ints(pids)
+	return pids, nil
+}
+
+// exeCandidates returns the paths an --exe value may appear as in an exe
+// link: the cleaned path and, while it still exists, the file it resolves
+// to ("/usr/bin/python3" → "/usr/bin/python3.11"). A bare command name is
+// looked up on PATH first.
+func exeCandidates(path string) (map[string]bool, error) {
+	path = strings.TrimSpace(path)
+	switch {
+	case path == "":
+		return nil, fmt.Errorf("invalid exe: empty path")
+	case !strings.ContainsRune(path, filepath.Separator):
+		found, err := exec.LookPath(path)
+		if err != nil {
+			return nil, fmt.Errorf("invalid exe: %q not found on PATH", path)
+		}
+		path = found
+	}
+	abs, err := filepath.Abs(path)
+	if err != nil {
+		return nil, fmt.Errorf("invalid exe: %w", err)
+	}
+	wanted := map[string]bool{abs: true}
+	if resolved, err := filepath.EvalSymlinks(abs); err == nil {
+		wanted[resolved] = true
+	}
+	return wanted, nil
+}
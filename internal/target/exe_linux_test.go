@@ -0,0 +1,128 @@
+//go:build linux
+
+package target
+
+import (
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestExeCandidates(t *testing.T) {
+	dir := t.TempDir()
+	real := filepath.Join(dir, "python3.11")
+	if err := os.WriteFile(real, nil, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	link := filepath.Join(dir, "python3")
+	if err := os.Symlink("python3.11", link); err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := exeCandidates(link)
+	if err != nil {
+		t.Fatalf("exeCandidates(%q): %v", link, err)
+	}
+	if !got[link] || !got[real] || len(got) != 2 {
+		t.Errorf("exeCandidates(%q) = %v, want the link and its target", link, got)
+	}
+
+	// A deleted executable no longer resolves but still names itself.
+	gone := filepath.Join(dir, "gone")
+	if got, err := exeCandidates(gone); err != nil || !got[gone] || len(got) != 1 {
+		t.Errorf("exeCandidates(%q) = %v, %v; want only the path", gone, got, err)
+	}
+
+	if _, err := exeCandidates(""); err == nil || !strings.Contains(err.Error(), "invalid") {
+		t.Errorf("exeCandidates(\"\") error = %v, want invalid", err)
+	}
+	if _, err := exeCandidates("witr-no-such-command"); err == nil || !strings.Contains(err.Error(), "not found on PATH") {
+		t.Errorf("exeCandidates(bare name) error = %v, want not found on PATH", err)
+	}
+}
+
+func TestResolveExeMatchesDeletedExecutable(t *testing.T) {
+	src, err := exec.LookPath("sleep")
+	if err != nil {
+		t.Skipf("sleep not found in PATH: %v", err)
+	}
+	exe := filepath.Join(t.TempDir(), "witr-sleeper")
+	copyExecutable(t, src, exe)
+
+	cmd := exec.Command(exe, "60")
+	if err := cmd.Start(); err != nil {
+		t.Skipf("could not spawn %s: %v", exe, err)
+	}
+	defer func() {
+		_ = cmd.Process.Kill()
+		_, _ = cmd.Process.Wait()
+	}()
+	time.Sleep(50 * time.Millisecond)
+
+	pids, err := ResolveExe(exe)
+	if err != nil || !slices.Contains(pids, cmd.Process.Pid) {
+		t.Fatalf("ResolveExe(%q) = %v, %v; want pid %d", exe, pids, err, cmd.Process.Pid)
+	}
+
+	if err := os.Remove(exe); err != nil {
+		t.Fatal(err)
+	}
+	pids, err = ResolveExe(exe)
+	if err != nil || !slices.Contains(pids, cmd.Process.Pid) {
+		t.Fatalf("ResolveExe(%q) after deletion = %v, %v; want pid %d", exe, pids, err, cmd.Process.Pid)
+	}
+
+	if _, err := ResolveExe(exe + "-other"); err == nil || !strings.Contains(err.Error(), "no running process") {
+		t.Errorf("ResolveExe(unused) error = %v, want no running process", err)
+	}
+}
+
+func copyExecutable(t *testing.T, src, dst string) {
+	t.Helper()
+	in, err := os.Open(src)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer in.Close()
+	out, err := os.OpenFile(dst, os.O_CREATE|os.O_WRONLY, 0o755)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := io.Copy(out, in); err != nil {
+		t.Fatal(err)
+	}
+	if err := out.Close(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// TestResolveExeFindsAncestor checks that --exe only leaves witr itself
+// out: the parent of this test (go test, a shell) is found by its
+// executable, as PID 1 or a parent sshd must be.
+func TestResolveExeFindsAncestor(t *testing.T) {
+	parent := os.Getppid()
+	exe, err := os.Readlink("/proc/" + strconv.Itoa(parent) + "/exe")
+	if err != nil {
+		t.Skipf("cannot read parent's executable: %v", err)
+	}
+	exe = strings.TrimSuffix(exe, " (deleted)")
+
+	pids, err := ResolveExe(exe)
+	if err != nil || !slices.Contains(pids, parent) {
+		t.Fatalf("ResolveExe(%q) = %v, %v; want the parent %d", exe, pids, err, parent)
+	}
+
+	self, err := os.Executable()
+	if err != nil {
+		t.Skipf("cannot find own executable: %v", err)
+	}
+	if pids, _ := ResolveExe(self); slices.Contains(pids, os.Getpid()) {
+		t.Errorf("ResolveExe(%q) included witr's own PID", self)
+	}
+}
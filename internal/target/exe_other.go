@@ -0,0 +1,11 @@
+//go:build !linux
+
+package target
+
+import "fmt"
+
+// ResolveExe is Linux-only: matching the resolved executable, deleted ones
+// included, needs /proc/<pid>/exe.
+func ResolveExe(path string) ([]int, error) {
+	return nil, fmt.Errorf("resolving executable %s: %w", path, ErrUnsupported)
+}
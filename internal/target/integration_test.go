@@ -6,10 +6,14 @@ import (
 	"net"
 	"os"
 	"os/exec"
+	"regexp"
 	"runtime"
 	"slices"
+	"strings"
 	"testing"
 	"time"
+
+	procpkg "github.com/pranshuparmar/witr/internal/proc"
 )
 
 // Integration smoke tests for the target package's platform-specific
@@ -69,6 +73,126 @@ func TestIntegration_ResolveNameFindsSpawnedChild(t *testing.T) {
 		name, childPID, pids, lastErr)
 }
 
+// TestIntegration_ResolveMatchFindsSpawnedChild runs the --match resolver
+// against the real process table: the sleeper's full command line, not just
+// its name, must match the pattern.
+func TestIntegration_ResolveMatchFindsSpawnedChild(t *testing.T) {
+	childPID, name, cleanup := startSleeper(t)
+	defer cleanup()
+
+	pattern := name + `.* 60\b`
+	var pids []int
+	var lastErr error
+	for i := 0; i < 10; i++ {
+		pids, lastErr = ResolveMatch(pattern)
+		if lastErr == nil && slices.Contains(pids, childPID) {
+			break
+		}
+		time.Sleep(100 * time.Millisecond)
+	}
+	if !slices.Contains(pids, childPID) {
+		t.Fatalf("ResolveMatch(%q) did not find spawned child PID %d after retries; got %v (last err: %v)",
+			pattern, childPID, pids, lastErr)
+	}
+
+	if _, err := ResolveMatch(name + ` 6000000\b`); err == nil {
+		t.Error("ResolveMatch should fail when no command line matches")
+	}
+	if _, err := ResolveMatch("celery(worker"); err == nil || !strings.Contains(err.Error(), "invalid match pattern") {
+		t.Errorf("ResolveMatch with a broken pattern: got %v, want invalid match pattern", err)
+	}
+}
+
+// TestIntegration_ResolveMatchFindsAncestor checks that --match leaves only
+// witr itself and ancestors echoing the pattern out: the process that
+// started this test (go test, a CI shell) is found by its command line like
+// any other, as PID 1 or a parent sshd must be.
+func TestIntegration_ResolveMatchFindsAncestor(t *testing.T) {
+	parent := os.Getppid()
+	procs, err := procpkg.ListProcesses()
+	if err != nil {
+		t.Skipf("cannot list processes: %v", err)
+	}
+	cmdline := ""
+	for _, p := range procs {
+		if p.PID == parent {
+			cmdline = p.Cmdline
+		}
+	}
+	if cmdline == "" {
+		t.Skipf("parent %d has no readable command line", parent)
+	}
+
+	pattern := "^" + regexp.QuoteMeta(cmdline) + "$"
+	pids, err := ResolveMatch(pattern)
+	if err != nil || !slices.Contains(pids, parent) {
+		t.Fatalf("ResolveMatch(%q) = %v, %v; want the parent %d", pattern, pids, err, parent)
+	}
+	if slices.Contains(pids, os.Getpid()) {
+		t.Errorf("ResolveMatch(%q) included witr's own PID", pattern)
+	}
+}
+
+// TestIntegration_FindEnvMatchesFindsSpawnedChild starts a child with a
+// marker variable and checks --env-match finds it, and only it, with the
+// matching entry.
+func TestIntegration_FindEnvMatchesFindsSpawnedChild(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("sleep is not available on Windows")
+	}
+	cmd := exec.Command("sleep", "60")
+	cmd.Env = append(os.Environ(), "WITR_ENV_MATCH_TEST=billing-worker-7")
+	if err := cmd.Start(); err != nil {
+		t.Skipf("could not spawn sleep: %v", err)
+	}
+	defer func() {
+		_ = cmd.Process.Kill()
+		_, _ = cmd.Process.Wait()
+	}()
+	childPID := cmd.Process.Pid
+
+	var matches map[int]string
+	var lastErr error
+	for i := 0; i < 10; i++ {
+		matches, lastErr = FindEnvMatches("WITR_ENV_MATCH_TEST=billing-*")
+		if lastErr == nil && matches[childPID] != "" {
+			break
+		}
+		time.Sleep(100 * time.Millisecond)
+	}
+	if got := matches[childPID]; got != "WITR_ENV_MATCH_TEST=billing-worker-7" {
+		t.Fatalf("FindEnvMatches did not report child PID %d with its entry; got %v (last err: %v)", childPID, matches, lastErr)
+	}
+	if len(matches) != 1 {
+		t.Errorf("FindEnvMatches matched more than the child: %v", matches)
+	}
+
+	if _, err := FindEnvMatches("WITR_ENV_MATCH_TEST=/^payments/"); err == nil {
+		t.Error("FindEnvMatches should fail when no environment matches")
+	}
+}
+
+// TestIntegration_FindEnvMatchesFindsAncestor checks that --env-match
+// leaves only witr itself out: the process that started this test, whose
+// environment witr inherited, is a match like any other.
+func TestIntegration_FindEnvMatchesFindsAncestor(t *testing.T) {
+	parent := os.Getppid()
+	env := procpkg.ReadEnvironment(parent)
+	if len(env) == 0 {
+		t.Skipf("parent %d has no readable environment", parent)
+	}
+	key, value, _ := strings.Cut(env[0], "=")
+	query := key + "=/^" + regexp.QuoteMeta(value) + "$/"
+
+	matches, err := FindEnvMatches(query)
+	if err != nil || matches[parent] != env[0] {
+		t.Fatalf("FindEnvMatches(%q) = %v, %v; want the parent %d", query, matches, err, parent)
+	}
+	if _, ok := matches[os.Getpid()]; ok {
+		t.Errorf("FindEnvMatches(%q) included witr's own PID", query)
+	}
+}
+
 // TestIntegration_ResolveNameFindsGrep is a regression test for the removed
 // "grep" name-matching exclusion. witr reads the process table directly (no
 // `ps | grep` pipeline), so a real grep process must be resolvable by name —
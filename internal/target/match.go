@@ -0,0 +1,58 @@
+package target
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	procpkg "github.com/pranshuparmar/witr/internal/proc"
+)
+
+// ResolveMatch returns the processes whose full command line matches the
+// regular expression pattern, e.g. "celery.*worker -Q billing" to pick one
+// of many interpreter processes that share a command name.
+func ResolveMatch(pattern string) ([]int, error) {
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return nil, fmt.Errorf("invalid match pattern: %w", err)
+	}
+	procs, err := procpkg.ListProcesses()
+	if err != nil {
+		return nil, err
+	}
+
+	ignored := queryEchoes(pattern)
+	var pids []int
+	for _, p := range procs {
+		if !ignored[p.PID] && re.MatchString(p.Cmdline) {
+			pids = append(pids, p.PID)
+		}
+	}
+	if len(pids) == 0 {
+		return nil, fmt.Errorf("no running process with a command line matching %q", pattern)
+	}
+	sort.Ints(pids)
+	return pids, nil
+}
+
+// queryEchoes returns witr's own PID and those of its ancestors whose
+// command line names both witr and query: the sudo or "sh -c" that ran
+// witr with the pattern, which would otherwise match it. Other ancestors,
+// up to PID 1, are ordinary candidates even when the pattern happens to
+// appear in their command line.
+func queryEchoes(query string) map[int]bool {
+	self := os.Getpid()
+	echoes := map[int]bool{self: true}
+	name := filepath.Base(os.Args[0])
+	if ancestry, err := procpkg.ResolveAncestry(self); err == nil {
+		for _, p := range ancestry {
+			if strings.Contains(p.Cmdline, name) && strings.Contains(p.Cmdline, query) {
+				echoes[p.PID] = true
+			}
+		}
+	}
+	return echoes
+}
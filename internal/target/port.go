@@ -0,0 +1,88 @@
+package target
+
+import (
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+
+	"github.com/pranshuparmar/witr/pkg/model"
+)
+
+// ParsePort parses a --port value: a port ("8080") or range ("8000-8100"),
+// optionally prefixed by a protocol ("udp/53") and a bind address
+// ("127.0.0.1:6379", "[::1]:8080", "tcp/[::1]:8000-8100").
+func ParsePort(value string) (model.PortQuery, error) {
+	var q model.PortQuery
+	rest := strings.TrimSpace(value)
+
+	if proto, after, ok := strings.Cut(rest, "/"); ok {
+		proto = strings.ToLower(proto)
+		if proto != "tcp" && proto != "udp" {
+			return q, fmt.Errorf("invalid port: unknown protocol %q (use tcp or udp)", proto)
+		}
+		q.Protocol, rest = proto, after
+	}
+
+	switch {
+	case strings.HasPrefix(rest, "["):
+		end := strings.Index(rest, "]:")
+		if end == -1 {
+			return q, fmt.Errorf("invalid port: expected [address]:port")
+		}
+		q.Address, rest = rest[1:end], rest[end+2:]
+		if net.ParseIP(q.Address) == nil {
+			return q, fmt.Errorf("invalid port: %q is not an IP address", q.Address)
+		}
+	case strings.Count(rest, ":") > 1:
+		return q, fmt.Errorf("invalid port: put IPv6 addresses in brackets, e.g. [::1]:8080")
+	case strings.Contains(rest, ":"):
+		q.Address, rest, _ = strings.Cut(rest, ":")
+		if ip := net.ParseIP(q.Address); ip == nil || ip.To4() == nil {
+			return q, fmt.Errorf("invalid port: %q is not an IPv4 address", q.Address)
+		}
+	}
+
+	low, high, isRange := strings.Cut(rest, "-")
+	var err error
+	if q.Low, err = parsePortNumber(low); err != nil {
+		return q, err
+	}
+	q.High = q.Low
+	if isRange {
+		if q.High, err = parsePortNumber(high); err != nil {
+			return q, err
+		}
+		if q.High < q.Low {
+			return q, fmt.Errorf("invalid port range: %d is greater than %d", q.Low, q.High)
+		}
+	}
+	return q, nil
+}
+
+func parsePortNumber(s string) (int, error) {
+	port, err := strconv.Atoi(s)
+	if err != nil {
+		return 0, fmt.Errorf("invalid port")
+	}
+	if port < 1 || port > 65535 {
+		return 0, fmt.Errorf("invalid port: must be between 1 and 65535")
+	}
+	return port, nil
+}
+
+// describePort names the ports a query covers in an error message: "port
+// 8080", "ports 8000-8100", "udp port 53 on 127.0.0.1".
+func describePort(q model.PortQuery) string {
+	s := "port " + strconv.Itoa(q.Low)
+	if q.IsRange() {
+		s = fmt.Sprintf("ports %d-%d", q.Low, q.High)
+	}
+	if q.Protocol != "" {
+		s = q.Protocol + " " + s
+	}
+	if q.Address != "" {
+		s += " on " + q.Address
+	}
+	return s
+}
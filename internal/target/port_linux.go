@@ -9,94 +9,53 @@ import (
 	"sort"
 	"strconv"
 	"strings"
-)
-
-func findSocketInodes(port int, listenersOnly bool) (map[string]bool, error) {
-	inodes := make(map[string]bool)
 
-	type procNetFile struct {
-		path  string
-		isTCP bool
-	}
-	files := []procNetFile{
-		{"/proc/net/tcp", true},
-		{"/proc/net/tcp6", true},
-		{"/proc/net/udp", false},
-		{"/proc/net/udp6", false},
-	}
-	targetHex := fmt.Sprintf("%04X", port)
-
-	for _, f := range files {
-		data, err := os.ReadFile(f.path)
-		if err != nil {
-			continue
-		}
-
-		lines := strings.Split(string(data), "\n")
-		for _, line := range lines[1:] {
-			fields := strings.Fields(line)
-			if len(fields) < 10 {
-				continue
-			}
-
-			localParts := strings.Split(fields[1], ":")
-			if len(localParts) != 2 {
-				continue
-			}
-			remoteParts := strings.Split(fields[2], ":")
-
-			state := fields[3]
-			if f.isTCP {
-				if listenersOnly {
-					// 0A = TCP_LISTEN — only report actual listeners
-					if state != "0A" {
-						continue
-					}
-				}
-				// In fallback mode, accept any TCP state so connected sockets
-				// (ESTABLISHED, TIME_WAIT, etc.) are also surfaced.
-			} else {
-				// UDP is connectionless; state 07 (CLOSE) means the socket is
-				// bound and ready to receive. Also accept 01 (ESTABLISHED) for
-				// connected UDP sockets.
-				if state != "07" && state != "01" {
-					continue
-				}
-			}
+	procpkg "github.com/pranshuparmar/witr/internal/proc"
+	"github.com/pranshuparmar/witr/pkg/model"
+)
 
-			matches := localParts[1] == targetHex
-			if !listenersOnly && !matches && len(remoteParts) == 2 {
-				// Match either side of the connection so a process with an
-				// outbound connection to :port is also found.
-				matches = remoteParts[1] == targetHex
-			}
-			if matches {
-				inodes[fields[9]] = true
-			}
-		}
+// findSocketInodes returns the inodes of the sockets q selects, filtered in
+// the kernel via sock_diag when possible and by parsing /proc/net otherwise.
+func findSocketInodes(q model.PortQuery, listenersOnly bool) (map[string]bool, error) {
+	inodes, err := procpkg.PortSocketInodes(q, listenersOnly)
+	if err != nil {
+		inodes = procpkg.PortSocketInodesText(q, listenersOnly)
 	}
 
 	if len(inodes) == 0 {
 		if listenersOnly {
-			return nil, fmt.Errorf("no process listening on port %d", port)
+			return nil, fmt.Errorf("no process listening on %s", describePort(q))
 		}
-		return nil, fmt.Errorf("no process bound to or connected on port %d", port)
+		return nil, fmt.Errorf("no process bound to or connected on %s", describePort(q))
 	}
 
 	return inodes, nil
 }
 
 func ResolvePort(port int) ([]int, error) {
-	inodes, err := findSocketInodes(port, true)
+	return ResolvePortQuery(model.PortQuery{Low: port, High: port})
+}
+
+// ResolvePortQuery returns the processes listening on the ports q selects,
+// or, when none are, those with a socket bound to or connected on them.
+func ResolvePortQuery(q model.PortQuery) ([]int, error) {
+	inodes, err := findSocketInodes(q, true)
 	if err != nil {
-		fallbackInodes, fallbackErr := findSocketInodes(port, false)
+		fallbackInodes, fallbackErr := findSocketInodes(q, false)
 		if fallbackErr != nil {
 			return nil, err
 		}
 		inodes = fallbackInodes
 	}
 
-	// collect all owning pids so callers can handle multi-owner sockets.
+	return socketOwnerPIDs(inodes)
+}
+
+// socketOwnerPIDs returns every PID holding an fd on one of inodes, so callers
+// can handle multi-owner sockets. PID 1 is dropped when another process also
+// holds the socket: with systemd socket activation, the daemon it hands the
+// socket to is the interesting owner.
+func socketOwnerPIDs(inodes map[string]bool) ([]int, error) {
 	pidSet := make(map[int]bool)
 	procEntries, _ := os.ReadDir("/proc")
 	for _, entry := range procEntries {
@@ -0,0 +1,91 @@
+//go:build linux || darwin || freebsd || windows
+
+package target
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/pranshuparmar/witr/pkg/model"
+)
+
+func TestParsePort(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		in   string
+		want model.PortQuery
+	}{
+		{"8080", model.PortQuery{Low: 8080, High: 8080}},
+		{" 443 ", model.PortQuery{Low: 443, High: 443}},
+		{"udp/53", model.PortQuery{Protocol: "udp", Low: 53, High: 53}},
+		{"TCP/443", model.PortQuery{Protocol: "tcp", Low: 443, High: 443}},
+		{"127.0.0.1:6379", model.PortQuery{Address: "127.0.0.1", Low: 6379, High: 6379}},
+		{"[::1]:8080", model.PortQuery{Address: "::1", Low: 8080, High: 8080}},
+		{"8000-8100", model.PortQuery{Low: 8000, High: 8100}},
+		{"tcp/[::]:8000-8100", model.PortQuery{Protocol: "tcp", Address: "::", Low: 8000, High: 8100}},
+	}
+	for _, tt := range tests {
+		got, err := ParsePort(tt.in)
+		if err != nil || got != tt.want {
+			t.Errorf("ParsePort(%q) = %+v, %v; want %+v", tt.in, got, err, tt.want)
+		}
+	}
+}
+
+func TestParsePortErrors(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		in, want string
+	}{
+		{"abc", "invalid port"},
+		{"0", "between 1 and 65535"},
+		{"70000", "between 1 and 65535"},
+		{"sctp/80", "unknown protocol"},
+		{"::1:80", "brackets"},
+		{"[::1]80", "[address]:port"},
+		{"localhost:80", "not an IPv4 address"},
+		{"[fe80::zz]:80", "not an IP address"},
+		{"9000-8000", "greater than"},
+		{"8000-", "invalid port"},
+	}
+	for _, tt := range tests {
+		_, err := ParsePort(tt.in)
+		if err == nil || !strings.Contains(err.Error(), tt.want) {
+			t.Errorf("ParsePort(%q) error = %v, want it to mention %q", tt.in, err, tt.want)
+		}
+	}
+}
+
+func TestPortQueryStringRoundTrips(t *testing.T) {
+	t.Parallel()
+
+	for _, in := range []string{"8080", "udp/53", "127.0.0.1:6379", "[::1]:8080", "8000-8100", "tcp/[::]:8000-8100"} {
+		q, err := ParsePort(in)
+		if err != nil {
+			t.Fatalf("ParsePort(%q): %v", in, err)
+		}
+		if got := q.String(); got != in {
+			t.Errorf("String() = %q, want %q", got, in)
+		}
+	}
+}
+
+func TestDescribePort(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		q    model.PortQuery
+		want string
+	}{
+		{model.PortQuery{Low: 80, High: 80}, "port 80"},
+		{model.PortQuery{Protocol: "udp", Address: "127.0.0.1", Low: 53, High: 53}, "udp port 53 on 127.0.0.1"},
+		{model.PortQuery{Low: 8000, High: 8100}, "ports 8000-8100"},
+	}
+	for _, tt := range tests {
+		if got := describePort(tt.q); got != tt.want {
+			t.Errorf("describePort(%+v) = %q, want %q", tt.q, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,36 @@
+//go:build !linux
+
+package target
+
+import (
+	"fmt"
+	"sort"
+
+	procpkg "github.com/pranshuparmar/witr/internal/proc"
+	"github.com/pranshuparmar/witr/pkg/model"
+)
+
+// ResolvePortQuery returns the processes listening on the ports q selects,
+// from the platform's open-port listing.
+func ResolvePortQuery(q model.PortQuery) ([]int, error) {
+	listeners, err := procpkg.ListPortListeners(q)
+	if err != nil {
+		return nil, err
+	}
+	seen := make(map[int]bool)
+	var pids []int
+	for _, l := range listeners {
+		if l.PID > 0 && !seen[l.PID] {
+			seen[l.PID] = true
+			pids = append(pids, l.PID)
+		}
+	}
+	if len(pids) == 0 {
+		if len(listeners) > 0 {
+			return nil, ErrSocketOwnerUnknown
+		}
+		return nil, fmt.Errorf("no process listening on %s", describePort(q))
+	}
+	sort.Ints(pids)
+	return pids, nil
+}
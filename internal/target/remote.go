@@ -0,0 +1,98 @@
+package target
+
+import (
+	"fmt"
+	"net"
+	"net/netip"
+	"strings"
+
+	procpkg "github.com/pranshuparmar/witr/internal/proc"
+	"github.com/pranshuparmar/witr/pkg/model"
+)
+
+// ParseRemote parses a --remote value: an address ("169.254.169.254",
+// "fd00::1"), hostname ("db.internal") or CIDR ("10.0.4.0/24"), optionally
+// followed by a port ("10.0.4.12:5432", "[fd00::1]:443"). A hostname is
+// resolved here and matches every address it resolves to.
+func ParseRemote(value string) (model.RemoteQuery, error) {
+	var q model.RemoteQuery
+	host := strings.TrimSpace(value)
+	port := ""
+
+	switch {
+	case strings.HasPrefix(host, "["):
+		end := strings.Index(host, "]")
+		if end == -1 {
+			return q, fmt.Errorf("invalid remote: missing ] in %q", value)
+		}
+		rest := host[end+1:]
+		host = host[1:end]
+		if rest != "" {
+			var ok bool
+			if port, ok = strings.CutPrefix(rest, ":"); !ok {
+				return q, fmt.Errorf("invalid remote: expected [address]:port")
+			}
+		}
+	case strings.Count(host, ":") == 1:
+		host, port, _ = strings.Cut(host, ":")
+	}
+	if host == "" {
+		return q, fmt.Errorf("invalid remote: missing host")
+	}
+	q.Host = host
+
+	if port != "" {
+		p, err := parsePortNumber(port)
+		if err != nil {
+			return q, err
+		}
+		q.Port = p
+	}
+
+	switch {
+	case strings.Contains(host, "/"):
+		prefix, err := netip.ParsePrefix(host)
+		if err != nil {
+			return q, fmt.Errorf("invalid remote: %q is not a CIDR block", host)
+		}
+		if prefix.Addr().Is4In6() && prefix.Bits() >= 96 {
+			prefix = netip.PrefixFrom(prefix.Addr().Unmap(), prefix.Bits()-96)
+		}
+		q.Prefixes = []netip.Prefix{prefix.Masked()}
+	default:
+		if addr, err := netip.ParseAddr(host); err == nil {
+			addr = addr.WithZone("").Unmap()
+			q.Prefixes = []netip.Prefix{netip.PrefixFrom(addr, addr.BitLen())}
+			break
+		}
+		ips, err := net.LookupIP(host)
+		if err != nil || len(ips) == 0 {
+			return q, fmt.Errorf("invalid remote: cannot resolve %q", host)
+		}
+		for _, ip := range ips {
+			if addr, ok := netip.AddrFromSlice(ip); ok {
+				addr = addr.Unmap()
+				q.Prefixes = append(q.Prefixes, netip.PrefixFrom(addr, addr.BitLen()))
+			}
+		}
+	}
+	return q, nil
+}
+
+// ResolveRemote returns the PIDs of the processes connected to a --remote
+// endpoint.
+func ResolveRemote(value string) ([]int, error) {
+	q, err := ParseRemote(value)
+	if err != nil {
+		return nil, err
+	}
+	conns, err := procpkg.ScanRemoteConnections(q)
+	if err != nil {
+		return nil, err
+	}
+	pids := make([]int, 0, len(conns.Processes))
+	for _, c := range conns.Processes {
+		pids = append(pids, c.PID)
+	}
+	return pids, nil
+}
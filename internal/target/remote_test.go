@@ -0,0 +1,105 @@
+//go:build linux || darwin || freebsd || windows
+
+package target
+
+import (
+	"net/netip"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/pranshuparmar/witr/pkg/model"
+)
+
+func TestParseRemote(t *testing.T) {
+	t.Parallel()
+
+	pfx := netip.MustParsePrefix
+	tests := []struct {
+		in   string
+		want model.RemoteQuery
+	}{
+		{"169.254.169.254", model.RemoteQuery{Host: "169.254.169.254", Prefixes: []netip.Prefix{pfx("169.254.169.254/32")}}},
+		{" 10.0.4.12:5432 ", model.RemoteQuery{Host: "10.0.4.12", Prefixes: []netip.Prefix{pfx("10.0.4.12/32")}, Port: 5432}},
+		{"10.0.4.7/24", model.RemoteQuery{Host: "10.0.4.7/24", Prefixes: []netip.Prefix{pfx("10.0.4.0/24")}}},
+		{"10.0.0.0/8:443", model.RemoteQuery{Host: "10.0.0.0/8", Prefixes: []netip.Prefix{pfx("10.0.0.0/8")}, Port: 443}},
+		{"fd00::1", model.RemoteQuery{Host: "fd00::1", Prefixes: []netip.Prefix{pfx("fd00::1/128")}}},
+		{"[fd00::1]:443", model.RemoteQuery{Host: "fd00::1", Prefixes: []netip.Prefix{pfx("fd00::1/128")}, Port: 443}},
+		{"[fd00::/8]", model.RemoteQuery{Host: "fd00::/8", Prefixes: []netip.Prefix{pfx("fd00::/8")}}},
+		{"::ffff:10.0.4.12", model.RemoteQuery{Host: "::ffff:10.0.4.12", Prefixes: []netip.Prefix{pfx("10.0.4.12/32")}}},
+	}
+	for _, tt := range tests {
+		got, err := ParseRemote(tt.in)
+		if err != nil || !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("ParseRemote(%q) = %+v, %v; want %+v", tt.in, got, err, tt.want)
+		}
+	}
+}
+
+func TestParseRemoteErrors(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		in, want string
+	}{
+		{"", "missing host"},
+		{":5432", "missing host"},
+		{"10.0.4.12:0", "between 1 and 65535"},
+		{"10.0.4.12:db", "invalid port"},
+		{"[fd00::1", "missing ]"},
+		{"[fd00::1]443", "[address]:port"},
+		{"10.0.4.0/33", "not a CIDR block"},
+		{"no-such-host.invalid", "cannot resolve"},
+	}
+	for _, tt := range tests {
+		_, err := ParseRemote(tt.in)
+		if err == nil || !strings.Contains(err.Error(), tt.want) {
+			t.Errorf("ParseRemote(%q) error = %v, want it to mention %q", tt.in, err, tt.want)
+		}
+	}
+}
+
+func TestRemoteQueryStringRoundTrips(t *testing.T) {
+	t.Parallel()
+
+	for _, in := range []string{"169.254.169.254", "10.0.4.12:5432", "10.0.0.0/8:443", "fd00::1", "[fd00::1]:443"} {
+		q, err := ParseRemote(in)
+		if err != nil {
+			t.Fatalf("ParseRemote(%q): %v", in, err)
+		}
+		if got := q.String(); got != in {
+			t.Errorf("String() = %q, want %q", got, in)
+		}
+	}
+}
+
+func TestRemoteQueryMatches(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		query string
+		addr  string
+		port  int
+		want  bool
+	}{
+		{"10.0.4.12:5432", "10.0.4.12", 5432, true},
+		{"10.0.4.12:5432", "10.0.4.12", 5433, false},
+		{"10.0.4.12", "10.0.4.12", 40000, true},
+		{"10.0.4.12", "::ffff:10.0.4.12", 5432, true},
+		{"10.0.4.0/24", "10.0.4.200", 443, true},
+		{"10.0.4.0/24", "10.0.5.1", 443, false},
+		{"[fe80::1]:22", "fe80::1%eth0", 22, true},
+		{"fd00::/8", "fd12::3", 80, true},
+		{"fd00::/8", "10.0.0.1", 80, false},
+		{"10.0.4.12", "", 0, false},
+	}
+	for _, tt := range tests {
+		q, err := ParseRemote(tt.query)
+		if err != nil {
+			t.Fatalf("ParseRemote(%q): %v", tt.query, err)
+		}
+		if got := q.Matches(tt.addr, tt.port); got != tt.want {
+			t.Errorf("%q matches %s port %d = %v, want %v", tt.query, tt.addr, tt.port, got, tt.want)
+		}
+	}
+}
@@ -43,14 +43,14 @@ func Resolve(t model.Target, exact bool) ([]int, error) {
 		return []int{pid}, nil
 
 	case model.TargetPort:
-		port, err := strconv.Atoi(val)
+		q, err := ParsePort(val)
 		if err != nil {
-			return nil, fmt.Errorf("invalid port")
+			return nil, err
 		}
-		if port < 1 || port > 65535 {
-			return nil, fmt.Errorf("invalid port: must be between 1 and 65535")
+		if q.IsPlain() {
+			return ResolvePort(q.Low)
 		}
-		return ResolvePort(port)
+		return ResolvePortQuery(q)
 
 	case model.TargetName:
 		return ResolveName(val, exact)
@@ -58,6 +58,24 @@ func Resolve(t model.Target, exact bool) ([]int, error) {
 	case model.TargetFile:
 		return ResolveFile(val)
 
+	case model.TargetSocket:
+		return ResolveSocket(val)
+
+	case model.TargetRemote:
+		return ResolveRemote(val)
+
+	case model.TargetCgroup:
+		return ResolveCgroup(val)
+
+	case model.TargetExe:
+		return ResolveExe(val)
+
+	case model.TargetMatch:
+		return ResolveMatch(t.Value)
+
+	case model.TargetEnvMatch:
+		return ResolveEnvMatch(val)
+
 	default:
 		return nil, fmt.Errorf("unknown target")
 	}
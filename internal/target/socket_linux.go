@@ -0,0 +1,16 @@
+//go:build linux
+
+package target
+
+import procpkg "github.com/pranshuparmar/witr/internal/proc"
+
+// ResolveSocket finds the processes serving the unix socket at path (or
+// "@name" for an abstract socket): the holders of its listening or bound
+// datagram socket.
+func ResolveSocket(path string) ([]int, error) {
+	inodes, err := procpkg.FindUnixSocketInodes(path)
+	if err != nil {
+		return nil, err
+	}
+	return socketOwnerPIDs(inodes)
+}
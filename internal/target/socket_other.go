@@ -0,0 +1,11 @@
+//go:build !linux
+
+package target
+
+import "fmt"
+
+// ResolveSocket is Linux-only: mapping a unix socket path to its server needs
+// /proc/net/unix or sock_diag.
+func ResolveSocket(path string) ([]int, error) {
+	return nil, fmt.Errorf("resolving unix socket %s: %w", path, ErrUnsupported)
+}
@@ -101,6 +101,7 @@ func (m MainModel) fetchContainerDetail(match *model.ContainerMatch) tea.Cmd {
 				PID:     pid,
 				Verbose: true,
 				Tree:    true,
+				Quick:   true,
 			})
 			if err == nil {
 				res.Process.Container = output.FormatContainerLine(match)
@@ -120,6 +121,7 @@ func (m MainModel) fetchTree(p model.Process) tea.Cmd {
 			PID:     p.PID,
 			Verbose: false,
 			Tree:    true,
+			Quick:   true,
 		})
 		if err != nil {
 			return treeMsg(model.Result{
@@ -136,6 +138,7 @@ func (m MainModel) fetchProcessDetail(pid int) tea.Cmd {
 			PID:     pid,
 			Verbose: true,
 			Tree:    true,
+			Quick:   true,
 		})
 		if err != nil {
 			return err
@@ -144,6 +147,17 @@ func (m MainModel) fetchProcessDetail(pid int) tea.Cmd {
 	}
 }
 
+// fetchThreads samples pid's threads for the threads sub-view.
+func (m MainModel) fetchThreads(pid int) tea.Cmd {
+	return func() tea.Msg {
+		threads, err := proc.SampleThreads(pid, pipeline.ThreadSampleInterval)
+		if err != nil {
+			return threadsMsg{pid: pid}
+		}
+		return threadsMsg{pid: pid, threads: threads}
+	}
+}
+
 type processSorter struct {
 	procs []model.Process
 	keys  []string // pre-computed lowercase keys (nil for non-string sorts)
@@ -532,6 +546,12 @@ func (m *MainModel) updateEnvViewport() {
 	res := *m.selectedDetail
 	var b strings.Builder
 
+	if m.showThreads {
+		renderThreadsPane(&b, m.threads, m.envViewport.Width)
+		m.envViewport.SetContent(b.String())
+		return
+	}
+
 	if len(res.Process.Env) > 0 {
 		for _, env := range res.Process.Env {
 			fmt.Fprintf(&b, "%s\n", output.SanitizeTerminalLine(env))
@@ -938,3 +958,41 @@ func (m *MainModel) updateLockTable() {
 }
 
 const openFilesDisplayCap = 100
+
+// renderThreadsPane lays the thread sample out for the narrow side pane: one
+// unwrapped row per thread, busiest first, with the name and wait channel
+// truncated to fit.
+func renderThreadsPane(b *strings.Builder, threads []model.Thread, width int) {
+	dimStyle := lipgloss.NewStyle().Foreground(colorMuted)
+	if threads == nil {
+		fmt.Fprintf(b, "%s\n", dimStyle.Render("Sampling threads..."))
+		return
+	}
+	if len(threads) == 0 {
+		fmt.Fprintf(b, "%s\n", dimStyle.Render("No thread information available."))
+		return
+	}
+
+	// TID, CPU%, state and CPU time take a fixed 30 columns; the name and
+	// wait channel share the rest.
+	rest := width - 30
+	if rest < 16 {
+		rest = 16
+	}
+	nameWidth := rest / 2
+	waitWidth := rest - nameWidth - 1
+
+	fmt.Fprintf(b, "%s\n", dimStyle.Render(fmt.Sprintf("%-8s %6s %1s %10s  %-*s %s", "TID", "CPU%", "S", "TIME", nameWidth, "NAME", "WAIT")))
+	busyStyle := lipgloss.NewStyle().Foreground(colorError)
+	for _, t := range threads {
+		row := fmt.Sprintf("%-8d %6.1f %1s %10s  %-*s %s",
+			t.TID, t.CPUPercent, t.State, output.FormatCPUTime(t.CPUSeconds),
+			nameWidth, truncate(output.SanitizeTerminalLine(t.Name), nameWidth),
+			truncate(output.SanitizeTerminalLine(t.WaitChannel), waitWidth))
+		row = strings.TrimRight(row, " ")
+		if t.CPUPercent >= output.BusyThreadPercent {
+			row = busyStyle.Render(row)
+		}
+		fmt.Fprintf(b, "%s\n", row)
+	}
+}
@@ -202,6 +202,11 @@ type MainModel struct {
 	treeAncestry  []model.Process
 	treeTargetPID int
 
+	// Threads sub-view: replaces the env pane in process detail, refreshed
+	// on every tick while open.
+	showThreads bool
+	threads     []model.Thread
+
 	// Process action state
 	actionMenuOpen bool
 	pendingAction  actionKind
@@ -13,6 +13,12 @@ import (
 
 type treeMsg model.Result
 
+// threadsMsg carries a thread sample for the process whose detail is open.
+type threadsMsg struct {
+	pid     int
+	threads []model.Thread
+}
+
 type debounceMsg struct {
 	id  int
 	pid int
@@ -49,6 +55,8 @@ func (m MainModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		return m.handleLockList(msg)
 	case treeMsg:
 		return m.handleTree(msg)
+	case threadsMsg:
+		return m.handleThreads(msg)
 	case model.Result:
 		return m.handleResult(msg)
 	case *model.ContainerMatch:
@@ -74,6 +82,9 @@ func (m MainModel) handleTick(msg tickMsg) (tea.Model, tea.Cmd) {
 			cmd = tea.Batch(cmd, m.refreshLocks())
 		}
 	}
+	if m.state == stateDetail && m.showThreads && m.selectedDetail != nil {
+		cmd = tea.Batch(cmd, m.fetchThreads(m.selectedDetail.Process.PID))
+	}
 	return m, tea.Batch(cmd, waitTick())
 }
 
@@ -579,6 +590,16 @@ func (m MainModel) handleResult(msg model.Result) (tea.Model, tea.Cmd) {
 	return m, nil
 }
 
+func (m MainModel) handleThreads(msg threadsMsg) (tea.Model, tea.Cmd) {
+	// Drop samples that arrive after the user left the view or moved on.
+	if !m.showThreads || m.selectedDetail == nil || m.selectedDetail.Process.PID != msg.pid {
+		return m, nil
+	}
+	m.threads = msg.threads
+	m.updateEnvViewport()
+	return m, nil
+}
+
 func (m MainModel) handleContainerDetail(msg *model.ContainerMatch) (tea.Model, tea.Cmd) {
 	m.selectedContainer = msg
 	m.selectedDetail = nil
@@ -1337,6 +1358,8 @@ func (m MainModel) handleDetailKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		m.selectedDetail = nil
 		m.selectedContainer = nil
 		m.detailFocus = focusDetail
+		m.showThreads = false
+		m.threads = nil
 		m.actionMenuOpen = false
 		m.pendingAction = actionNone
 		m.reniceInput.SetValue("")
@@ -1350,6 +1373,18 @@ func (m MainModel) handleDetailKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 			m.actionMenuOpen = true
 		}
 		return m, nil
+	case "t", "T":
+		if m.selectedDetail == nil {
+			return m, nil
+		}
+		m.showThreads = !m.showThreads
+		m.threads = nil
+		m.envViewport.GotoTop()
+		m.updateEnvViewport()
+		if m.showThreads {
+			return m, m.fetchThreads(m.selectedDetail.Process.PID)
+		}
+		return m, nil
 	case "left", "h", "H":
 		m.detailFocus = focusDetail
 		return m, nil
@@ -2,6 +2,7 @@ package tui
 
 import (
 	"fmt"
+	"strings"
 	"testing"
 	"time"
 
@@ -281,3 +282,44 @@ func TestDetailKeyNavigation(t *testing.T) {
 		}
 	})
 }
+
+func TestDetailThreadsToggle(t *testing.T) {
+	m := InitialModel("test")
+	m.state = stateDetail
+	m.width, m.height = 160, 40
+	m.envViewport.Width, m.envViewport.Height = 60, 20
+	m.selectedDetail = &model.Result{Process: model.Process{PID: 42, Env: []string{"HOME=/root"}}}
+
+	m, cmd := step(t, m, keyRunes("t"))
+	if !m.showThreads || cmd == nil {
+		t.Fatalf("t should open the threads pane and start a sample; showThreads=%v cmd=%v", m.showThreads, cmd != nil)
+	}
+
+	// A stale sample for another PID is ignored.
+	m, _ = step(t, m, threadsMsg{pid: 7, threads: []model.Thread{{TID: 7}}})
+	if m.threads != nil {
+		t.Errorf("sample for another pid should be dropped, got %+v", m.threads)
+	}
+
+	m, _ = step(t, m, threadsMsg{pid: 42, threads: []model.Thread{
+		{TID: 43, Name: "worker-1", State: "R", CPUPercent: 97},
+		{TID: 42, Name: "main", State: "S", WaitChannel: "do_epoll_wait"},
+	}})
+	if len(m.threads) != 2 {
+		t.Fatalf("threads = %+v, want the sample", m.threads)
+	}
+	view := m.View()
+	for _, want := range []string{"Threads (2)", "worker-1", "do_epoll_wait", "t: Env"} {
+		if !strings.Contains(view, want) {
+			t.Errorf("view missing %q", want)
+		}
+	}
+
+	m, _ = step(t, m, keyRunes("t"))
+	if m.showThreads || m.threads != nil {
+		t.Error("second t should return to the env pane")
+	}
+	if !strings.Contains(m.View(), "Environment Variables") {
+		t.Error("env pane should be back")
+	}
+}
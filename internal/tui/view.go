@@ -371,6 +371,12 @@ func (m MainModel) viewProcessDetail(outerStyle lipgloss.Style) string {
 	}
 
 	envTitle := "Environment Variables"
+	if m.showThreads {
+		envTitle = "Threads"
+		if len(m.threads) > 0 {
+			envTitle = fmt.Sprintf("Threads (%d)", len(m.threads))
+		}
+	}
 	if !m.envViewport.AtTop() && !m.envViewport.AtBottom() {
 		envTitle += " ↕"
 	} else if !m.envViewport.AtTop() {
@@ -402,6 +408,10 @@ func (m MainModel) viewProcessDetail(outerStyle lipgloss.Style) string {
 	}
 
 	var helpText string
+	paneToggle := "Threads"
+	if m.showThreads {
+		paneToggle = "Env"
+	}
 	pid := 0
 	if m.selectedDetail != nil {
 		pid = m.selectedDetail.Process.PID
@@ -423,9 +433,9 @@ func (m MainModel) viewProcessDetail(outerStyle lipgloss.Style) string {
 		helpText = errorStyle.Render(m.statusMsg)
 	default:
 		if actionsSupported {
-			helpText = "a: Actions | Esc/q: Back | Tab: Focus | Up/Down: Scroll"
+			helpText = "a: Actions | t: " + paneToggle + " | Esc/q: Back | Tab: Focus | Up/Down: Scroll"
 		} else {
-			helpText = "Esc/q: Back | Tab: Focus | Up/Down: Scroll"
+			helpText = "t: " + paneToggle + " | Esc/q: Back | Tab: Focus | Up/Down: Scroll"
 		}
 	}
 	footerContent := helpText
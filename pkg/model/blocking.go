@@ -0,0 +1,67 @@
+package model
+
+// BlockingInfo describes what a sleeping process is waiting for: the kernel
+// function it sleeps in, the system call it is inside and the file, socket,
+// lock or other object that call is waiting on.
+type BlockingInfo struct {
+	// Scheduler state letter: S (interruptible sleep) or D (uninterruptible).
+	State string
+
+	// Kernel function the process sleeps in, from /proc/<pid>/wchan.
+	WaitChannel string `json:",omitempty"`
+
+	// System call the process is blocked in ("read", "futex", or
+	// "syscall 451" when the number is not known for this architecture) and
+	// its raw arguments in hex. Empty when /proc/<pid>/syscall is unreadable
+	// or the process sleeps outside a system call, e.g. in a page fault.
+	Syscall     string   `json:",omitempty"`
+	SyscallArgs []string `json:",omitempty"`
+
+	// Kernel stack, innermost frame first. Readable by root only.
+	Stack []string `json:",omitempty"`
+
+	// Human-readable description of the object the call waits on, e.g.
+	// "file /srv/data.bin", "TCP socket 10.0.0.5:41022 -> 10.0.0.9:5432" or
+	// "lock on /var/lib/dpkg/lock held by PID 812 (apt)".
+	WaitingOn string `json:",omitempty"`
+
+	// Descriptor the blocking call operates on, when it takes one.
+	FD *FilePosition `json:",omitempty"`
+
+	// Network or FUSE filesystem the process is blocked on, with its mount
+	// point when known (FSType as in /proc/<pid>/mountinfo, e.g. "nfs4" or
+	// "fuse.sshfs").
+	FSType string `json:",omitempty"`
+	Mount  string `json:",omitempty"`
+
+	// For a process in D state or waiting on a network filesystem: how many
+	// samples were taken, and how many in a row found it still in
+	// uninterruptible sleep and still asleep in the same system call.
+	Samples                int `json:",omitempty"`
+	UninterruptibleSamples int `json:",omitempty"`
+	SameCallSamples        int `json:",omitempty"`
+
+	// Offsets of the regular files the process has open, from
+	// /proc/<pid>/fdinfo. Collected in verbose mode only.
+	Files []FilePosition `json:",omitempty"`
+}
+
+// FilePosition is an open file descriptor with its current offset.
+type FilePosition struct {
+	FD     int
+	Path   string
+	Pos    int64
+	Access string // "R", "W" or "RW", as in the open-files listing
+}
+
+// Stuck reports whether the process stayed in uninterruptible sleep for
+// every sample taken.
+func (b *BlockingInfo) Stuck() bool {
+	return b != nil && b.State == "D" && b.Samples > 1 && b.UninterruptibleSamples == b.Samples
+}
+
+// StuckOnFS reports whether the process waits on a network or FUSE
+// filesystem and stayed in the same system call for every sample taken.
+func (b *BlockingInfo) StuckOnFS() bool {
+	return b != nil && b.FSType != "" && b.Samples > 1 && b.SameCallSamples == b.Samples
+}
@@ -0,0 +1,73 @@
+package model
+
+// CgroupStats holds cgroup v2 resource limits, usage and pressure for the
+// cgroup a process belongs to. Limits are the effective ones, i.e. the
+// tightest value found walking up the hierarchy; 0 means unlimited.
+type CgroupStats struct {
+	Path string
+
+	MemoryCurrent uint64
+	MemoryMax     uint64
+	// Set when MemoryMax is inherited from an ancestor cgroup: that cgroup's
+	// path and its own memory.current, which is what the limit applies to.
+	MemoryMaxPath    string `json:",omitempty"`
+	MemoryMaxCurrent uint64 `json:",omitempty"`
+	OOMEvents        uint64 // memory.events "oom": times the limit was hit
+	OOMKills         uint64 // memory.events "oom_kill": processes killed
+
+	CPUQuota      uint64 // cpu.max quota in microseconds per period
+	CPUPeriod     uint64 // cpu.max period in microseconds
+	NrPeriods     uint64 // cpu.stat enforcement periods elapsed
+	NrThrottled   uint64 // cpu.stat periods in which the cgroup was throttled
+	ThrottledUsec uint64 // cpu.stat total time spent throttled
+
+	PidsCurrent uint64
+	PidsMax     uint64
+
+	// Pressure stall information; nil when the kernel doesn't expose PSI.
+	CPUPressure    *Pressure `json:",omitempty"`
+	MemoryPressure *Pressure `json:",omitempty"`
+	IOPressure     *Pressure `json:",omitempty"`
+}
+
+// Pressure is one PSI file: the percentage of wall time in which some (or
+// all, "full") tasks were stalled on the resource, averaged over 10s, 60s
+// and 300s windows.
+type Pressure struct {
+	Some10, Some60, Some300 float64
+	Full10, Full60, Full300 float64
+}
+
+// CgroupNode is one cgroup of a --cgroup tree: the processes directly in it
+// and the cgroups below it that hold processes.
+type CgroupNode struct {
+	Path string
+	// Kind is "slice", "scope" or "service" for a systemd unit, "container"
+	// for a container runtime's cgroup, or empty for any other cgroup.
+	Kind      string          `json:",omitempty"`
+	Processes []CgroupProcess `json:",omitempty"`
+	Children  []CgroupNode    `json:",omitempty"`
+	// Total is the number of processes in the whole subtree.
+	Total int
+}
+
+// CgroupProcess is a process in a CgroupNode.
+type CgroupProcess struct {
+	PID     int
+	Command string
+	Cmdline string `json:",omitempty"`
+	User    string `json:",omitempty"`
+}
+
+// PIDs returns every process in the subtree, each cgroup's processes before
+// those of the cgroups below it.
+func (n CgroupNode) PIDs() []int {
+	pids := make([]int, 0, n.Total)
+	for _, p := range n.Processes {
+		pids = append(pids, p.PID)
+	}
+	for _, c := range n.Children {
+		pids = append(pids, c.PIDs()...)
+	}
+	return pids
+}
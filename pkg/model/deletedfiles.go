@@ -0,0 +1,61 @@
+package model
+
+// DeletedFilesAudit lists the processes holding open files that have been
+// deleted: space df counts as used but du can't find, freed only once every
+// holder closes the file or exits.
+type DeletedFilesAudit struct {
+	// Processes holding deleted files, largest reclaimable total first.
+	Processes []DeletedFileHolder
+	// Reclaimable space per filesystem, largest first.
+	Filesystems []DeletedFilesystem
+	// Space freed once every holder is restarted; files held by several
+	// processes count once.
+	TotalBytes uint64
+
+	// Number of processes inspected, and of those whose file descriptors
+	// could not be read (other users' processes when not running as root).
+	Scanned    int
+	Unreadable int `json:",omitempty"`
+}
+
+// DeletedFileHolder is a process holding deleted files open.
+type DeletedFileHolder struct {
+	PID     int
+	Command string
+	User    string `json:",omitempty"`
+	// Unit, container or session that started the process, as in the
+	// --needs-restart report.
+	Source    SourceType `json:",omitempty"`
+	StartedBy string     `json:",omitempty"`
+	// Disk space of the deleted files it holds, each file counted once.
+	Bytes uint64
+	// Its deleted files, largest first.
+	Files []DeletedFile
+}
+
+// DeletedFile is a deleted file held open through a descriptor.
+type DeletedFile struct {
+	// Lowest descriptor the process holds the file through.
+	FD   int
+	Path string
+	// Apparent size (st_size) and the disk space actually allocated to the
+	// file, which is what deleting it frees; they differ for sparse files.
+	Size      int64
+	Allocated uint64
+	// Mount point of the file's filesystem, as the holder sees it, and its
+	// type.
+	Mount  string `json:",omitempty"`
+	FSType string `json:",omitempty"`
+	// Other processes holding the same file; its space is freed only when
+	// they close it too.
+	SharedWith []int `json:",omitempty"`
+}
+
+// DeletedFilesystem is the reclaimable space of deleted files on one
+// filesystem.
+type DeletedFilesystem struct {
+	Mount  string
+	FSType string `json:",omitempty"`
+	Bytes  uint64
+	Files  int
+}
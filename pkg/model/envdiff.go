@@ -0,0 +1,28 @@
+package model
+
+// EnvDiff is a process's environment compared to a baseline: the
+// environment of its parent, or the environment its systemd unit declares.
+type EnvDiff struct {
+	// "parent" or "unit".
+	Baseline string
+	// PID and command of the parent, or the unit name.
+	BaselinePID  int `json:",omitempty"`
+	BaselineName string
+
+	// Variables added, changed or removed relative to the baseline, in that
+	// order and sorted by name within each group.
+	Changes []EnvChange
+	// Number of variables identical in both.
+	Unchanged int
+}
+
+// EnvChange is one variable that differs from the baseline.
+type EnvChange struct {
+	Name string
+	// "added", "changed" or "removed".
+	Kind string
+	// Value in the process; empty for a removed variable.
+	Value string `json:",omitempty"`
+	// Value in the baseline; empty for an added variable.
+	BaselineValue string `json:",omitempty"`
+}
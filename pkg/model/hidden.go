@@ -0,0 +1,26 @@
+package model
+
+// HiddenAudit is the result of cross-checking the /proc directory listing
+// against direct probes of every PID: processes that exist but are left out
+// of the listing are a classic rootkit symptom.
+type HiddenAudit struct {
+	Hidden []HiddenProcess
+
+	// Number of PIDs in the /proc listing, and the top of the probed range
+	// (kernel.pid_max).
+	Listed int
+	PIDMax int
+}
+
+// HiddenProcess is a process that exists but is missing from the /proc
+// listing.
+type HiddenProcess struct {
+	PID int
+	// How it was found: "kill" (kill(pid, 0) reaches it), "stat"
+	// (/proc/<pid> can be opened by number) or "task" (a listed process's
+	// /proc/<pid>/task/*/children names it).
+	FoundBy []string
+	// Ancestry from init down to the process itself, when its /proc entries
+	// can still be read.
+	Ancestry []Process `json:",omitempty"`
+}
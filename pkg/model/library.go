@@ -0,0 +1,21 @@
+package model
+
+// Library is a shared object mapped into a process's address space.
+type Library struct {
+	Path string
+
+	// GNU build ID (hex) of the mapped file, when its ELF notes are readable.
+	BuildID string `json:",omitempty"`
+
+	// Deleted is set when the mapped file has been unlinked, Replaced when
+	// the path now names a different file (e.g. a package upgrade installed
+	// a new copy). Either way the process runs the old code until restarted.
+	Deleted  bool `json:",omitempty"`
+	Replaced bool `json:",omitempty"`
+}
+
+// Stale reports whether the process still runs a copy of the library that is
+// no longer on disk.
+func (l Library) Stale() bool {
+	return l.Deleted || l.Replaced
+}
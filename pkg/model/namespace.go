@@ -0,0 +1,12 @@
+package model
+
+// Namespace describes one Linux namespace a process belongs to.
+type Namespace struct {
+	Type  string // pid, net, mnt, uts, ipc, user, cgroup
+	Inode uint64
+
+	// True when the namespace differs from the one PID 1 (or, when that is
+	// unreadable, witr) is in, i.e. the process is isolated from the host
+	// along this axis.
+	Isolated bool
+}
@@ -0,0 +1,53 @@
+package model
+
+// PathUsage lists the processes keeping a directory or mount point busy,
+// grouped by how they use it: the answer to "umount: target is busy".
+type PathUsage struct {
+	Path string
+	// Mount the path is on (the path itself for a mount point) and its
+	// filesystem type.
+	Mount  string `json:",omitempty"`
+	FSType string `json:",omitempty"`
+
+	// Groups in the order "cwd", "open", "mapped", "root"; empty groups
+	// are left out.
+	Groups []PathUsageGroup
+
+	// Number of processes inspected, and of those whose files could not be
+	// read (other users' processes when not running as root).
+	Scanned    int
+	Unreadable int `json:",omitempty"`
+}
+
+// PathUsageGroup is the processes using a path in one way.
+type PathUsageGroup struct {
+	// "cwd" (working directory beneath the path), "open" (open file
+	// descriptors), "mapped" (memory-mapped executables and libraries) or
+	// "root" (chroot or container root beneath the path).
+	Reason    string
+	Processes []PathUser
+}
+
+// PathUser is a process using files beneath the path.
+type PathUser struct {
+	PID     int
+	Command string
+	User    string `json:",omitempty"`
+	// Unit, container or session that started the process, as in the
+	// --needs-restart report.
+	Source    SourceType `json:",omitempty"`
+	StartedBy string     `json:",omitempty"`
+	// Files or directories beneath the path it uses this way.
+	Paths []string
+}
+
+// Count returns the number of distinct processes across all groups.
+func (u PathUsage) Count() int {
+	seen := make(map[int]bool)
+	for _, g := range u.Groups {
+		for _, p := range g.Processes {
+			seen[p.PID] = true
+		}
+	}
+	return len(seen)
+}
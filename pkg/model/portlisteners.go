@@ -0,0 +1,32 @@
+package model
+
+// PortListeners lists every process listening on the ports a --port query
+// selects when it covers a range or more than one process.
+type PortListeners struct {
+	Query     string
+	Listeners []PortListener
+}
+
+// PortListener is a process listening on one protocol, address and port.
+type PortListener struct {
+	Protocol string // "tcp", "tcp6", "udp" or "udp6"
+	Address  string
+	Port     int
+
+	PID     int
+	Command string
+	User    string `json:",omitempty"`
+	// Unit, container or session that started the process, as in the
+	// --needs-restart report.
+	Source    SourceType `json:",omitempty"`
+	StartedBy string     `json:",omitempty"`
+	// Why the process is running: its ancestry, oldest first, ending with
+	// the process itself.
+	Chain []ChainLink
+}
+
+// ChainLink is one process in an ancestry chain.
+type ChainLink struct {
+	PID     int
+	Command string
+}
@@ -0,0 +1,86 @@
+package model
+
+import (
+	"net"
+	"strconv"
+	"strings"
+)
+
+// PortQuery is a parsed --port value: a port or range of ports, optionally
+// limited to one protocol and one bind address, as in "udp/53",
+// "127.0.0.1:6379", "[::1]:8080" or "8000-8100".
+type PortQuery struct {
+	Protocol string // "tcp", "udp", or "" for both
+	Address  string // IP address, or "" for any
+	Low      int
+	High     int // equal to Low for a single port
+}
+
+// IsRange reports whether the query covers more than one port.
+func (q PortQuery) IsRange() bool {
+	return q.High > q.Low
+}
+
+// IsPlain reports whether the query is a bare port number.
+func (q PortQuery) IsPlain() bool {
+	return q.Protocol == "" && q.Address == "" && !q.IsRange()
+}
+
+// String formats the query the way it is parsed.
+func (q PortQuery) String() string {
+	s := strconv.Itoa(q.Low)
+	if q.IsRange() {
+		s += "-" + strconv.Itoa(q.High)
+	}
+	switch {
+	case strings.Contains(q.Address, ":"):
+		s = "[" + q.Address + "]:" + s
+	case q.Address != "":
+		s = q.Address + ":" + s
+	}
+	if q.Protocol != "" {
+		s = q.Protocol + "/" + s
+	}
+	return s
+}
+
+// MatchesPort reports whether port is within the query's range.
+func (q PortQuery) MatchesPort(port int) bool {
+	return port >= q.Low && port <= q.High
+}
+
+// MatchesProtocol reports whether a socket protocol label such as "TCP",
+// "TCP6" or "UDPv6" is the queried one.
+func (q PortQuery) MatchesProtocol(protocol string) bool {
+	return q.Protocol == "" || strings.HasPrefix(strings.ToLower(protocol), q.Protocol)
+}
+
+// MatchesAddress reports whether a socket bound to addr receives traffic
+// for the queried address: bound to it exactly, or to the wildcard address
+// of its family ("0.0.0.0", "::", "*"). A dual-stack "::" socket also
+// receives IPv4 traffic. exact is false for a wildcard match, which the
+// kernel only uses when no socket is bound to the address itself.
+func (q PortQuery) MatchesAddress(addr string) (ok, exact bool) {
+	if q.Address == "" {
+		return true, true
+	}
+	want := net.ParseIP(q.Address)
+	addr = strings.TrimSuffix(strings.TrimPrefix(addr, "["), "]")
+	if i := strings.IndexByte(addr, '%'); i >= 0 {
+		addr = addr[:i] // zone
+	}
+	if addr == "*" {
+		return true, false
+	}
+	got := net.ParseIP(addr)
+	if want == nil || got == nil {
+		return false, false
+	}
+	if got.Equal(want) {
+		return true, true
+	}
+	if got.IsUnspecified() && (got.To4() == nil || want.To4() != nil) {
+		return true, false
+	}
+	return false, false
+}
@@ -30,6 +30,11 @@ type Process struct {
 	// CLOSE_WAIT, etc.). Each entry carries protocol and state.
 	Sockets []Socket
 
+	// Unix domain sockets the process serves or is connected to by path,
+	// with the processes on the other end. Populated for the analysed target
+	// only, on Linux.
+	UnixSockets []UnixSocket `json:",omitempty"`
+
 	// Health status ("healthy", "zombie", "stopped", "high-cpu", "high-mem")
 	Health string
 
@@ -41,9 +46,46 @@ type Process struct {
 	// True if the executable was deleted after the process started
 	ExeDeleted bool
 
+	// Shared objects mapped from /proc/<pid>/maps, with deleted or replaced
+	// ones flagged. Populated for the analysed target only, on Linux.
+	Libraries []Library `json:",omitempty"`
+
+	// What the process is waiting for while it sleeps (S or D state).
+	// Populated for the analysed target only, on Linux.
+	Blocking *BlockingInfo `json:",omitempty"`
+
+	// PID inside the process's innermost PID namespace (Linux NSpid), set only
+	// when it differs from PID, e.g. for a containerised process.
+	NSPid int `json:",omitempty"`
+
+	// Namespaces the process belongs to, with the ones that differ from the
+	// host's marked. Populated for the analysed target only.
+	Namespaces []Namespace `json:",omitempty"`
+	// Whose namespaces Isolated is relative to: "pid 1", or "witr" when
+	// PID 1's can't be read and witr's own stand in for the host's.
+	NamespacesComparedTo string `json:",omitempty"`
+
+	// cgroup v2 limits, usage and pressure. Populated for the analysed target
+	// only, on Linux hosts with the unified hierarchy.
+	Cgroup *CgroupStats `json:",omitempty"`
+
 	// Linux capabilities (e.g., CAP_NET_BIND_SERVICE, CAP_SYS_ADMIN)
 	Capabilities []string `json:",omitempty"`
 
+	// The fields of /proc/<pid>/status as read with the process, so the
+	// target's later diagnostics don't read the file again. Linux only.
+	Status map[string]string `json:"-"`
+
+	// Credentials, seccomp, no_new_privs and LSM confinement. Populated for
+	// the analysed target only, on Linux.
+	Security *SecurityContext `json:",omitempty"`
+
+	// Scheduling policy, affinity, I/O priority and OOM ranking, and the
+	// resource limits with current usage where measurable. Populated for the
+	// analysed target only, on Linux.
+	Scheduling *SchedulingInfo `json:",omitempty"`
+	Limits     []ResourceLimit `json:",omitempty"`
+
 	// Extended information for verbose output
 	Memory      MemoryInfo `json:",omitempty"`
 	IO          IOStats    `json:",omitempty"`
@@ -52,6 +94,10 @@ type Process struct {
 	FDLimit     uint64     `json:",omitempty"`
 	Children    []int      `json:",omitempty"`
 	ThreadCount int        `json:",omitempty"`
+
+	// Per-thread breakdown, busiest first. Populated only for the threads
+	// view, on Linux.
+	Threads []Thread `json:",omitempty"`
 }
 
 // MemoryInfo contains detailed memory information
@@ -0,0 +1,68 @@
+package model
+
+import (
+	"net/netip"
+	"strconv"
+	"strings"
+)
+
+// RemoteQuery is a parsed --remote value: the remote addresses a connection
+// may go to, as prefixes so that a host, each address a hostname resolves
+// to and a CIDR are matched alike, and optionally the remote port.
+type RemoteQuery struct {
+	Host     string // address, hostname or CIDR as given
+	Prefixes []netip.Prefix
+	Port     int // 0 for any port
+}
+
+// String formats the query the way it was given.
+func (q RemoteQuery) String() string {
+	if q.Port == 0 {
+		return q.Host
+	}
+	host := q.Host
+	if strings.Contains(host, ":") {
+		host = "[" + host + "]"
+	}
+	return host + ":" + strconv.Itoa(q.Port)
+}
+
+// Matches reports whether a connection to addr:port goes to the queried
+// endpoint. IPv4 addresses seen through an IPv6 socket ("::ffff:10.0.0.1")
+// match IPv4 prefixes.
+func (q RemoteQuery) Matches(addr string, port int) bool {
+	if q.Port != 0 && port != q.Port {
+		return false
+	}
+	ip, err := netip.ParseAddr(strings.TrimSuffix(strings.TrimPrefix(addr, "["), "]"))
+	if err != nil {
+		return false
+	}
+	ip = ip.WithZone("").Unmap()
+	for _, p := range q.Prefixes {
+		if p.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
+
+// RemoteConnections lists the processes with a socket connected to the
+// endpoint of a RemoteQuery.
+type RemoteConnections struct {
+	Query     string
+	Processes []RemoteConnector
+
+	// Matching connections no process can be shown for: closed ones still
+	// lingering in TIME_WAIT, and the number held by processes whose
+	// descriptors could not be read (other users' processes when not
+	// running as root).
+	RecentlyClosed bool `json:",omitempty"`
+	Unowned        int  `json:",omitempty"`
+}
+
+// RemoteConnector is a process and its connections to the endpoint.
+type RemoteConnector struct {
+	PID     int
+	Sockets []Socket
+}
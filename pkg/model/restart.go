@@ -0,0 +1,37 @@
+package model
+
+// RestartAudit lists the processes still running code that has been deleted
+// or replaced on disk, grouped by what started them: restarting a group's
+// unit, container or session is what picks up the new files.
+type RestartAudit struct {
+	Groups []RestartGroup
+
+	// Number of processes inspected, and of those whose mappings could not
+	// be read (other users' processes when not running as root).
+	Scanned    int
+	Unreadable int `json:",omitempty"`
+}
+
+// RestartGroup is one unit, container or user session needing a restart.
+type RestartGroup struct {
+	// "service", "container", "session" or "other".
+	Kind   string
+	Source SourceType
+	// Unit name, container name or session description.
+	Name string
+	// Command that restarts the group, when witr knows it (e.g.
+	// "systemctl restart nginx.service").
+	Restart   string `json:",omitempty"`
+	Processes []RestartProcess
+}
+
+// RestartProcess is a process running stale code.
+type RestartProcess struct {
+	PID     int
+	Command string
+	User    string `json:",omitempty"`
+	// Path of the executable, if it was deleted or replaced.
+	Executable string `json:",omitempty"`
+	// Deleted or replaced shared libraries.
+	Libraries []Library `json:",omitempty"`
+}
@@ -18,4 +18,19 @@ type Result struct {
 
 	// FileContext holds file descriptor and lock info
 	FileContext *FileContext
+
+	// Connections holds the process's sockets connected to a --remote
+	// endpoint
+	Connections []Socket `json:",omitempty"`
+
+	// MatchedEnv holds the KEY=VALUE environment entry that matched an
+	// --env-match query
+	MatchedEnv string `json:",omitempty"`
+
+	// Unit holds the state and other processes of the systemd unit named
+	// by a --unit target
+	Unit *UnitStatus `json:",omitempty"`
+
+	// EnvDiff holds the environment compared to the parent or unit (--env-diff)
+	EnvDiff *EnvDiff `json:",omitempty"`
 }
@@ -0,0 +1,58 @@
+package model
+
+import "strconv"
+
+// SchedulingInfo describes how the kernel schedules a process: CPU policy
+// and priorities, where it may run and allocate memory, its I/O priority
+// and how the OOM killer ranks it.
+type SchedulingInfo struct {
+	// SCHED_OTHER, SCHED_BATCH, SCHED_IDLE, SCHED_FIFO, SCHED_RR or
+	// SCHED_DEADLINE; RTPriority is only set for the real-time policies.
+	Policy     string
+	RTPriority int `json:",omitempty"`
+	Nice       int
+
+	// CPUs and NUMA nodes the process may use, as ranges ("0-3,8"), and the
+	// NUMA memory policy ("default", "interleave:0-1", "bind:0", ...).
+	CPUAffinity string `json:",omitempty"`
+	MemoryNodes string `json:",omitempty"`
+	NUMAPolicy  string `json:",omitempty"`
+
+	// I/O scheduling class ("realtime", "best-effort" or "idle") and level,
+	// 0 (highest) to 7. IOPriorityFromNice is set when no class was chosen
+	// explicitly and the kernel derives both from the CPU nice value.
+	IOClass            string
+	IOPriority         int
+	IOPriorityFromNice bool `json:",omitempty"`
+
+	OOMScore    int
+	OOMScoreAdj int
+}
+
+// ResourceLimit is one row of /proc/<pid>/limits.
+type ResourceLimit struct {
+	Name string // e.g. "Max open files"
+	Soft string // a number, or "unlimited"
+	Hard string
+	Unit string `json:",omitempty"` // "bytes", "files", "seconds", ...
+
+	// Current use of the limited resource in Unit, when witr can measure it.
+	Usage *uint64 `json:",omitempty"`
+}
+
+// SoftValue returns the soft limit as a number; ok is false when it is
+// unlimited.
+func (l ResourceLimit) SoftValue() (uint64, bool) {
+	return parseLimit(l.Soft)
+}
+
+// HardValue returns the hard limit as a number; ok is false when it is
+// unlimited.
+func (l ResourceLimit) HardValue() (uint64, bool) {
+	return parseLimit(l.Hard)
+}
+
+func parseLimit(s string) (uint64, bool) {
+	v, err := strconv.ParseUint(s, 10, 64)
+	return v, err == nil
+}
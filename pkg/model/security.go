@@ -0,0 +1,45 @@
+package model
+
+import "strconv"
+
+// Credential is a numeric user or group ID with its resolved name, if any.
+type Credential struct {
+	ID   int
+	Name string `json:",omitempty"`
+}
+
+// String renders the ID followed by its name in parentheses, e.g. "0 (root)".
+func (c Credential) String() string {
+	if c.Name != "" {
+		return strconv.Itoa(c.ID) + " (" + c.Name + ")"
+	}
+	return strconv.Itoa(c.ID)
+}
+
+// SecurityContext describes the credentials and confinement of a process:
+// the full UID/GID set, seccomp and no_new_privs state, its LSM label and
+// the capability sets beyond the effective one.
+type SecurityContext struct {
+	RealUID, EffectiveUID, SavedUID, FSUID Credential
+	RealGID, EffectiveGID, SavedGID, FSGID Credential
+	Groups                                 []Credential `json:",omitempty"`
+
+	// Whether the executable carries the setuid/setgid mode bits.
+	SetUIDExe bool `json:",omitempty"`
+	SetGIDExe bool `json:",omitempty"`
+
+	NoNewPrivs     bool
+	SeccompMode    string // "disabled", "strict" or "filter"
+	SeccompFilters int    `json:",omitempty"`
+
+	// Active Linux security module ("apparmor", "selinux") and the process's
+	// label under it, e.g. "/usr/sbin/nginx (enforce)" or "system_u:system_r:httpd_t:s0".
+	LSM      string `json:",omitempty"`
+	LSMLabel string `json:",omitempty"`
+
+	CapBounding []string `json:",omitempty"`
+	// Known capabilities missing from the bounding set, i.e. dropped for
+	// good by the process or whatever launched it.
+	CapBoundingDropped []string `json:",omitempty"`
+	CapAmbient         []string `json:",omitempty"`
+}
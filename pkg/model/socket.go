@@ -6,6 +6,48 @@ type Socket struct {
 	Address  string // 0.0.0.0, 127.0.0.1, ::
 	State    string
 	Protocol string
+
+	// Remote end of a connected socket; empty for listeners.
+	RemoteAddress string `json:",omitempty"`
+	RemotePort    int    `json:",omitempty"`
+
+	// Local process on the other end of a loopback or same-host connection.
+	Peer *SocketPeer `json:",omitempty"`
+
+	// Queue depths: unread and unacknowledged bytes for a connection, or the
+	// current and maximum accept backlog for a listener (the maximum needs
+	// sock_diag). Linux only.
+	RecvQ uint32 `json:",omitempty"`
+	SendQ uint32 `json:",omitempty"`
+
+	// Owning UID (decimal, like Inode) and cgroup v2 ID as recorded by the
+	// kernel. Linux only; CgroupID needs sock_diag.
+	UID      string `json:",omitempty"`
+	CgroupID uint64 `json:",omitempty"`
+}
+
+// SocketPeer identifies the process owning the other end of a connection.
+type SocketPeer struct {
+	PID     int
+	Command string
+}
+
+// UnixSocket is a unix domain socket held by a process: either one it
+// serves (a listening stream socket or a bound datagram socket) or a
+// connection it made to another process's named socket.
+type UnixSocket struct {
+	Inode string
+	Path  string // filesystem path, or "@name" for an abstract socket
+	Type  string // STREAM, DGRAM or SEQPACKET
+	State string // LISTEN, BOUND (datagram server) or ESTABLISHED
+
+	// Client reports whether the process is the connecting side, in which
+	// case Path is the server's socket and Peers holds the server.
+	Client bool `json:",omitempty"`
+
+	// Processes on the other end: the connected clients of a served socket,
+	// or the server of a client connection.
+	Peers []SocketPeer `json:",omitempty"`
 }
 
 // SocketInfo holds information about a socket's state
@@ -8,6 +8,15 @@ const (
 	TargetPort      TargetType = "port"
 	TargetFile      TargetType = "file"
 	TargetContainer TargetType = "container"
+	TargetSocket    TargetType = "socket"
+	TargetWatchers  TargetType = "watchers"
+	TargetRemote    TargetType = "remote"
+	TargetUnit      TargetType = "unit"
+	TargetCgroup    TargetType = "cgroup"
+	TargetUser      TargetType = "user"
+	TargetExe       TargetType = "exe"
+	TargetMatch     TargetType = "match"
+	TargetEnvMatch  TargetType = "env-match"
 )
 
 type Target struct {
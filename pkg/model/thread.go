@@ -0,0 +1,18 @@
+package model
+
+// Thread is one task of a process, as listed by /proc/<pid>/task.
+type Thread struct {
+	TID  int
+	Name string
+	// Scheduler state letter: R (running), S (sleeping), D (uninterruptible
+	// wait), T (stopped), Z (zombie), ...
+	State string
+	// User plus system CPU time consumed since the thread started, in seconds.
+	CPUSeconds float64
+	// Share of one CPU used over the sampling window, in percent.
+	CPUPercent float64
+	// CPU the thread last ran on.
+	Processor int
+	// Kernel function the thread is sleeping in; empty while it runs.
+	WaitChannel string `json:",omitempty"`
+}
@@ -0,0 +1,30 @@
+package model
+
+import "time"
+
+// UnitStatus is a systemd unit named by --unit: its state as systemd
+// reports it and the processes in its control group.
+type UnitStatus struct {
+	Name        string
+	Description string `json:",omitempty"`
+	UnitFile    string `json:",omitempty"`
+
+	// LoadState is "loaded", "not-found" or "masked"; ActiveState and
+	// SubState are as systemctl shows them ("active (running)", "failed
+	// (failed)"), and Result is how the last run ended ("success",
+	// "exit-code", "signal", ...). All empty when systemd's D-Bus API can't
+	// be reached and the unit was found through the cgroup filesystem.
+	LoadState   string `json:",omitempty"`
+	ActiveState string `json:",omitempty"`
+	SubState    string `json:",omitempty"`
+	Result      string `json:",omitempty"`
+
+	// Since is when the unit last changed state; zero when unknown.
+	Since    time.Time
+	Restarts int
+
+	ControlGroup string `json:",omitempty"`
+	MainPID      int    `json:",omitempty"`
+	// Members are the other processes in the unit's control group.
+	Members []Process `json:",omitempty"`
+}
@@ -0,0 +1,51 @@
+package model
+
+// UserSummary lists everything a user is running, grouped by what started
+// it, with the resources each group uses and the ports it listens on: what
+// stops working when the account goes away.
+type UserSummary struct {
+	User string
+	UID  string `json:",omitempty"`
+
+	Processes     int
+	CPUPercent    float64
+	MemoryRSS     uint64 // In bytes
+	MemoryPercent float64
+	Groups        []UserGroup
+}
+
+// UserGroup is the user's processes started by one unit, container or
+// session.
+type UserGroup struct {
+	// "service", "container", "session" or "other", as in RestartGroup.
+	Kind   string
+	Source SourceType
+	// Unit name, container name or session description.
+	Name string
+
+	CPUPercent    float64
+	MemoryRSS     uint64 // In bytes
+	MemoryPercent float64
+
+	// Processes, busiest first.
+	Processes []UserProcess
+	Listening []UserListener `json:",omitempty"`
+}
+
+// UserProcess is one of a user's processes.
+type UserProcess struct {
+	PID           int
+	Command       string
+	CPUPercent    float64
+	MemoryRSS     uint64 // In bytes
+	MemoryPercent float64
+}
+
+// UserListener is a port one of a user's processes listens on.
+type UserListener struct {
+	Protocol string // "tcp", "tcp6", "udp" or "udp6"
+	Address  string
+	Port     int
+	PID      int
+	Command  string
+}
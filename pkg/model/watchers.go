@@ -0,0 +1,42 @@
+package model
+
+// FileWatchers lists the processes holding inotify or fanotify watches that
+// report events for a path.
+type FileWatchers struct {
+	Path     string
+	Watchers []FileWatcher
+
+	// Number of processes inspected, and of those whose descriptors could
+	// not be read (other users' processes when not running as root).
+	Scanned    int
+	Unreadable int `json:",omitempty"`
+}
+
+// FileWatcher is a process with one or more watches covering the path.
+type FileWatcher struct {
+	PID     int
+	Command string
+	User    string `json:",omitempty"`
+	// Unit, container or session that started the process, as in the
+	// --needs-restart report.
+	Source    SourceType `json:",omitempty"`
+	StartedBy string     `json:",omitempty"`
+	Watches   []Watch
+}
+
+// Watch is one inotify watch or fanotify mark.
+type Watch struct {
+	// "inotify" or "fanotify".
+	API string
+	// Descriptor of the inotify or fanotify instance, and for inotify the
+	// watch descriptor within it.
+	FD int
+	WD int `json:",omitempty"`
+	// What the watch is placed on: "path" (the path itself), "parent" (its
+	// directory, which reports events for the entries in it), "mount" or
+	// "filesystem" (fanotify marks covering everything on them).
+	Scope string
+	// Event mask as the kernel reports it, and its event names.
+	Mask   uint64
+	Events []string
+}